@@ -0,0 +1,51 @@
+package cron
+
+import "time"
+
+// FailureBackoff stretches the interval of an entry after consecutive failed
+// runs, e.g. to spare a flaky dependency.  After n consecutive failures, the
+// job is run at the first activation which is at least the interval times the
+// Multiplier to the power of n after its last run, where the interval is the
+// one between its activations.  The stretched interval is capped at Max, and
+// the interval is restored by a run which succeeds.
+type FailureBackoff struct {
+	Multiplier float64
+	Max        time.Duration
+}
+
+// SetFailureBackoff stretches the interval of the entry with the given ID
+// after consecutive failed runs, by the backoff.  The activations in between
+// are skipped.  It returns an error if there is no such entry.
+func (c *Cron) SetFailureBackoff(id EntryID, backoff FailureBackoff) error {
+	return c.updateEntry(id, func(e *Entry, running bool) {
+		e.backoff = &backoff
+	})
+}
+
+// backedOff returns true if the activation of the entry is skipped by its
+// FailureBackoff.  The interval is the one of the schedule after the last run,
+// so that irregular schedules, like monthly ones, are not skipped.
+func (c *Cron) backedOff(e *Entry, activation time.Time) bool {
+	if e.backoff == nil || e.Prev.IsZero() {
+		return false
+	}
+	c.mu.Lock()
+	failures := e.Stats.ConsecutiveFailures
+	c.mu.Unlock()
+	if failures == 0 {
+		return false
+	}
+
+	next := e.next(e.Prev)
+	if next.IsZero() {
+		return false
+	}
+	delay := float64(next.Sub(e.Prev))
+	for i := 0; i < failures && (e.backoff.Max <= 0 || delay < float64(e.backoff.Max)); i++ {
+		delay *= e.backoff.Multiplier
+	}
+	if e.backoff.Max > 0 && delay > float64(e.backoff.Max) {
+		delay = float64(e.backoff.Max)
+	}
+	return activation.Before(e.Prev.Add(time.Duration(delay)))
+}
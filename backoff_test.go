@@ -0,0 +1,77 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFailureBackoff(t *testing.T) {
+	start := time.Date(2016, time.March, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		backoff  FailureBackoff
+		failures int // number of the first runs which fail
+		expected []time.Duration
+	}{
+		// The interval doubles after each failure, and is restored after the
+		// first run which succeeds.
+		{FailureBackoff{Multiplier: 2, Max: 24 * time.Hour}, 3,
+			[]time.Duration{1 * time.Hour, 3 * time.Hour, 7 * time.Hour, 15 * time.Hour, 16 * time.Hour}},
+
+		// The stretched interval is capped.
+		{FailureBackoff{Multiplier: 2, Max: 3 * time.Hour}, 10,
+			[]time.Duration{1 * time.Hour, 3 * time.Hour, 6 * time.Hour, 9 * time.Hour, 12 * time.Hour, 15 * time.Hour}},
+	}
+	for i, c := range tests {
+		runs := 0
+		cron := New(WithLocation(time.UTC), WithErrorHandler(func(run RunInfo) {}))
+		id, _ := cron.AddJob("@hourly", ErrorFuncJob(func(ctx context.Context) error {
+			if runs++; runs <= c.failures {
+				return errors.New("failed")
+			}
+			return nil
+		}))
+		if err := cron.SetFailureBackoff(id, c.backoff); err != nil {
+			t.Fatal(err)
+		}
+
+		activations, err := cron.Simulate(start, start.Add(16*time.Hour))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var actual []time.Duration
+		for _, a := range activations {
+			actual = append(actual, a.Time.Sub(start))
+		}
+		if len(actual) != len(c.expected) {
+			t.Errorf("%d: (expected) %v != %v (actual)", i, c.expected, actual)
+			continue
+		}
+		for j := range c.expected {
+			if actual[j] != c.expected[j] {
+				t.Errorf("%d: (expected) %v != %v (actual)", i, c.expected, actual)
+				break
+			}
+		}
+	}
+
+	// Without failures, no activations of irregular schedules are skipped.
+	for _, spec := range []string{"0 0 0 1 * *", "0 0 0 L * *"} {
+		cron := New(WithLocation(time.UTC))
+		id, _ := cron.AddFunc(spec, func() {})
+		cron.SetFailureBackoff(id, FailureBackoff{Multiplier: 2})
+		from := time.Date(2024, time.December, 31, 12, 0, 0, 0, time.UTC)
+		activations, err := cron.Simulate(from, from.AddDate(1, 0, 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(activations) != 12 {
+			t.Errorf("%s: (expected) 12 runs != %d (actual)", spec, len(activations))
+		}
+	}
+
+	if err := New().SetFailureBackoff(1, FailureBackoff{}); err == nil {
+		t.Error("expected an error for an unknown ID")
+	}
+}
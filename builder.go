@@ -0,0 +1,199 @@
+package cron
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Builder constructs a schedule step by step instead of parsing a spec, e.g.
+//
+//	sched, err := cron.On().Weekdays().At(9, 30).InLocation(loc).Build()
+//
+// Each step restricts the schedule further: days which are not restricted
+// match every day, and a schedule without a time of the day activates at
+// midnight.  Unlike in a spec, restricting both the days of the month and the
+// days of the week requires both to match.  The first invalid value given to
+// a step is reported by Build.
+type Builder struct {
+	dom, month, dow uint64
+	lastDom         bool
+	minute, hour    uint64
+	times           [][2]int // hour and minute
+	location        *time.Location
+	err             error
+}
+
+// On returns a Builder of a schedule activating at midnight of every day.
+func On() *Builder {
+	return &Builder{}
+}
+
+// Weekdays restricts the schedule to Monday through Friday.
+func (b *Builder) Weekdays() *Builder {
+	return b.Days(time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday)
+}
+
+// Weekends restricts the schedule to Saturday and Sunday.
+func (b *Builder) Weekends() *Builder {
+	return b.Days(time.Saturday, time.Sunday)
+}
+
+// Days restricts the schedule to the given days of the week.
+func (b *Builder) Days(days ...time.Weekday) *Builder {
+	for _, day := range days {
+		if day < time.Sunday || day > time.Saturday {
+			b.fail("Day of week (%d) not within %d-%d", day, dow.min, dow.max)
+			continue
+		}
+		b.dow |= 1 << uint(day)
+	}
+	return b
+}
+
+// DaysOfMonth restricts the schedule to the given days of the month.
+func (b *Builder) DaysOfMonth(days ...int) *Builder {
+	for _, day := range days {
+		if day < int(dom.min) || day > int(dom.max) {
+			b.fail("Day of month (%d) not within %d-%d", day, dom.min, dom.max)
+			continue
+		}
+		b.dom |= 1 << uint(day)
+	}
+	return b
+}
+
+// LastDayOfMonth restricts the schedule to the last day of the month, in
+// addition to the DaysOfMonth.
+func (b *Builder) LastDayOfMonth() *Builder {
+	b.lastDom = true
+	return b
+}
+
+// Months restricts the schedule to the given months.
+func (b *Builder) Months(months ...time.Month) *Builder {
+	for _, month := range months {
+		if month < time.January || month > time.December {
+			b.fail("Month (%d) not within 1-12", month)
+			continue
+		}
+		b.month |= 1 << uint(month)
+	}
+	return b
+}
+
+// At activates the schedule at the given time of the day.  It may be given
+// several times, e.g. At(9, 30).At(17, 0).
+func (b *Builder) At(hour, minute int) *Builder {
+	if hour < int(hours.min) || hour > int(hours.max) {
+		b.fail("Hour (%d) not within %d-%d", hour, hours.min, hours.max)
+	} else if minute < int(minutes.min) || minute > int(minutes.max) {
+		b.fail("Minute (%d) not within %d-%d", minute, minutes.min, minutes.max)
+	} else {
+		b.times = append(b.times, [2]int{hour, minute})
+	}
+	return b
+}
+
+// EveryMinutes activates the schedule every n minutes, starting at the full
+// hour, during every hour or the hours given by Between.
+func (b *Builder) EveryMinutes(n int) *Builder {
+	if n < 1 || n > int(minutes.max) {
+		b.fail("Step (%d) not within 1-%d", n, minutes.max)
+		return b
+	}
+	b.minute = getBits(minutes.min, minutes.max, uint(n))
+	return b
+}
+
+// Between restricts the schedule to the hours from start through end, e.g.
+// Between(9, 17) until 17:59.  Without EveryMinutes, it activates at the full
+// hours.
+func (b *Builder) Between(start, end int) *Builder {
+	if start < int(hours.min) || end > int(hours.max) || start > end {
+		b.fail("Hours (%d-%d) not within %d-%d", start, end, hours.min, hours.max)
+		return b
+	}
+	b.hour = getBits(uint(start), uint(end), 1)
+	return b
+}
+
+// InLocation evaluates the schedule in the given time zone, instead of the
+// location of the time passed to Next.
+func (b *Builder) InLocation(loc *time.Location) *Builder {
+	b.location = loc
+	return b
+}
+
+// Build returns the schedule, or the first invalid value given to the steps.
+// The schedule is a *SpecSchedule, unless times of the day with different
+// minutes are combined.
+func (b *Builder) Build() (Schedule, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if len(b.times) > 0 && (b.minute != 0 || b.hour != 0) {
+		return nil, fmt.Errorf("At can not be combined with EveryMinutes or Between")
+	}
+
+	base := SpecSchedule{
+		Second:   1 << seconds.min,
+		Minute:   b.minute,
+		Hour:     b.hour,
+		Dom:      b.dom,
+		Month:    b.month,
+		Dow:      b.dow,
+		Location: b.location,
+		lastDom:  b.lastDom,
+		dayAnd:   (b.dom != 0 || b.lastDom) && b.dow != 0,
+	}
+	if b.dom == 0 && !b.lastDom {
+		base.Dom = all(dom)
+	}
+	if b.month == 0 {
+		base.Month = all(months)
+	}
+	if b.dow == 0 {
+		base.Dow = all(dow)
+	}
+	if len(b.times) == 0 {
+		switch {
+		case base.Minute == 0:
+			base.Minute = 1 << minutes.min
+			if base.Hour == 0 {
+				base.Hour = 1 << hours.min
+			}
+		case base.Hour == 0:
+			base.Hour = all(hours)
+		}
+		return &base, nil
+	}
+
+	// The hours of the times with the same minute share a schedule.
+	byMinute := make(map[int]uint64)
+	var minutesUsed []int
+	for _, t := range b.times {
+		if byMinute[t[1]] == 0 {
+			minutesUsed = append(minutesUsed, t[1])
+		}
+		byMinute[t[1]] |= 1 << uint(t[0])
+	}
+	sort.Ints(minutesUsed)
+	var schedules []Schedule
+	for _, minute := range minutesUsed {
+		s := base
+		s.Minute, s.Hour = 1<<uint(minute), byMinute[minute]
+		schedules = append(schedules, &s)
+	}
+	if len(schedules) == 1 {
+		return schedules[0], nil
+	}
+	return ScheduleUnion(schedules...), nil
+}
+
+// fail records the error of a step, unless an earlier step failed already.
+func (b *Builder) fail(format string, args ...interface{}) {
+	if b.err == nil {
+		b.err = fmt.Errorf(format, args...)
+	}
+}
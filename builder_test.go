@@ -0,0 +1,87 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuilder(t *testing.T) {
+	zurich, _ := time.LoadLocation("Europe/Zurich")
+	tests := []struct {
+		builder  *Builder
+		expected string
+	}{
+		{On(), "0 0 0 * * *"},
+		{On().Weekdays().At(9, 30), "0 30 9 * * 1-5"},
+		{On().Weekends().At(10, 0), "0 0 10 * * 0,6"},
+		{On().Days(time.Friday).At(17, 15).At(8, 15), "0 15 8,17 * * 5"},
+		{On().DaysOfMonth(1, 15).Months(time.January, time.July).At(6, 0), "0 0 6 1,15 1,7 *"},
+		{On().LastDayOfMonth().At(23, 0), "0 0 23 L * *"},
+		{On().Weekdays().EveryMinutes(15).Between(9, 17), "0 0,15,30,45 9-17 * * 1-5"},
+		{On().EveryMinutes(20), "0 0,20,40 * * * *"},
+		{On().Between(8, 10), "0 0 8-10 * * *"},
+		{On().At(6, 0).InLocation(zurich), "CRON_TZ=Europe/Zurich 0 0 6 * * *"},
+	}
+	for _, c := range tests {
+		sched, err := c.builder.Build()
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		text, err := sched.(*SpecSchedule).MarshalText()
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if string(text) != c.expected {
+			t.Errorf("(expected) %q != %q (actual)", c.expected, text)
+		}
+	}
+}
+
+func TestBuilderNext(t *testing.T) {
+	runs := []struct {
+		builder        *Builder
+		time, expected string
+	}{
+		// Times with different minutes are combined.
+		{On().At(9, 30).At(17, 0), "Mon Jul 9 10:00 2012", "Mon Jul 9 17:00 2012"},
+		{On().At(9, 30).At(17, 0), "Mon Jul 9 17:00 2012", "Tue Jul 10 09:30 2012"},
+
+		// Both the day of month and the day of week must match.
+		{On().DaysOfMonth(13).Days(time.Friday), "Sat Jul 14 00:00 2012", "Fri Sep 13 00:00 2013"},
+		{On().LastDayOfMonth().Weekdays().At(18, 0), "Mon Jul 9 00:00 2012", "Tue Jul 31 18:00 2012"},
+		{On().LastDayOfMonth().Weekdays().At(18, 0), "Tue Jul 31 18:00 2012", "Fri Aug 31 18:00 2012"},
+	}
+	for _, c := range runs {
+		sched, err := c.builder.Build()
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		actual := sched.Next(getTime(c.time))
+		if expected := getTime(c.expected); !actual.Equal(expected) {
+			t.Errorf("%s: (expected) %v != %v (actual)", c.time, expected, actual)
+		}
+	}
+}
+
+func TestBuilderErrors(t *testing.T) {
+	builders := []*Builder{
+		On().At(24, 0),
+		On().At(9, 60),
+		On().Days(7),
+		On().DaysOfMonth(0),
+		On().DaysOfMonth(32),
+		On().Months(13),
+		On().EveryMinutes(0),
+		On().Between(17, 9),
+		On().Between(9, 24),
+		On().At(9, 0).EveryMinutes(15),
+	}
+	for i, b := range builders {
+		if _, err := b.Build(); err == nil {
+			t.Errorf("%d: expected an error", i)
+		}
+	}
+}
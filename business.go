@@ -0,0 +1,167 @@
+package cron
+
+import "time"
+
+// RollPolicy specifies the business day on which a BusinessDaySchedule
+// activates, if its day of the month is not a business day.
+type RollPolicy int
+
+const (
+	RollFollowing         RollPolicy = iota // The following business day
+	RollPreceding                           // The preceding business day
+	RollModifiedFollowing                   // The following business day, unless it is in the next month, then the preceding one
+	RollModifiedPreceding                   // The preceding business day, unless it is in the previous month, then the following one
+)
+
+// BusinessDaySchedule activates once a month on a business day, e.g. "the 3rd
+// business day of the month at 06:00", or "the 15th of the month at 06:00, or
+// the following business day".  Business days are the days which are neither
+// on the weekend nor holidays.
+type BusinessDaySchedule struct {
+	// Day is the day of the month, or the n-th business day of the month if
+	// BusinessDays is set.  A day beyond the end of a month is its last day.
+	// Negative business days count from the end of the month, so -1 is the
+	// last business day.
+	Day          int
+	BusinessDays bool
+
+	Hour, Minute int
+
+	// Roll chooses the business day if the day of the month is not one.  It
+	// does not apply to BusinessDays.
+	Roll RollPolicy
+
+	// Weekend lists the days of the week which are not business days.  A nil
+	// Weekend is Saturday and Sunday.
+	Weekend []time.Weekday
+
+	// Holidays are not business days either, if given.
+	Holidays Calendar
+
+	// Location is the time zone in which the schedule is evaluated.  A nil
+	// Location uses the location of the time passed to Next.
+	Location *time.Location
+}
+
+// NthBusinessDay returns a Schedule that activates on the n-th business day of
+// every month, at the given time of the day.
+func NthBusinessDay(n, hour, minute int) *BusinessDaySchedule {
+	return &BusinessDaySchedule{
+		Day:          n,
+		BusinessDays: true,
+		Hour:         hour,
+		Minute:       minute,
+	}
+}
+
+// LastBusinessDay returns a Schedule that activates on the last business day
+// of every month, at the given time of the day.
+func LastBusinessDay(hour, minute int) *BusinessDaySchedule {
+	return NthBusinessDay(-1, hour, minute)
+}
+
+// OnBusinessDay returns a Schedule that activates on the given day of every
+// month at the given time of the day, rolled by the policy to a business day.
+func OnBusinessDay(day, hour, minute int, roll RollPolicy) *BusinessDaySchedule {
+	return &BusinessDaySchedule{
+		Day:    day,
+		Hour:   hour,
+		Minute: minute,
+		Roll:   roll,
+	}
+}
+
+// Next returns the next activation after the given time.  If there is none
+// within five years, return the zero time.
+func (s *BusinessDaySchedule) Next(t time.Time) time.Time {
+	origLocation := t.Location()
+	if s.Location != nil {
+		t = t.In(s.Location)
+	}
+
+	// Rolling back may move the activation of the next month into this one,
+	// so the search starts in the month before.
+	month := time.Date(t.Year(), t.Month()-1, 1, 0, 0, 0, 0, t.Location())
+	for i := 0; i < 12*5+2; i++ {
+		if day, ok := s.dayIn(month); ok {
+			next := time.Date(day.Year(), day.Month(), day.Day(), s.Hour, s.Minute, 0, 0, t.Location())
+			if next.After(t) {
+				return next.In(origLocation)
+			}
+		}
+		month = month.AddDate(0, 1, 0)
+	}
+	return time.Time{}
+}
+
+// dayIn returns the day of the activation for the given month, or false if
+// there is none.
+func (s *BusinessDaySchedule) dayIn(month time.Time) (time.Time, bool) {
+	last := daysIn(month.Month(), month.Year())
+	if s.BusinessDays {
+		first, direction, n := 1, 1, s.Day
+		if n < 0 {
+			first, direction, n = last, -1, -n
+		}
+		for d := first; d >= 1 && d <= last; d += direction {
+			day := month.AddDate(0, 0, d-1)
+			if s.isBusinessDay(day) {
+				n--
+				if n == 0 {
+					return day, true
+				}
+			}
+		}
+		return time.Time{}, false
+	}
+
+	d := s.Day
+	if d > last {
+		d = last
+	}
+	day := month.AddDate(0, 0, d-1)
+	switch s.Roll {
+	case RollFollowing:
+		return s.roll(day, 1)
+	case RollPreceding:
+		return s.roll(day, -1)
+	case RollModifiedFollowing:
+		if rolled, ok := s.roll(day, 1); ok && rolled.Month() == day.Month() {
+			return rolled, true
+		}
+		return s.roll(day, -1)
+	case RollModifiedPreceding:
+		if rolled, ok := s.roll(day, -1); ok && rolled.Month() == day.Month() {
+			return rolled, true
+		}
+		return s.roll(day, 1)
+	}
+	return time.Time{}, false
+}
+
+// roll returns the first business day from the given day on, in the given
+// direction, or false if there is none within a year.
+func (s *BusinessDaySchedule) roll(day time.Time, direction int) (time.Time, bool) {
+	for i := 0; i <= 366; i++ {
+		if s.isBusinessDay(day) {
+			return day, true
+		}
+		day = day.AddDate(0, 0, direction)
+	}
+	return time.Time{}, false
+}
+
+// isBusinessDay returns true if the day is neither on the weekend nor a
+// holiday.
+func (s *BusinessDaySchedule) isBusinessDay(day time.Time) bool {
+	weekend := s.Weekend
+	if weekend == nil {
+		weekend = []time.Weekday{time.Saturday, time.Sunday}
+	}
+	for _, w := range weekend {
+		if day.Weekday() == w {
+			return false
+		}
+	}
+	return s.Holidays == nil || !s.Holidays.IsHoliday(day)
+}
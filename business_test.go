@@ -0,0 +1,75 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBusinessDayNext(t *testing.T) {
+	holidays := NewStaticCalendar(getTime("Wed Jul 4 00:00 2012"))
+	friday := []time.Weekday{time.Friday, time.Saturday}
+
+	tests := []struct {
+		schedule       *BusinessDaySchedule
+		time, expected string
+	}{
+		// The n-th business day
+		{NthBusinessDay(3, 6, 0), "Mon Jul 9 12:00 2012", "Fri Aug 3 06:00 2012"},
+		{NthBusinessDay(3, 6, 0), "Sun Jul 1 12:00 2012", "Wed Jul 4 06:00 2012"},
+		{&BusinessDaySchedule{Day: 3, BusinessDays: true, Hour: 6, Holidays: holidays}, "Sun Jul 1 12:00 2012", "Thu Jul 5 06:00 2012"},
+		{&BusinessDaySchedule{Day: 1, BusinessDays: true, Hour: 6, Weekend: friday}, "Sat Jun 30 12:00 2012", "Sun Jul 1 06:00 2012"},
+		{&BusinessDaySchedule{Day: 1, BusinessDays: true, Hour: 6, Weekend: friday}, "Mon Jul 2 12:00 2012", "Wed Aug 1 06:00 2012"},
+		{NthBusinessDay(1, 6, 30), "Fri Aug 31 12:00 2012", "Mon Sep 3 06:30 2012"},
+		{NthBusinessDay(23, 6, 0), "Sun Jul 1 12:00 2012", "Fri Aug 31 06:00 2012"},
+
+		// The last business days
+		{LastBusinessDay(18, 0), "Sun Jul 1 12:00 2012", "Tue Jul 31 18:00 2012"},
+		{LastBusinessDay(18, 0), "Tue Jul 31 18:00 2012", "Fri Aug 31 18:00 2012"},
+		{LastBusinessDay(18, 0), "Mon Sep 3 12:00 2012", "Fri Sep 28 18:00 2012"},
+		{NthBusinessDay(-2, 18, 0), "Mon Sep 3 12:00 2012", "Thu Sep 27 18:00 2012"},
+		{&BusinessDaySchedule{Day: -1, BusinessDays: true, Hour: 18, Holidays: NewStaticCalendar(getTime("Fri Sep 28 00:00 2012"))}, "Mon Sep 3 12:00 2012", "Thu Sep 27 18:00 2012"},
+		{&BusinessDaySchedule{Day: -1, BusinessDays: true, Hour: 18, Weekend: []time.Weekday{time.Friday, time.Saturday}}, "Mon Sep 3 12:00 2012", "Sun Sep 30 18:00 2012"},
+
+		// Rolling the day of the month
+		{OnBusinessDay(15, 6, 0, RollFollowing), "Mon Jul 9 12:00 2012", "Mon Jul 16 06:00 2012"},
+		{OnBusinessDay(15, 6, 0, RollPreceding), "Mon Jul 9 12:00 2012", "Fri Jul 13 06:00 2012"},
+		{OnBusinessDay(16, 6, 0, RollPreceding), "Mon Jul 9 12:00 2012", "Mon Jul 16 06:00 2012"},
+		{OnBusinessDay(4, 6, 0, RollFollowing), "Sun Jul 1 12:00 2012", "Wed Jul 4 06:00 2012"},
+		{&BusinessDaySchedule{Day: 4, Hour: 6, Holidays: holidays}, "Sun Jul 1 12:00 2012", "Thu Jul 5 06:00 2012"},
+
+		// Rolling across the end of the month
+		{OnBusinessDay(30, 6, 0, RollFollowing), "Sat Sep 1 12:00 2012", "Mon Oct 1 06:00 2012"},
+		{OnBusinessDay(30, 6, 0, RollModifiedFollowing), "Sat Sep 1 12:00 2012", "Fri Sep 28 06:00 2012"},
+		{OnBusinessDay(1, 6, 0, RollPreceding), "Sun Jul 1 12:00 2012", "Wed Aug 1 06:00 2012"},
+		{OnBusinessDay(1, 6, 0, RollPreceding), "Thu Aug 30 12:00 2012", "Fri Aug 31 06:00 2012"},
+		{OnBusinessDay(1, 6, 0, RollModifiedPreceding), "Thu Aug 30 12:00 2012", "Mon Sep 3 06:00 2012"},
+
+		// Days beyond the end of the month
+		{OnBusinessDay(31, 6, 0, RollPreceding), "Mon Apr 2 12:00 2012", "Mon Apr 30 06:00 2012"},
+
+		// Never
+		{NthBusinessDay(24, 6, 0), "Sun Jul 1 12:00 2012", ""},
+	}
+
+	for _, c := range tests {
+		actual := c.schedule.Next(getTime(c.time))
+		expected := getTime(c.expected)
+		if !actual.Equal(expected) {
+			t.Errorf("%+v, %s: (expected) %v != %v (actual)", *c.schedule, c.time, expected, actual)
+		}
+	}
+}
+
+func TestBusinessDayLocation(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	s := NthBusinessDay(1, 6, 0)
+	s.Location = ny
+	from := time.Date(2012, time.July, 1, 0, 0, 0, 0, time.UTC)
+	expected := time.Date(2012, time.July, 2, 10, 0, 0, 0, time.UTC)
+	if actual := s.Next(from); !actual.Equal(expected) || actual.Location() != time.UTC {
+		t.Errorf("(expected) %v != %v (actual)", expected, actual)
+	}
+}
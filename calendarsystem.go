@@ -0,0 +1,107 @@
+package cron
+
+import (
+	"fmt"
+	"time"
+)
+
+// CalendarSystem is a calendar system, e.g. the Hijri or Hebrew calendar,
+// against which a CalendarSystemSchedule matches the months and the days of the
+// month.
+type CalendarSystem interface {
+	// Date returns the year, the month and the day of the month of the date
+	// of the given time in its location, with months and days starting at 1.
+	Date(t time.Time) (year, month, day int)
+
+	// DaysIn returns the number of days of the month of the year.
+	DaysIn(year, month int) int
+}
+
+// Gregorian is the calendar of the time package, in which a SpecSchedule is
+// evaluated.
+var Gregorian CalendarSystem = gregorian{}
+
+type gregorian struct{}
+
+func (gregorian) Date(t time.Time) (year, month, day int) {
+	y, m, d := t.Date()
+	return y, int(m), d
+}
+
+func (gregorian) DaysIn(year, month int) int {
+	return daysIn(time.Month(month), year)
+}
+
+// CalendarSystemSchedule activates like its schedule, but matches the months and
+// the days of the month of the schedule against the dates of another calendar,
+// e.g. "0 0 0 1 9 *" on the first day of Ramadan in the Hijri calendar.  The
+// times of the day, the days of the week and the years remain those of the
+// schedule.  Months beyond December, e.g. of the Hebrew calendar, may be set
+// in the Month bits of the schedule directly.
+type CalendarSystemSchedule struct {
+	Schedule *SpecSchedule
+	System   CalendarSystem
+}
+
+// InCalendarSystem returns a Schedule that activates like the given schedule,
+// with its months and days of the month in the given calendar system.
+// It returns an error if the schedule uses the nearest weekday of a day of the
+// month ("W" or "LW"), which is not supported.
+func InCalendarSystem(schedule *SpecSchedule, system CalendarSystem) (CalendarSystemSchedule, error) {
+	if schedule.weekdayDom != 0 || schedule.lastWeekdayDom {
+		return CalendarSystemSchedule{}, fmt.Errorf("Nearest weekdays (W) are not supported in a calendar system")
+	}
+	return CalendarSystemSchedule{Schedule: schedule, System: system}, nil
+}
+
+// Next returns the next activation of the schedule on a matching date of the
+// calendar.  If there is none within five years, return the zero time.
+func (s CalendarSystemSchedule) Next(t time.Time) time.Time {
+	origLocation := t.Location()
+	if s.Schedule.Location != nil {
+		t = t.In(s.Schedule.Location)
+	}
+
+	daily := s.daily()
+	limit := t.AddDate(5, 0, 0)
+	for next := daily.Next(t); !next.IsZero() && !next.After(limit); next = daily.Next(t) {
+		if s.matches(next) {
+			return next.In(origLocation)
+		}
+
+		// Continue before the following day.
+		t = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, next.Location()).Add(-1 * time.Nanosecond)
+	}
+	return time.Time{}
+}
+
+// daily returns a copy of the schedule, which activates at its times of the
+// day on every day of its years.
+func (s CalendarSystemSchedule) daily() *SpecSchedule {
+	c := *s.Schedule
+	c.Dom, c.Month, c.Dow = all(dom), all(months), all(dow)
+	c.lastDom, c.lastDow, c.nthDow, c.dayAnd = false, 0, 0, false
+	return &c
+}
+
+// matches returns true if the date of the given time matches the months and the
+// days of the schedule in the calendar.
+func (s CalendarSystemSchedule) matches(t time.Time) bool {
+	year, month, day := s.System.Date(t)
+	if 1<<uint(month)&s.Schedule.Month == 0 {
+		return false
+	}
+
+	var (
+		sched    = s.Schedule
+		days     = s.System.DaysIn(year, month)
+		domMatch = 1<<uint(day)&sched.Dom > 0 || sched.lastDom && day == days
+		dowMatch = 1<<uint(t.Weekday())&sched.Dow > 0 ||
+			1<<uint(t.Weekday())&sched.lastDow > 0 && day+7 > days ||
+			1<<uint(8*((day-1)/7)+int(t.Weekday()))&sched.nthDow > 0
+	)
+	if sched.dayAnd || sched.Dom&starBit > 0 || sched.Dow&starBit > 0 {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
@@ -0,0 +1,74 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+// thirtyDays is a calendar of twelve months of 30 days, starting on January
+// 1st 2000.
+type thirtyDays struct{}
+
+func (thirtyDays) Date(t time.Time) (year, month, day int) {
+	d := civilDay(t)
+	return d / 360, d%360/30 + 1, d%30 + 1
+}
+
+func (thirtyDays) DaysIn(year, month int) int {
+	return 30
+}
+
+func TestCalendarSystemSchedule(t *testing.T) {
+	tests := []struct {
+		spec           string
+		time, expected string
+	}{
+		// January 31st 2000 is the first day of the second month.
+		{"0 0 6 1 * *", "Sat Jan 1 12:00 2000", "Mon Jan 31 06:00 2000"},
+		{"0 0 6 1 3 *", "Sat Jan 1 12:00 2000", "Wed Mar 1 06:00 2000"},
+		{"0 0 6 L * *", "Sat Jan 1 12:00 2000", "Sun Jan 30 06:00 2000"},
+		{"0 30 9 15 * *", "Sat Jan 15 09:30 2000", "Mon Feb 14 09:30 2000"},
+
+		// The days of the week are those of the time.
+		{"0 0 6 1 * MON", "Sat Jan 1 12:00 2000", "Mon Jan 3 06:00 2000"},
+		{"0 0 6 ? * MON#1", "Mon Jan 3 06:00 2000", "Mon Jan 31 06:00 2000"},
+		{"0 0 6 ? * 6L", "Sat Jan 1 12:00 2000", "Fri Jan 28 06:00 2000"},
+	}
+	for _, c := range tests {
+		sched, err := InCalendarSystem(mustParseSpec(c.spec), thirtyDays{})
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		actual := sched.Next(getTime(c.time))
+		if expected := getTime(c.expected); !actual.Equal(expected) {
+			t.Errorf("%s, \"%s\": (expected) %v != %v (actual)", c.time, c.spec, expected, actual)
+		}
+	}
+
+	// The Gregorian calendar activates like the schedule itself.
+	for _, spec := range []string{"0 0 6 L * *", "0 30 9 29 2 *", "0 0 0 13 * FRI", "0 0 8 ? * 1#2"} {
+		s := mustParseSpec(spec)
+		sched, _ := InCalendarSystem(s, Gregorian)
+		from := getTime("Mon Jul 9 14:45 2012")
+		for i := 0; i < 5; i++ {
+			expected, actual := s.Next(from), sched.Next(from)
+			if !actual.Equal(expected) {
+				t.Errorf("%s: (expected) %v != %v (actual)", spec, expected, actual)
+			}
+			from = expected
+		}
+	}
+
+	if _, err := InCalendarSystem(mustParseSpec("0 0 6 15W * *"), thirtyDays{}); err == nil {
+		t.Error("expected an error for the nearest weekday")
+	}
+}
+
+func mustParseSpec(spec string) *SpecSchedule {
+	sched, err := quartzParser.Parse(spec)
+	if err != nil {
+		panic(err)
+	}
+	return sched.(*SpecSchedule)
+}
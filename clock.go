@@ -0,0 +1,26 @@
+package cron
+
+import "time"
+
+// Clock is the source of the current time and of timers, which a Cron and a
+// Parser use instead of the time package, e.g. to control the time in tests.
+// The schedules and jobs created without a Cron or a Parser take the time from
+// the SystemClock, unless created by their variants taking a Clock, such as
+// EveryWithInitialClock, EveryWithRandInitialClock, LimitClock and
+// DelayIfStillRunningClock.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// After returns a channel, which receives the current time once the
+	// duration has elapsed.
+	After(d time.Duration) <-chan time.Time
+}
+
+// SystemClock is the Clock of the time package.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
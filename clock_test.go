@@ -0,0 +1,159 @@
+package cron
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock, whose time only advances by Advance.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []fakeTimer
+}
+
+type fakeTimer struct {
+	at time.Time
+	c  chan time.Time
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now}
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *fakeClock) After(d time.Duration) <-chan time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	c := make(chan time.Time, 1)
+	if d <= 0 {
+		c <- f.now
+		return c
+	}
+	f.timers = append(f.timers, fakeTimer{f.now.Add(d), c})
+	return c
+}
+
+// Advance advances the time, firing the timers which have elapsed.
+func (f *fakeClock) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+	timers := f.timers[:0]
+	for _, timer := range f.timers {
+		if timer.at.After(f.now) {
+			timers = append(timers, timer)
+			continue
+		}
+		timer.c <- f.now
+	}
+	f.timers = timers
+}
+
+// waitForTimers waits until the given number of timers are pending.
+func (f *fakeClock) waitForTimers(t *testing.T, n int) {
+	for i := 0; i < 1000; i++ {
+		f.mu.Lock()
+		pending := len(f.timers)
+		f.mu.Unlock()
+		if pending >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("expected %d pending timers", n)
+}
+
+func TestCronWithClock(t *testing.T) {
+	start := time.Date(2016, time.March, 1, 11, 59, 30, 500, time.UTC)
+	clock := newFakeClock(start)
+	ran := make(chan time.Time, 10)
+
+	cron := New(WithClock(clock), WithLocation(time.UTC))
+	if _, err := cron.AddFunc("0 0 * * * *", func() { ran <- clock.Now() }); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cron.AddFunc("@every 1h,5m", func() {}); err != nil {
+		t.Fatal(err)
+	}
+	cron.Start()
+	defer cron.Stop()
+
+	clock.waitForTimers(t, 1)
+	entries := cron.Entries()
+	expected := map[string]time.Time{
+		"0 0 * * * *":  time.Date(2016, time.March, 1, 12, 0, 0, 0, time.UTC),
+		"@every 1h,5m": time.Date(2016, time.March, 1, 12, 4, 30, 0, time.UTC),
+	}
+	if len(entries) != len(expected) {
+		t.Fatalf("(expected) %d entries != %d (actual)", len(expected), len(entries))
+	}
+	for _, e := range entries {
+		if !e.Next.Equal(expected[e.Spec]) {
+			t.Errorf("%s: (expected) %v != %v (actual)", e.Spec, expected[e.Spec], e.Next)
+		}
+	}
+
+	clock.Advance(30 * time.Second)
+	select {
+	case now := <-ran:
+		if !now.Equal(start.Add(30 * time.Second)) {
+			t.Errorf("(expected) a run at %v != %v (actual)", start.Add(30*time.Second), now)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the job to run")
+	}
+}
+
+// The variants taking a Clock take the time from it.
+func TestClockVariants(t *testing.T) {
+	start := time.Date(2016, time.March, 1, 12, 0, 0, 0, time.UTC)
+	clock := newFakeClock(start)
+	tests := []struct {
+		name     string
+		schedule Schedule
+		expected time.Time
+	}{
+		{"EveryWithInitialClock", EveryWithInitialClock(time.Hour, 5*time.Minute, clock), start.Add(5 * time.Minute)},
+		{"EveryWithRandInitialClock", EveryWithRandInitialClock(time.Hour, clock), time.Time{}},
+		{"LimitClock", LimitClock(Every(time.Hour), 1, clock), start.Add(time.Hour)},
+	}
+	for _, c := range tests {
+		next := c.schedule.Next(start)
+		if c.expected.IsZero() {
+			// The random initial delay is within the first hour.
+			if !next.After(start) || next.After(start.Add(time.Hour)) {
+				t.Errorf("%s: unexpected %v", c.name, next)
+			}
+		} else if !next.Equal(c.expected) {
+			t.Errorf("%s: (expected) %v != %v (actual)", c.name, c.expected, next)
+		}
+	}
+
+	// The delay of a SerialJob is measured by the clock.
+	started, release := make(chan struct{}, 2), make(chan struct{})
+	job := DelayIfStillRunningClock(FuncJob(func() {
+		started <- struct{}{}
+		<-release
+	}), clock)
+	done := make(chan struct{})
+	go job.Run()
+	<-started
+	go func() {
+		job.Run()
+		close(done)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	clock.Advance(time.Minute)
+	close(release)
+	<-done
+	if job.Delay() != time.Minute {
+		t.Errorf("(expected) a delay of 1m != %v (actual)", job.Delay())
+	}
+}
@@ -0,0 +1,40 @@
+package cron
+
+import (
+	"context"
+	"time"
+)
+
+type activationsKey struct{}
+
+// Activations returns the activations, which the run of a job with the given
+// context has been run for, the latest one last: the activation of the run, or
+// all the activations coalesced into it, e.g. by MisfireCoalesce.  It returns
+// nil if the context is not the one of a run.
+func Activations(ctx context.Context) []time.Time {
+	activations, _ := ctx.Value(activationsKey{}).([]time.Time)
+	return activations
+}
+
+// withActivations returns the context of a run for the activations.
+func withActivations(ctx context.Context, activations []time.Time) context.Context {
+	return context.WithValue(ctx, activationsKey{}, activations)
+}
+
+// suppress records the activations of the entry suppressed by pausing, to be
+// coalesced into one run when it is resumed, if its misfire policy is
+// MisfireCoalesce.
+func (e *Entry) suppress(activations []time.Time) {
+	if e.misfire == MisfireCoalesce {
+		e.pending = append(e.pending, activations...)
+	}
+}
+
+// resumeCoalesced runs the job of the resumed entry once for its suppressed
+// activations, if any.
+func (c *Cron) resumeCoalesced(e *Entry) {
+	if len(e.pending) > 0 {
+		c.dispatchAll(e, e.pending)
+		e.pending = nil
+	}
+}
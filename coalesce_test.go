@@ -0,0 +1,83 @@
+package cron
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestActivations(t *testing.T) {
+	t1 := time.Date(2016, time.March, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		ctx      context.Context
+		expected []time.Time
+	}{
+		{context.Background(), nil},
+		{withActivations(context.Background(), []time.Time{t1}), []time.Time{t1}},
+		{context.WithValue(withActivations(context.Background(), []time.Time{t1}), spanKey{}, "span"), []time.Time{t1}},
+	}
+	for _, c := range tests {
+		if actual := Activations(c.ctx); !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("(expected) %v != %v (actual)", c.expected, actual)
+		}
+	}
+}
+
+// Activations missed while asleep or paused are coalesced into one run, which
+// is passed all of them.
+func TestMisfireCoalesce(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2016, time.March, 1, hour, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		name     string
+		pause    func(c *Cron, id EntryID)
+		resume   func(c *Cron, id EntryID)
+		expected []time.Time
+	}{
+		{"asleep", nil, nil, []time.Time{at(13), at(14), at(15)}},
+		{"entry paused", func(c *Cron, id EntryID) { c.Pause(id) }, func(c *Cron, id EntryID) { c.Resume(id) }, []time.Time{at(13), at(14), at(15)}},
+		{"cron paused", func(c *Cron, id EntryID) { c.PauseAll() }, func(c *Cron, id EntryID) { c.ResumeAll(CoalesceMissed) }, []time.Time{at(13), at(14), at(15)}},
+		{"cron paused, skipped", func(c *Cron, id EntryID) { c.PauseAll() }, func(c *Cron, id EntryID) { c.ResumeAll(SkipMissed) }, nil},
+	}
+	for _, c := range tests {
+		clock := newFakeClock(time.Date(2016, time.March, 1, 12, 0, 30, 0, time.UTC))
+		ran := make(chan []time.Time, 10)
+		cron := New(WithClock(clock), WithLocation(time.UTC))
+		id, _ := cron.AddJob("0 0 * * * *", ErrorFuncJob(func(ctx context.Context) error {
+			ran <- Activations(ctx)
+			return nil
+		}))
+		cron.SetMisfirePolicy(id, MisfireCoalesce)
+		cron.Start()
+		clock.waitForTimers(t, 1)
+
+		if c.pause == nil {
+			clock.Advance(3*time.Hour + 29*time.Minute + 30*time.Second)
+		} else {
+			c.pause(cron, id)
+			for i := 0; i < 3; i++ {
+				clock.Advance(time.Hour)
+				clock.waitForTimers(t, 1)
+			}
+			c.resume(cron, id)
+		}
+
+		var actual [][]time.Time
+		for waiting := true; waiting; {
+			select {
+			case activations := <-ran:
+				actual = append(actual, activations)
+			case <-time.After(50 * time.Millisecond):
+				waiting = false
+			}
+		}
+		cron.Stop()
+		var expected [][]time.Time
+		if c.expected != nil {
+			expected = [][]time.Time{c.expected}
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%s: (expected) %v != %v (actual)", c.name, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package cron
+
+import "time"
+
+// UnionSchedule activates whenever any of its schedules activates, e.g. "every
+// Monday at 9:00 or the first of the month at 7:00".
+type UnionSchedule struct {
+	Schedules []Schedule
+}
+
+// ScheduleUnion returns a Schedule that activates at the activations of all
+// the given schedules.
+func ScheduleUnion(schedules ...Schedule) UnionSchedule {
+	return UnionSchedule{Schedules: schedules}
+}
+
+// Next returns the earliest next activation of the schedules.  If none of the
+// schedules activates anymore, return the zero time.
+func (schedule UnionSchedule) Next(t time.Time) time.Time {
+	var next time.Time
+	for _, s := range schedule.Schedules {
+		n := s.Next(t)
+		if !n.IsZero() && (next.IsZero() || n.Before(next)) {
+			next = n
+		}
+	}
+	return next
+}
+
+// IntersectionSchedule activates only when all of its schedules activate at the
+// same instant, e.g. "every 15 minutes" and "on weekdays".  The schedules
+// should activate at fixed times, like SpecSchedule, rather than relative to
+// the time passed to Next, like ConstantDelaySchedule.
+type IntersectionSchedule struct {
+	Schedules []Schedule
+}
+
+// ScheduleIntersection returns a Schedule that activates only at the times at
+// which all the given schedules activate.
+func ScheduleIntersection(schedules ...Schedule) IntersectionSchedule {
+	return IntersectionSchedule{Schedules: schedules}
+}
+
+// Next returns the next time at which all schedules activate.  If there is no
+// such time within five years, or no schedules at all, return the zero time.
+func (schedule IntersectionSchedule) Next(t time.Time) time.Time {
+	if len(schedule.Schedules) == 0 {
+		return time.Time{}
+	}
+	limit := t.AddDate(5, 0, 0)
+	next := schedule.Schedules[0].Next(t)
+	for !next.IsZero() && !next.After(limit) {
+		matched := true
+		for _, s := range schedule.Schedules {
+			// The earliest activation at or after the candidate.
+			n := s.Next(next.Add(-1 * time.Nanosecond))
+			if n.IsZero() {
+				return time.Time{}
+			}
+			if n.After(next) {
+				next, matched = n, false
+				break
+			}
+		}
+		if matched {
+			return next
+		}
+	}
+	return time.Time{}
+}
+
+// ExceptSchedule activates like its base schedule, but skips the activations
+// which coincide with an activation of the excluded schedule.  A window may be
+// excluded by a schedule activating every second within it, e.g. "* * 2-3 * *
+// *" for a nightly backup from 2am to 4am.
+type ExceptSchedule struct {
+	Base, Excluded Schedule
+}
+
+// Except returns a Schedule that activates like base, except at the times at
+// which excluded activates.
+func Except(base Schedule, excluded Schedule) ExceptSchedule {
+	return ExceptSchedule{Base: base, Excluded: excluded}
+}
+
+// Next returns the next activation of the base schedule, which is not an
+// activation of the excluded schedule.  If there is none within five years,
+// return the zero time.
+func (schedule ExceptSchedule) Next(t time.Time) time.Time {
+	limit := t.AddDate(5, 0, 0)
+	for next := schedule.Base.Next(t); !next.IsZero() && !next.After(limit); next = schedule.Base.Next(next) {
+		if !schedule.Excluded.Next(next.Add(-1 * time.Nanosecond)).Equal(next) {
+			return next
+		}
+	}
+	return time.Time{}
+}
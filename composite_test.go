@@ -0,0 +1,98 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUnionNext(t *testing.T) {
+	mondays, _ := Parse("0 0 9 * * Mon")
+	firsts, _ := Parse("0 0 7 1 * *")
+	once := At(getTime("Wed Jul 11 12:00 2012"))
+	union := ScheduleUnion(mondays, firsts, once)
+
+	tests := []struct {
+		time, expected string
+	}{
+		{"Mon Jul 9 08:00 2012", "Mon Jul 9 09:00 2012"},
+		{"Mon Jul 9 09:00 2012", "Wed Jul 11 12:00 2012"},
+		{"Wed Jul 11 12:00 2012", "Mon Jul 16 09:00 2012"},
+		{"Mon Jul 30 09:00 2012", "Wed Aug 1 07:00 2012"},
+		{"Wed Aug 1 07:00 2012", "Mon Aug 6 09:00 2012"},
+	}
+	for _, c := range tests {
+		actual := union.Next(getTime(c.time))
+		expected := getTime(c.expected)
+		if !actual.Equal(expected) {
+			t.Errorf("%s: (expected) %v != %v (actual)", c.time, expected, actual)
+		}
+	}
+
+	// The union is over when all of its schedules are over.
+	over := ScheduleUnion(once, At(getTime("Thu Jul 12 12:00 2012")))
+	if actual := over.Next(getTime("Thu Jul 12 12:00 2012")); !actual.IsZero() {
+		t.Errorf("expected zero time, got %v", actual)
+	}
+	if actual := ScheduleUnion().Next(time.Now()); !actual.IsZero() {
+		t.Errorf("expected zero time for an empty union, got %v", actual)
+	}
+}
+
+func TestIntersectionNext(t *testing.T) {
+	quarters, _ := Parse("0 0/15 * * * *")
+	weekdays, _ := Parse("* * * * * Mon-Fri")
+	office, _ := Parse("* * 9-17 * * *")
+	intersection := ScheduleIntersection(quarters, weekdays, office)
+
+	tests := []struct {
+		time, expected string
+	}{
+		{"Mon Jul 9 08:00 2012", "Mon Jul 9 09:00 2012"},
+		{"Mon Jul 9 09:00 2012", "Mon Jul 9 09:15 2012"},
+		{"Mon Jul 9 17:45 2012", "Tue Jul 10 09:00 2012"},
+		{"Fri Jul 13 17:45 2012", "Mon Jul 16 09:00 2012"},
+	}
+	for _, c := range tests {
+		actual := intersection.Next(getTime(c.time))
+		expected := getTime(c.expected)
+		if !actual.Equal(expected) {
+			t.Errorf("%s: (expected) %v != %v (actual)", c.time, expected, actual)
+		}
+	}
+
+	// Schedules which never activate at the same instant.
+	even, _ := Parse("0 0 0/2 * * *")
+	odd, _ := Parse("0 0 1/2 * * *")
+	if actual := ScheduleIntersection(even, odd).Next(getTime("Mon Jul 9 08:00 2012")); !actual.IsZero() {
+		t.Errorf("expected zero time, got %v", actual)
+	}
+	if actual := ScheduleIntersection().Next(time.Now()); !actual.IsZero() {
+		t.Errorf("expected zero time for an empty intersection, got %v", actual)
+	}
+}
+
+func TestExceptNext(t *testing.T) {
+	hourly, _ := Parse("@hourly")
+	backup, _ := Parse("* * 2-3 * * *")
+	except := Except(hourly, backup)
+
+	tests := []struct {
+		time, expected string
+	}{
+		{"Mon Jul 9 00:30 2012", "Mon Jul 9 01:00 2012"},
+		{"Mon Jul 9 01:00 2012", "Mon Jul 9 04:00 2012"},
+		{"Mon Jul 9 23:00 2012", "Tue Jul 10 00:00 2012"},
+	}
+	for _, c := range tests {
+		actual := except.Next(getTime(c.time))
+		expected := getTime(c.expected)
+		if !actual.Equal(expected) {
+			t.Errorf("%s: (expected) %v != %v (actual)", c.time, expected, actual)
+		}
+	}
+
+	// Excluding every activation.
+	if actual := Except(hourly, hourly).Next(getTime("Mon Jul 9 00:30 2012")); !actual.IsZero() {
+		t.Errorf("expected zero time, got %v", actual)
+	}
+}
@@ -0,0 +1,74 @@
+package cron
+
+import "context"
+
+// WithEntryConcurrency limits the number of concurrent runs of the entry to n,
+// independently of WithMaxConcurrency.  Runs beyond n wait for one to
+// complete, holding a goroutine of the worker pool, if any, and fail with the
+// error of their context if it is done before.  A limit of 0 removes it.
+func WithEntryConcurrency(n int) EntryOption {
+	return func(e *Entry) {
+		e.setMaxConcurrency(n)
+	}
+}
+
+// setMaxConcurrency limits the number of concurrent runs of the entry to n, or
+// removes the limit if n is 0.
+func (e *Entry) setMaxConcurrency(n int) {
+	e.slots = nil
+	if n > 0 {
+		e.slots = make(chan struct{}, n)
+	}
+}
+
+// SetPriority sets the priority of the runs of the entry with the given ID in
+// the queue of the worker pool of WithMaxConcurrency, which is 0 by default.
+// Queued runs of a higher priority run first, e.g. for a critical job waiting
+// behind bulk ones, and the ones of the same priority in the order of their
+// activations.  It returns an error if there is no such entry.
+func (c *Cron) SetPriority(id EntryID, priority int) error {
+	return c.updateEntry(id, func(e *Entry, running bool) {
+		e.priority = priority
+	})
+}
+
+// admit waits for a run of the entry to be admitted by the concurrency limit of
+// the entry and the rate limit of the Cron, and to acquire the lock of the
+// Locker, and returns the func which releases its slot and lock.  It returns
+// the error of the context if it is done before.
+func (c *Cron) admit(ctx context.Context, run *Entry) (release func(), err error) {
+	releaseSlot, err := run.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if c.limiter != nil {
+		if err = c.limiter.wait(ctx, c.clock); err != nil {
+			releaseSlot()
+			return nil, err
+		}
+	}
+	unlock, err := c.lock(ctx, run)
+	if err != nil {
+		releaseSlot()
+		return nil, err
+	}
+	return func() {
+		unlock()
+		releaseSlot()
+	}, nil
+}
+
+// acquire waits for a slot of a run of the entry, and returns the func which
+// releases it.  It returns the error of the context if it is done before.
+func (e *Entry) acquire(ctx context.Context) (release func(), err error) {
+	slots := e.slots
+	if slots == nil {
+		return func() {}, nil
+	}
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
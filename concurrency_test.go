@@ -0,0 +1,81 @@
+package cron
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// An entry with a maximum concurrency has at most that many runs at a time,
+// while the others are not limited.
+func TestWithEntryConcurrency(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		running = map[string]int{}
+		maximum = map[string]int{}
+	)
+	job := func(name string) FuncJob {
+		return func() {
+			mu.Lock()
+			if running[name]++; running[name] > maximum[name] {
+				maximum[name] = running[name]
+			}
+			mu.Unlock()
+			time.Sleep(20 * time.Millisecond)
+			mu.Lock()
+			running[name]--
+			mu.Unlock()
+		}
+	}
+
+	cron := New()
+	limited, _ := cron.AddNamedJob("limited", "@yearly", job("limited"), WithEntryConcurrency(2))
+	unlimited, _ := cron.AddNamedJob("unlimited", "@yearly", job("unlimited"), WithEntryConcurrency(0))
+	cron.Start()
+	for i := 0; i < 4; i++ {
+		cron.RunNow(limited)
+		cron.RunNow(unlimited)
+	}
+	time.Sleep(100 * time.Millisecond)
+	<-cron.Stop().Done()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if maximum["limited"] != 2 || maximum["unlimited"] != 4 {
+		t.Errorf("(expected) 2 and 4 concurrent runs != %v (actual)", maximum)
+	}
+	if s := cron.EntryByName("limited").Stats; s.Runs != 4 || s.Failures != 0 {
+		t.Errorf("(expected) 4 runs != %+v (actual)", s)
+	}
+}
+
+// Runs of a higher priority are run first by the worker pool.
+func TestSetPriority(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		order []string
+	)
+	release := make(chan struct{})
+	cron := New(WithMaxConcurrency(1))
+	blocking, _ := cron.AddFunc("@yearly", func() { <-release })
+	bulk, _ := cron.AddFunc("@yearly", func() { mu.Lock(); order = append(order, "bulk"); mu.Unlock() })
+	billing, _ := cron.AddFunc("@yearly", func() { mu.Lock(); order = append(order, "billing"); mu.Unlock() })
+	if err := cron.SetPriority(billing, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := cron.SetPriority(billing+1, 1); err == nil {
+		t.Error("expected an error for an unknown ID")
+	}
+	cron.Start()
+	cron.RunNow(blocking)
+	cron.RunNow(bulk)
+	cron.RunNow(billing)
+	close(release)
+	<-cron.Stop().Done()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(order) != 2 || order[0] != "billing" {
+		t.Errorf("(expected) billing before bulk != %v (actual)", order)
+	}
+}
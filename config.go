@@ -0,0 +1,120 @@
+package cron
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Spec is the declarative form of a cron spec, listing the items of each field,
+// e.g. to be unmarshaled from a configuration file:
+//
+//	{"minutes": [0, 30], "hours": ["9-17"], "daysOfWeek": ["MON-FRI"]}
+//
+// The items are the same as in a spec, including ranges, steps and names.
+// Fields without items take on the defaults of NewParser: 0 for the seconds,
+// minutes and hours, and every value for the other fields.
+type Spec struct {
+	Seconds     Values `json:"seconds,omitempty" yaml:"seconds,omitempty"`
+	Minutes     Values `json:"minutes,omitempty" yaml:"minutes,omitempty"`
+	Hours       Values `json:"hours,omitempty" yaml:"hours,omitempty"`
+	DaysOfMonth Values `json:"daysOfMonth,omitempty" yaml:"daysOfMonth,omitempty"`
+	Months      Values `json:"months,omitempty" yaml:"months,omitempty"`
+	DaysOfWeek  Values `json:"daysOfWeek,omitempty" yaml:"daysOfWeek,omitempty"`
+	Years       Values `json:"years,omitempty" yaml:"years,omitempty"`
+
+	// Location is the name of the time zone in which the schedule is
+	// evaluated, e.g. "Europe/Zurich".  An empty Location uses the location of
+	// the time passed to Next.
+	Location string `json:"location,omitempty" yaml:"location,omitempty"`
+}
+
+// Values lists the items of a field of a Spec, e.g. "MON-FRI", "*/15" or "30".
+// When unmarshaled from JSON or YAML, numbers are accepted as well as strings,
+// and a single item may be given without a list.
+type Values []string
+
+// specParser parses the fields assembled from a Spec.
+var specParser = NewParser(Second | Minute | Hour | Dom | Month | Dow | Year)
+
+// NewSpecSchedule returns the schedule of the declarative spec.  It returns a
+// *ParseError locating the invalid field, if any.
+func NewSpecSchedule(spec Spec) (*SpecSchedule, error) {
+	fields := []Values{spec.Seconds, spec.Minutes, spec.Hours, spec.DaysOfMonth, spec.Months, spec.DaysOfWeek, spec.Years}
+	items := make([]string, len(fields))
+	for i, values := range fields {
+		for _, value := range values {
+			if value == "" || strings.IndexFunc(value, unicode.IsSpace) >= 0 || strings.Contains(value, ",") {
+				return nil, fmt.Errorf("Invalid item %q in the %s", value, fieldNames[i])
+			}
+		}
+		items[i] = strings.Join(values, ",")
+		if len(values) == 0 {
+			items[i] = defaults[i]
+		}
+	}
+
+	text := strings.Join(items, " ")
+	if spec.Location != "" {
+		text = "CRON_TZ=" + spec.Location + " " + text
+	}
+	schedule, err := specParser.Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	return schedule.(*SpecSchedule), nil
+}
+
+// UnmarshalJSON sets the values from a JSON list of strings and numbers, or a
+// single string or number.
+func (v *Values) UnmarshalJSON(data []byte) error {
+	var items []interface{}
+	if err := json.Unmarshal(data, &items); err != nil {
+		var item interface{}
+		if err := json.Unmarshal(data, &item); err != nil {
+			return err
+		}
+		items = []interface{}{item}
+	}
+	return v.set(items)
+}
+
+// UnmarshalYAML sets the values from a YAML sequence of strings and numbers, or
+// a single string or number.  It implements the yaml.Unmarshaler interface of
+// gopkg.in/yaml.v2, without depending on it.
+func (v *Values) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var items []interface{}
+	if err := unmarshal(&items); err != nil {
+		var item interface{}
+		if err := unmarshal(&item); err != nil {
+			return err
+		}
+		items = []interface{}{item}
+	}
+	return v.set(items)
+}
+
+// set sets the values from the decoded items.
+func (v *Values) set(items []interface{}) error {
+	values := make(Values, 0, len(items))
+	for _, item := range items {
+		switch item := item.(type) {
+		case nil:
+		case string:
+			values = append(values, item)
+		case int:
+			values = append(values, strconv.Itoa(item))
+		case float64:
+			if item != float64(int(item)) {
+				return fmt.Errorf("Expected an integer item, got %v", item)
+			}
+			values = append(values, strconv.Itoa(int(item)))
+		default:
+			return fmt.Errorf("Expected a string or integer item, got %v of type %T", item, item)
+		}
+	}
+	*v = values
+	return nil
+}
@@ -0,0 +1,108 @@
+package cron
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestNewSpecSchedule(t *testing.T) {
+	tests := []struct {
+		json, expected string
+	}{
+		{`{}`, "0 0 0 * * *"},
+		{`{"minutes": [0, 30], "hours": ["9-17"], "daysOfWeek": ["MON-FRI"]}`, "0 0,30 9-17 * * MON-FRI"},
+		{`{"minutes": "*/15"}`, "0 */15 0 * * *"},
+		{`{"seconds": ["*.0/500"], "minutes": "*", "hours": "*"}`, "*.0/500 * * * * *"},
+		{`{"hours": 6, "daysOfMonth": ["L", 15], "months": ["jan", "Jul"]}`, "0 0 6 L,15 1,7 *"},
+		{`{"hours": 12, "daysOfMonth": 1, "months": 1, "years": [2030, "2040-2042"]}`, "0 0 12 1 1 * 2030,2040-2042"},
+		{`{"hours": 6, "location": "Europe/Zurich"}`, "CRON_TZ=Europe/Zurich 0 0 6 * * *"},
+		{`{"minutes": null, "hours": []}`, "0 0 0 * * *"},
+	}
+	for _, c := range tests {
+		var spec Spec
+		if err := json.Unmarshal([]byte(c.json), &spec); err != nil {
+			t.Error(err)
+			continue
+		}
+		actual, err := NewSpecSchedule(spec)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		expected, _ := Parse(c.expected)
+		// LoadLocation returns a new location for every call.
+		if expected.(*SpecSchedule).Location != nil {
+			actual.Location = expected.(*SpecSchedule).Location
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%s: (expected) %v != %v (actual)", c.json, expected, actual)
+		}
+	}
+}
+
+func TestNewSpecScheduleErrors(t *testing.T) {
+	invalid := []Spec{
+		{Hours: Values{"24"}},
+		{DaysOfWeek: Values{"XYZ"}},
+		{Minutes: Values{"0,30"}},
+		{Minutes: Values{"0 30"}},
+		{Minutes: Values{""}},
+		{Location: "Nowhere/Nothing"},
+	}
+	for _, spec := range invalid {
+		if _, err := NewSpecSchedule(spec); err == nil {
+			t.Errorf("%+v: expected an error", spec)
+		}
+	}
+
+	// Invalid fields are located by a *ParseError.
+	_, err := NewSpecSchedule(Spec{Hours: Values{"9-17"}, DaysOfWeek: Values{"MON", "XYZ"}})
+	if parseErr, ok := err.(*ParseError); !ok || parseErr.Name != "day of week" || parseErr.Spec != "0 0 9-17 * * MON,XYZ *" {
+		t.Errorf("(expected) a *ParseError of the day of week != %#v (actual)", err)
+	}
+
+	for _, text := range []string{`{"hours": [1.5]}`, `{"hours": [true]}`, `{"hours": {"a": 1}}`} {
+		var spec Spec
+		if err := json.Unmarshal([]byte(text), &spec); err == nil {
+			t.Errorf("%s: expected an error", text)
+		}
+	}
+}
+
+func TestValuesUnmarshalYAML(t *testing.T) {
+	tests := []struct {
+		decoded  interface{}
+		expected Values
+	}{
+		{[]interface{}{0, "30"}, Values{"0", "30"}},
+		{"MON-FRI", Values{"MON-FRI"}},
+		{15, Values{"15"}},
+	}
+	for _, c := range tests {
+		// The unmarshal function mimics yaml.v2, which fails to decode a
+		// scalar into a list.
+		unmarshal := func(out interface{}) error {
+			items, isList := c.decoded.([]interface{})
+			switch out := out.(type) {
+			case *[]interface{}:
+				if !isList {
+					return errors.New("cannot unmarshal scalar into list")
+				}
+				*out = items
+			case *interface{}:
+				*out = c.decoded
+			}
+			return nil
+		}
+		var actual Values
+		if err := actual.UnmarshalYAML(unmarshal); err != nil {
+			t.Error(err)
+			continue
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("(expected) %q != %q (actual)", c.expected, actual)
+		}
+	}
+}
@@ -1,7 +1,10 @@
 package cron
 
 import (
+	"fmt"
+	"hash/fnv"
 	"math/rand"
+	"sync"
 	"time"
 )
 
@@ -9,10 +12,27 @@ import (
 // StartTime defines, when the first run shoud be executed.
 // This allows to run the job immediatly (@every 5s,0s) or
 // with a random delay (@every 5s,@rand) within the Delay.
-// It does not support jobs more frequent than once a second.
+// It does not support jobs more frequent than once a second, unless it is
+// Exact.
+// With a Jitter (@every 5m~30s), each activation is delayed by a random amount
+// of up to Jitter.
 type ConstantDelaySchedule struct {
 	Delay     time.Duration
 	StartTime time.Time
+	Jitter    time.Duration
+
+	// Exact activates exactly one Delay after the given time, without rounding
+	// to the second, e.g. every 250ms.
+	Exact bool
+
+	// Aligned activates on the cadence of Delay starting at StartTime, instead
+	// of one Delay after the given time, so that restarts do not shift the
+	// activations (@every 5m,@aligned).
+	Aligned bool
+
+	// Keyed marks an Aligned schedule, whose StartTime has been derived from a
+	// key by EveryWithKey (@every 5m,H).
+	Keyed bool
 }
 
 // Every returns a crontab Schedule that activates once every duration.
@@ -28,12 +48,38 @@ func Every(duration time.Duration) ConstantDelaySchedule {
 	}
 }
 
+// EveryExact returns a crontab Schedule that activates once every duration,
+// which may be less than a second, e.g. 250ms.  Unlike Every, the duration and
+// the activations are not rounded to the second.  Durations which are not
+// positive are rounded up to a millisecond.
+func EveryExact(duration time.Duration) ConstantDelaySchedule {
+	if duration <= 0 {
+		duration = time.Millisecond
+	}
+	return ConstantDelaySchedule{
+		Delay:     duration,
+		StartTime: time.Unix(0, 0),
+		Exact:     true,
+	}
+}
+
 // Every returns a crontab Schedule that activates once every duration,
 // but with a explicit initial delay. This allows to run the job immediatly.
 // Delays of less than a second are not supported (will round up to 1 second).
 // Any fields less than a Second are truncated.
 func EveryWithInitial(duration time.Duration, initial time.Duration) ConstantDelaySchedule {
-	t := time.Now()
+	return EveryWithInitialClock(duration, initial, SystemClock)
+}
+
+// EveryWithInitialClock returns the schedule of EveryWithInitial, whose initial
+// delay starts at the current time of the clock, e.g. a fake clock in tests.
+func EveryWithInitialClock(duration time.Duration, initial time.Duration, clock Clock) ConstantDelaySchedule {
+	return everyWithInitial(duration, initial, clock.Now())
+}
+
+// everyWithInitial returns the schedule of EveryWithInitial, as of the given
+// current time.
+func everyWithInitial(duration time.Duration, initial time.Duration, t time.Time) ConstantDelaySchedule {
 	cds := Every(duration)
 	cds.StartTime = t.Add(initial - time.Duration(t.Nanosecond())%time.Second)
 	return cds
@@ -45,20 +91,192 @@ func EveryWithInitial(duration time.Duration, initial time.Duration) ConstantDel
 // Delays of less than a second are not supported (will round up to 1 second).
 // Any fields less than a Second are truncated.
 func EveryWithRandInitial(duration time.Duration) ConstantDelaySchedule {
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-	t := time.Now()
+	return EveryWithRandInitialClock(duration, SystemClock)
+}
+
+// EveryWithRandInitialClock returns the schedule of EveryWithRandInitial, whose
+// initial delay starts at the current time of the clock, e.g. a fake clock in
+// tests.
+func EveryWithRandInitialClock(duration time.Duration, clock Clock) ConstantDelaySchedule {
+	return everyWithRandInitial(duration, clock.Now())
+}
+
+// everyWithRandInitial returns the schedule of EveryWithRandInitial, as of the
+// given current time.
+func everyWithRandInitial(duration time.Duration, t time.Time) ConstantDelaySchedule {
+	r := rand.New(rand.NewSource(t.UnixNano()))
 	cds := Every(duration)
 	cds.StartTime = t.Add(cds.Delay - time.Duration(t.Nanosecond())%time.Second - time.Duration(r.Int63()%int64(duration.Seconds()))*time.Second)
 	return cds
 }
 
+// EveryAligned returns a crontab Schedule that activates once every duration,
+// aligned to the wall clock in the given location, e.g. every 5 minutes at :00,
+// :05, :10 and so on.  The cadence starts at midnight of January 1st 2000, so
+// durations which divide a day are aligned to the days.
+// Delays of less than a second are not supported (will round up to 1 second).
+// Any fields less than a Second are truncated.
+func EveryAligned(duration time.Duration, loc *time.Location) ConstantDelaySchedule {
+	cds := Every(duration)
+	cds.StartTime = alignedStart(loc)
+	cds.Aligned = true
+	return cds
+}
+
+// EveryWithKey returns a crontab Schedule that activates once every duration,
+// at an offset within the duration derived from a hash of the given key, e.g.
+// the name of the job.  Unlike EveryWithRandInitial, the activations of a job
+// stay the same across restarts and across processes, while jobs with
+// different keys are spread over the interval.
+// Delays of less than a second are not supported (will round up to 1 second).
+// Any fields less than a Second are truncated.
+func EveryWithKey(duration time.Duration, key string) ConstantDelaySchedule {
+	cds := Every(duration)
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	offset := time.Duration(uint64(h.Sum32())%uint64(cds.Delay/time.Second)) * time.Second
+	cds.StartTime = time.Unix(0, 0).Add(offset)
+	cds.Aligned = true
+	cds.Keyed = true
+	return cds
+}
+
+// alignedStart returns the start of the cadence of aligned schedules in the
+// location.
+func alignedStart(loc *time.Location) time.Time {
+	return time.Date(2000, time.January, 1, 0, 0, 0, 0, loc)
+}
+
+// EveryWithJitter returns a crontab Schedule that activates once every duration,
+// with each activation delayed by a random amount of up to jitter.  This allows
+// to spread the load of multiple jobs with the same interval.
+// Delays of less than a second are not supported (will round up to 1 second).
+// Any fields less than a Second are truncated.
+func EveryWithJitter(duration time.Duration, jitter time.Duration) ConstantDelaySchedule {
+	cds := Every(duration)
+	cds.Jitter = jitter - jitter%time.Second
+	return cds
+}
+
 // Next returns the next time this should be run.
-// This rounds so that the next activation time will be on the second.
+// This rounds so that the next activation time will be on the second, unless
+// the schedule is Exact.
 func (schedule ConstantDelaySchedule) Next(t time.Time) time.Time {
+	if schedule.Jitter > 0 {
+		return schedule.nextWithJitter(t)
+	}
+	if schedule.Aligned {
+		return schedule.nextOnCadence(t).In(t.Location())
+	}
 	if schedule.StartTime.Sub(t).Seconds() > 0 {
 		// Initial run
 		return schedule.StartTime
+	} else if schedule.Exact {
+		return t.Add(schedule.Delay)
 	} else {
 		return t.Add(schedule.Delay - time.Duration(t.Nanosecond())*time.Nanosecond)
 	}
 }
+
+// Prev returns the previous time this should have been run, one Delay before
+// the given time, rounded to the second.  Before the first run at StartTime,
+// it returns the zero time.  With a Jitter, or if Aligned, it returns the
+// undelayed time of the previous activation on the cadence of Delay starting at
+// StartTime.  An Exact schedule is not rounded.
+func (schedule ConstantDelaySchedule) Prev(t time.Time) time.Time {
+	if schedule.Jitter > 0 || schedule.Aligned {
+		if !schedule.StartTime.Before(t) {
+			return time.Time{}
+		}
+		periods := (t.Sub(schedule.StartTime) - 1) / schedule.Delay
+		return schedule.StartTime.Add(periods * schedule.Delay).In(t.Location())
+	}
+	prev := t.Add(-schedule.Delay)
+	if !schedule.Exact {
+		prev = prev.Add(-time.Duration(t.Nanosecond()) * time.Nanosecond)
+	}
+	if prev.Before(schedule.StartTime) {
+		return time.Time{}
+	}
+	return prev
+}
+
+// nextWithJitter returns the next time this should be run, delayed by a random
+// amount of up to the jitter.  Since the given time is usually the delayed
+// activation time of the previous run, the activations are kept on the cadence
+// of Delay starting at StartTime, and the delay is added to those.
+func (schedule ConstantDelaySchedule) nextWithJitter(t time.Time) time.Time {
+	next := schedule.nextOnCadence(t)
+	if schedule.Exact {
+		return next.Add(time.Duration(randomInt63n(int64(schedule.Jitter) + 1)))
+	}
+	return next.Add(time.Duration(randomInt63n(int64(schedule.Jitter/time.Second)+1)) * time.Second)
+}
+
+// random is the source of the jitters and random tokens, which is seeded once
+// at startup rather than from the current time for each value, so that the
+// values do not depend on the clock.
+var random = struct {
+	sync.Mutex
+	*rand.Rand
+}{Rand: rand.New(rand.NewSource(time.Now().UnixNano()))}
+
+// randomInt63n returns a random number in [0, n).
+func randomInt63n(n int64) int64 {
+	random.Lock()
+	defer random.Unlock()
+	return random.Int63n(n)
+}
+
+// nextOnCadence returns the first activation on the cadence of Delay starting
+// at StartTime, which is after the given time.
+func (schedule ConstantDelaySchedule) nextOnCadence(t time.Time) time.Time {
+	next := schedule.StartTime
+	if !next.After(t) {
+		periods := t.Sub(next)/schedule.Delay + 1
+		next = next.Add(periods * schedule.Delay)
+	}
+	return next
+}
+
+// MarshalText returns the schedule as an "@every" descriptor, e.g. "@every 5m0s"
+// or "@every 5m0s~30s".  The time of the first run is not retained, except for
+// the location of an Aligned schedule, e.g.
+// "CRON_TZ=Asia/Kolkata @every 1h0m0s,@aligned".  Neither is the key of a Keyed
+// schedule, whose descriptor "@every 5m0s,H" takes it from the hash key of the
+// parser.  An Exact schedule can not be written as a descriptor, since Parse
+// rounds it.
+func (schedule ConstantDelaySchedule) MarshalText() ([]byte, error) {
+	if schedule.Exact {
+		return nil, fmt.Errorf("Exact schedule every %v can not be written as a descriptor", schedule.Delay)
+	}
+	text := "@every " + schedule.Delay.String()
+	if schedule.Jitter > 0 {
+		text += "~" + schedule.Jitter.String()
+	}
+	if schedule.Keyed {
+		text += ",H"
+	} else if schedule.Aligned {
+		text += ",@aligned"
+		if loc := schedule.StartTime.Location(); loc != time.Local {
+			text = "CRON_TZ=" + loc.String() + " " + text
+		}
+	}
+	return []byte(text), nil
+}
+
+// UnmarshalText sets the schedule to the "@every" descriptor, which is parsed
+// with Parse.
+// It returns a descriptive error if the descriptor is not valid.
+func (schedule *ConstantDelaySchedule) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	every, ok := parsed.(ConstantDelaySchedule)
+	if !ok {
+		return fmt.Errorf("Expected an @every descriptor, found %T: %s", parsed, text)
+	}
+	*schedule = every
+	return nil
+}
@@ -52,3 +52,262 @@ func TestConstantDelayNext(t *testing.T) {
 		}
 	}
 }
+
+func TestConstantDelayExactNext(t *testing.T) {
+	tests := []struct {
+		time     string
+		delay    time.Duration
+		expected string
+	}{
+		{"Mon Jul 9 14:45:00 2012", 250 * time.Millisecond, "Mon Jul 9 14:45:00.25 2012"},
+		{"Mon Jul 9 14:45:00.25 2012", 250 * time.Millisecond, "Mon Jul 9 14:45:00.5 2012"},
+		{"Mon Jul 9 14:45:00.9 2012", 250 * time.Millisecond, "Mon Jul 9 14:45:01.15 2012"},
+		{"Mon Jul 9 14:45:00.005 2012", 15*time.Minute + 50*time.Nanosecond, "Mon Jul 9 15:00:00.00500005 2012"},
+
+		// Round up to 1 millisecond if the duration is not positive.
+		{"Mon Jul 9 14:45:00 2012", 0, "Mon Jul 9 14:45:00.001 2012"},
+	}
+
+	for _, c := range tests {
+		actual := EveryExact(c.delay).Next(getTime(c.time))
+		expected := getTime(c.expected)
+		if actual != expected {
+			t.Errorf("%s, \"%s\": (expected) %v != %v (actual)", c.time, c.delay, expected, actual)
+		}
+	}
+
+	// The jitter is not rounded either.
+	schedule := EveryExact(time.Second)
+	schedule.Jitter = 100 * time.Millisecond
+	from, expected := getTime("Mon Jul 9 14:45:00.3 2012"), getTime("Mon Jul 9 14:45:01 2012")
+	for i := 0; i < 20; i++ {
+		actual := schedule.Next(from)
+		if actual.Before(expected) || actual.After(expected.Add(schedule.Jitter)) {
+			t.Errorf("(expected) %v + jitter != %v (actual)", expected, actual)
+		}
+	}
+
+	// An exact schedule is not rounded backwards.
+	if actual, expected := EveryExact(250*time.Millisecond).Prev(getTime("Mon Jul 9 14:45:00.1 2012")), getTime("Mon Jul 9 14:44:59.85 2012"); actual != expected {
+		t.Errorf("(expected) %v != %v (actual)", expected, actual)
+	}
+
+	// An exact schedule can not be written as a descriptor.
+	if _, err := EveryExact(250 * time.Millisecond).MarshalText(); err == nil {
+		t.Error("expected an error marshaling an exact schedule")
+	}
+}
+
+func TestConstantDelayJitterNext(t *testing.T) {
+	tests := []struct {
+		time     string
+		delay    time.Duration
+		jitter   time.Duration
+		expected string
+	}{
+		{"Mon Jul 9 14:45 2012", 15 * time.Minute, 30 * time.Second, "Mon Jul 9 15:00 2012"},
+		{"Mon Jul 9 14:59:59 2012", 15 * time.Minute, 30 * time.Second, "Mon Jul 9 15:00 2012"},
+
+		// Delayed activations stay on the cadence.
+		{"Mon Jul 9 15:00:29 2012", 15 * time.Minute, 30 * time.Second, "Mon Jul 9 15:15 2012"},
+		{"Mon Jul 9 23:59:45 2012", 15 * time.Second, 10 * time.Second, "Tue Jul 10 00:00:00 2012"},
+	}
+
+	for _, c := range tests {
+		schedule := EveryWithJitter(c.delay, c.jitter)
+		expected := getTime(c.expected)
+		for i := 0; i < 20; i++ {
+			actual := schedule.Next(getTime(c.time))
+			if actual.Before(expected) || actual.After(expected.Add(c.jitter)) || actual.Nanosecond() != 0 {
+				t.Errorf("%s, \"%s~%s\": (expected) %v + jitter != %v (actual)", c.time, c.delay, c.jitter, expected, actual)
+			}
+		}
+	}
+
+	// With an initial delay, the cadence starts at the first run.
+	schedule := EveryWithJitter(time.Minute, 10*time.Second)
+	schedule.StartTime = getTime("Mon Jul 9 15:00:30 2012")
+	expecteds := []struct{ time, expected string }{
+		{"Mon Jul 9 14:45 2012", "Mon Jul 9 15:00:30 2012"},
+		{"Mon Jul 9 15:00:35 2012", "Mon Jul 9 15:01:30 2012"},
+	}
+	for _, c := range expecteds {
+		expected := getTime(c.expected)
+		actual := schedule.Next(getTime(c.time))
+		if actual.Before(expected) || actual.After(expected.Add(10*time.Second)) {
+			t.Errorf("%s: (expected) %v + jitter != %v (actual)", c.time, expected, actual)
+		}
+	}
+}
+
+func TestConstantDelayAlignedNext(t *testing.T) {
+	kolkata, _ := time.LoadLocation("Asia/Kolkata")
+	tests := []struct {
+		time     string
+		delay    time.Duration
+		loc      *time.Location
+		expected string
+	}{
+		{"Mon Jul 9 14:46:01 2012", 5 * time.Minute, time.UTC, "Mon Jul 9 14:50 2012"},
+		{"Mon Jul 9 14:48:30 2012", 5 * time.Minute, time.UTC, "Mon Jul 9 14:50 2012"},
+		{"Mon Jul 9 14:50 2012", 5 * time.Minute, time.UTC, "Mon Jul 9 14:55 2012"},
+		{"Mon Jul 9 14:59:59.5 2012", 5 * time.Minute, time.UTC, "Mon Jul 9 15:00 2012"},
+		{"Mon Jul 9 14:46 2012", 15 * time.Minute, time.UTC, "Mon Jul 9 15:00 2012"},
+		{"Mon Jul 9 14:46 2012", 6 * time.Hour, time.UTC, "Mon Jul 9 18:00 2012"},
+
+		// The hours are aligned to the wall clock of the location, which is
+		// 5:30 ahead of UTC in Kolkata.
+		{"Mon Jul 9 14:46 2012", time.Hour, kolkata, "Mon Jul 9 15:30 2012"},
+	}
+
+	for _, c := range tests {
+		actual := EveryAligned(c.delay, c.loc).Next(getTime(c.time))
+		expected := getTime(c.expected)
+		if !actual.Equal(expected) {
+			t.Errorf("%s, \"%s\": (expected) %v != %v (actual)", c.time, c.delay, expected, actual)
+		}
+	}
+
+	// Parsed aligned schedules, which are aligned to the local time zone
+	// unless given a location.
+	sched, err := Parse("CRON_TZ=Asia/Kolkata @every 1h,@aligned")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual, expected := sched.Next(getTime("Mon Jul 9 14:46 2012")), getTime("Mon Jul 9 15:30 2012"); !actual.Equal(expected) {
+		t.Errorf("(expected) %v != %v (actual)", expected, actual)
+	}
+	sched, err = Parse("@every 5m,@aligned")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := sched.(ConstantDelaySchedule); !s.Aligned || s.Delay != 5*time.Minute || !s.StartTime.Equal(alignedStart(time.Local)) {
+		t.Errorf("unexpected schedule: %v", s)
+	}
+	if actual, expected := sched.(ConstantDelaySchedule).Prev(getTime("Mon Jul 9 14:46 2012")), getTime("Mon Jul 9 14:45 2012"); !actual.Equal(expected) {
+		t.Errorf("(expected) %v != %v (actual)", expected, actual)
+	}
+}
+
+func TestConstantDelayKeyedNext(t *testing.T) {
+	// The offset is derived from the key, independent of the time of the call.
+	backup := EveryWithKey(time.Hour, "backup")
+	if again := EveryWithKey(time.Hour, "backup"); again != backup {
+		t.Errorf("(expected) %v != %v (actual)", backup, again)
+	}
+	offset := backup.StartTime.Sub(time.Unix(0, 0))
+	if offset < 0 || offset >= time.Hour || offset%time.Second != 0 {
+		t.Errorf("unexpected offset %v", offset)
+	}
+	if report := EveryWithKey(time.Hour, "report"); report.StartTime.Equal(backup.StartTime) {
+		t.Errorf("expected different offsets for different keys, got %v", offset)
+	}
+
+	// The activations stay on the cadence of the offset.
+	from := getTime("Mon Jul 9 14:00 2012")
+	first := backup.Next(from)
+	if first.Sub(from) != offset {
+		t.Errorf("(expected) %v != %v (actual)", from.Add(offset), first)
+	}
+	if second := backup.Next(first.Add(5 * time.Minute)); !second.Equal(first.Add(time.Hour)) {
+		t.Errorf("(expected) %v != %v (actual)", first.Add(time.Hour), second)
+	}
+
+	// Parsed keyed schedules take the key from the parser.
+	sched, err := ParseWithHashKey("@every 1h,H", "backup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sched != backup {
+		t.Errorf("(expected) %v != %v (actual)", backup, sched)
+	}
+}
+
+func TestConstantDelayPrev(t *testing.T) {
+	tests := []struct {
+		time     string
+		delay    time.Duration
+		expected string
+	}{
+		{"Mon Jul 9 15:00 2012", 15 * time.Minute, "Mon Jul 9 14:45 2012"},
+		{"Mon Jul 9 15:14:59 2012", 15 * time.Minute, "Mon Jul 9 14:59:59 2012"},
+		{"Tue Jan 1 00:00:10 2013", 15 * time.Second, "Mon Dec 31 23:59:55 2012"},
+		{"Mon Jul 9 15:00:00.005 2012", 15 * time.Minute, "Mon Jul 9 14:45 2012"},
+	}
+
+	for _, c := range tests {
+		actual := Every(c.delay).Prev(getTime(c.time))
+		expected := getTime(c.expected)
+		if actual != expected {
+			t.Errorf("%s, \"%s\": (expected) %v != %v (actual)", c.time, c.delay, expected, actual)
+		}
+	}
+
+	// There is no activation before the first run.
+	schedule := Every(time.Minute)
+	schedule.StartTime = getTime("Mon Jul 9 15:00 2012")
+	if actual := schedule.Prev(getTime("Mon Jul 9 15:00:30 2012")); !actual.IsZero() {
+		t.Errorf("expected zero time before the first run, got %v", actual)
+	}
+
+	// With a jitter, the previous activation is on the cadence.
+	schedule = EveryWithJitter(15*time.Minute, 30*time.Second)
+	jitters := []struct{ time, expected string }{
+		{"Mon Jul 9 15:00:29 2012", "Mon Jul 9 15:00 2012"},
+		{"Mon Jul 9 15:00 2012", "Mon Jul 9 14:45 2012"},
+		{"Mon Jul 9 15:14:59 2012", "Mon Jul 9 15:00 2012"},
+	}
+	for _, c := range jitters {
+		expected := getTime(c.expected)
+		if actual := schedule.Prev(getTime(c.time)); actual != expected {
+			t.Errorf("%s: (expected) %v != %v (actual)", c.time, expected, actual)
+		}
+	}
+}
+
+func TestConstantDelayText(t *testing.T) {
+	tests := []struct {
+		schedule ConstantDelaySchedule
+		expected string
+	}{
+		{Every(5 * time.Minute), "@every 5m0s"},
+		{Every(90 * time.Minute), "@every 1h30m0s"},
+		{EveryWithJitter(5*time.Minute, 30*time.Second), "@every 5m0s~30s"},
+		{EveryAligned(5*time.Minute, time.Local), "@every 5m0s,@aligned"},
+		{EveryWithKey(5*time.Minute, ""), "@every 5m0s,H"},
+	}
+
+	for _, c := range tests {
+		text, err := c.schedule.MarshalText()
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if string(text) != c.expected {
+			t.Errorf("(expected) %q != %q (actual)", c.expected, text)
+		}
+
+		var actual ConstantDelaySchedule
+		if err := actual.UnmarshalText(text); err != nil {
+			t.Error(err)
+			continue
+		}
+		if actual != c.schedule {
+			t.Errorf("%s: (expected) %v != %v (actual)", text, c.schedule, actual)
+		}
+	}
+
+	// The location of an aligned schedule is retained.
+	kolkata, _ := time.LoadLocation("Asia/Kolkata")
+	text, err := EveryAligned(time.Hour, kolkata).MarshalText()
+	if err != nil || string(text) != "CRON_TZ=Asia/Kolkata @every 1h0m0s,@aligned" {
+		t.Errorf("(expected) \"CRON_TZ=Asia/Kolkata @every 1h0m0s,@aligned\" != %q, %v (actual)", text, err)
+	}
+
+	var s ConstantDelaySchedule
+	for _, text := range []string{"@every", "@every 5x", "0 0 * * * *"} {
+		if err := s.UnmarshalText([]byte(text)); err == nil {
+			t.Error("expected an error unmarshaling: ", text)
+		}
+	}
+}
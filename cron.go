@@ -3,7 +3,11 @@
 package cron
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"sort"
+	"sync"
 	"time"
 )
 
@@ -14,8 +18,78 @@ type Cron struct {
 	entries  []*Entry
 	stop     chan struct{}
 	add      chan *Entry
+	remove   chan EntryID
+	update   chan func()
 	snapshot chan []*Entry
+	done     chan struct{}
 	running  bool
+	parser   ScheduleParser
+	location *time.Location
+	clock    Clock
+
+	// maxConcurrency is the number of goroutines of the worker pool, if
+	// positive, which exists while the scheduler runs.
+	maxConcurrency int
+	pool           *pool
+
+	// subscribers receive the events of the Cron.
+	subscribers subscribers
+
+	// chain wraps the jobs of all entries, if set by WithChain.
+	chain Chain
+
+	// limiter bounds the rate of the starts of runs, if set by WithRateLimit.
+	limiter *rateLimiter
+
+	// panicHandler handles the panics of the jobs, and errorHandler their
+	// failed runs.  beforeRun and afterRun are the hooks around the runs.
+	panicHandler PanicHandler
+	errorHandler ErrorHandler
+	beforeRun    RunHook
+	afterRun     RunHook
+
+	// logger logs the decisions of the Cron.
+	logger Logger
+
+	// metrics records the metrics of the Cron.
+	metrics Metrics
+
+	// debug holds the DebugStats, guarded by mu.
+	debug DebugStats
+
+	// lastFire returns the last fire of an entry, if set by WithLastFire, and
+	// store persists the RunStates of the entries, guarded by storeMu.
+	lastFire func(e *Entry) time.Time
+	store    Store
+	storeMu  sync.Mutex
+
+	// locker locks the runs of the named entries across processes, and
+	// elector elects the leader among them.
+	locker  Locker
+	elector Elector
+
+	// tracer traces the runs of the jobs.
+	tracer Tracer
+
+	// jobs counts the running jobs, whose entries are counted by active.
+	// jobCtx is their context, which cancelJobs cancels.
+	jobs       sync.WaitGroup
+	mu         sync.Mutex
+	active     map[*Entry]int
+	jobCtx     context.Context
+	cancelJobs context.CancelFunc
+
+	// paused is true while no jobs are run, after PauseAll.
+	paused bool
+
+	// dependents maps the IDs of the entries to the ones triggered after their
+	// successful runs, guarded by mu.
+	dependents map[EntryID][]EntryID
+
+	// lastID is the ID of the latest entry, and names maps the names of the
+	// entries to their IDs, both guarded by mu.
+	lastID EntryID
+	names  map[string]EntryID
 }
 
 // Job is an interface for submitted cron jobs.
@@ -23,6 +97,25 @@ type Job interface {
 	Run()
 }
 
+// ContextJob is a Job, which is run with a context that is canceled when the
+// Cron stops, e.g. to abort long running work.
+type ContextJob interface {
+	Job
+	RunContext(ctx context.Context)
+}
+
+// ErrorJob is a Job, whose run may fail with an error, which is recorded by the
+// Stats of its entry.  It is run with the context of a ContextJob.
+type ErrorJob interface {
+	Job
+	RunError(ctx context.Context) error
+}
+
+// ScheduleParser is an interface for parsers of schedule specs, such as Parser.
+type ScheduleParser interface {
+	Parse(spec string) (Schedule, error)
+}
+
 // The Schedule describes a job's duty cycle.
 type Schedule interface {
 	// Return the next activation time, later than the given time.
@@ -30,11 +123,62 @@ type Schedule interface {
 	Next(time.Time) time.Time
 }
 
+// EntryID identifies an entry of a Cron.  IDs are assigned in the order of
+// addition, starting at 1, and are not reused.
+type EntryID int
+
 // Entry consists of a schedule and the func to execute on that schedule.
 type Entry struct {
+	// ID identifies the entry, e.g. to remove it.
+	ID EntryID
+
+	// Name is the unique name of the entry, if it has been added with
+	// AddNamedJob.
+	Name string
+
+	// Tags group the entry with others, e.g. by tenant, to manage them with
+	// EntriesByTag, RemoveByTag and PauseByTag.
+	Tags []string
+
+	// Paused is true if the entry has been paused by Pause or PauseByTag, so
+	// that its job is not run at its activations.
+	Paused bool
+
+	// missed is true if an activation has been suppressed by PauseAll.
+	missed bool
+
+	// onError handles the failed runs of the job, if set by OnError, and
+	// retry is its policy set by SetRetryPolicy.
+	onError ErrorHandler
+	retry   *RetryPolicy
+
+	// backoff is the policy set by SetFailureBackoff, and timeout the limit
+	// set by SetTimeout.
+	backoff *FailureBackoff
+	timeout time.Duration
+
+	// slots holds a value for each running job, up to the maximum set by
+	// WithEntryConcurrency, and priority is the one set by SetPriority.
+	slots    chan struct{}
+	priority int
+
+	// misfire is the policy set by SetMisfirePolicy, and pending holds the
+	// activations suppressed by pausing, to be coalesced by MisfireCoalesce.
+	misfire MisfirePolicy
+	pending []time.Time
+
+	// Spec is the spec of the schedule, if the entry has been added by a spec
+	// rather than a Schedule.
+	Spec string
+
 	// The schedule on which this job should be run.
 	Schedule Schedule
 
+	// Location is the time zone in which the schedule is evaluated, if the
+	// entry has been added with AddJobIn, e.g. for the local time of a
+	// customer.  A nil Location evaluates it in the location of the Cron.
+	Location *time.Location
+
 	// The next time the job will run. This is the zero time if Cron has not been
 	// started or this entry's schedule is unsatisfiable
 	Next time.Time
@@ -45,6 +189,10 @@ type Entry struct {
 
 	// The Job to run.
 	Job Job
+
+	// Stats are the execution statistics of the job, guarded by the mutex of
+	// the Cron.
+	Stats Stats
 }
 
 // byTime is a wrapper for sorting the entry array by time
@@ -66,15 +214,41 @@ func (s byTime) Less(i, j int) bool {
 	return s[i].Next.Before(s[j].Next)
 }
 
-// New returns a new Cron job runner.
-func New() *Cron {
-	return &Cron{
+// New returns a new Cron job runner, configured by the options.  By default,
+// it parses specs with Parse, and evaluates the schedules in the local time
+// zone by the SystemClock.
+func New(opts ...Option) *Cron {
+	c := &Cron{
 		entries:  nil,
 		add:      make(chan *Entry),
+		remove:   make(chan EntryID),
+		update:   make(chan func()),
 		stop:     make(chan struct{}),
 		snapshot: make(chan []*Entry),
+		done:     make(chan struct{}),
 		running:  false,
+		parser:   defaultParser,
+		location: time.Local,
+		clock:    SystemClock,
+		active:   make(map[*Entry]int),
+		names:    make(map[string]EntryID),
+
+		logger:     DefaultLogger,
+		dependents: make(map[EntryID][]EntryID),
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	if p, ok := c.parser.(Parser); ok && c.clock != SystemClock {
+		c.parser = p.WithClock(c.clock)
+	}
+	return c
+}
+
+// NewWithParser returns a new Cron job runner, which parses the specs given to
+// AddFunc and AddJob with the given parser, like New(WithParser(p)).
+func NewWithParser(p ScheduleParser) *Cron {
+	return New(WithParser(p))
 }
 
 // A wrapper that turns a func() into a cron.Job
@@ -82,118 +256,782 @@ type FuncJob func()
 
 func (f FuncJob) Run() { f() }
 
-// AddFunc adds a func to the Cron to be run on the given schedule.
-func (c *Cron) AddFunc(spec string, cmd func()) error {
-	return c.AddJob(spec, FuncJob(cmd))
+// A wrapper that turns a func(context.Context) into a cron.ContextJob.  Run
+// calls it with context.Background().
+type ContextFuncJob func(ctx context.Context)
+
+func (f ContextFuncJob) Run()                           { f(context.Background()) }
+func (f ContextFuncJob) RunContext(ctx context.Context) { f(ctx) }
+
+// A wrapper that turns a func(ctx) error into a cron.ErrorJob.
+type ErrorFuncJob func(ctx context.Context) error
+
+func (f ErrorFuncJob) Run()                               { f(context.Background()) }
+func (f ErrorFuncJob) RunError(ctx context.Context) error { return f(ctx) }
+
+// AddFunc adds a func to the Cron to be run on the given schedule, and returns
+// the ID of its entry.
+func (c *Cron) AddFunc(spec string, cmd func(), opts ...EntryOption) (EntryID, error) {
+	return c.AddJob(spec, FuncJob(cmd), opts...)
+}
+
+// AddContextFunc adds a func to the Cron to be run on the given schedule, with
+// the context of the Cron, and returns the ID of its entry.
+func (c *Cron) AddContextFunc(spec string, cmd func(ctx context.Context), opts ...EntryOption) (EntryID, error) {
+	return c.AddJob(spec, ContextFuncJob(cmd), opts...)
+}
+
+// AddJob adds a Job to the Cron to be run on the given schedule, and returns
+// the ID of its entry.  The entry is configured by the options, like the ones
+// of the other Add methods, e.g. WithEntryConcurrency.
+func (c *Cron) AddJob(spec string, cmd Job, opts ...EntryOption) (EntryID, error) {
+	schedule, err := c.parse(spec)
+	if err != nil {
+		return 0, err
+	}
+	return c.addEntry(&Entry{Spec: spec, Schedule: schedule, Job: cmd}, opts)
+}
+
+// parse parses the spec with the parser of the Cron, logging a failure.
+func (c *Cron) parse(spec string) (Schedule, error) {
+	schedule, err := c.parser.Parse(spec)
+	if err != nil {
+		c.logger.Error(err, "failed to parse spec", "spec", spec)
+	}
+	return schedule, err
+}
+
+// AddJobIn adds a Job to the Cron to be run on the given schedule, evaluated in
+// the given location rather than the one of the Cron, and returns the ID of
+// its entry.  A time zone given by the spec takes precedence.
+func (c *Cron) AddJobIn(loc *time.Location, spec string, cmd Job, opts ...EntryOption) (EntryID, error) {
+	schedule, err := c.parse(spec)
+	if err != nil {
+		return 0, err
+	}
+	return c.addEntry(&Entry{Spec: spec, Schedule: schedule, Location: loc, Job: cmd}, opts)
+}
+
+// AddNamedJob adds a Job to the Cron to be run on the given schedule, under a
+// name to look it up with EntryByName, and returns the ID of its entry.  It
+// returns an error if an entry of the Cron already has the name.
+func (c *Cron) AddNamedJob(name, spec string, cmd Job, opts ...EntryOption) (EntryID, error) {
+	schedule, err := c.parse(spec)
+	if err != nil {
+		return 0, err
+	}
+	return c.addEntry(&Entry{Name: name, Spec: spec, Schedule: schedule, Job: cmd}, opts)
+}
+
+// Schedule adds a Job to the Cron to be run on the given schedule, and returns
+// the ID of its entry.
+func (c *Cron) Schedule(schedule Schedule, cmd Job, opts ...EntryOption) EntryID {
+	id, _ := c.addEntry(&Entry{Schedule: schedule, Job: cmd}, opts)
+	return id
 }
 
-// AddFunc adds a Job to the Cron to be run on the given schedule.
-func (c *Cron) AddJob(spec string, cmd Job) error {
-	schedule, err := Parse(spec)
+// addEntry configures the entry with the options, assigns it an ID and adds it
+// to the Cron.  It returns an error if the name of the entry is taken.
+func (c *Cron) addEntry(entry *Entry, opts []EntryOption) (EntryID, error) {
+	for _, opt := range opts {
+		opt(entry)
+	}
+	entry.Job = c.chain.Then(entry.Job)
+	c.mu.Lock()
+	if entry.Name != "" {
+		if _, ok := c.names[entry.Name]; ok {
+			c.mu.Unlock()
+			return 0, fmt.Errorf("Entry named %q already exists", entry.Name)
+		}
+	}
+	c.lastID++
+	entry.ID = c.lastID
+	if entry.Name != "" {
+		c.names[entry.Name] = entry.ID
+	}
+	c.mu.Unlock()
+
+	if !c.running {
+		c.entries = append(c.entries, entry)
+		c.entriesChanged()
+		c.emit(Event{Type: EntryAdded, ID: entry.ID, Name: entry.Name})
+		return entry.ID, nil
+	}
+
+	select {
+	case c.add <- entry:
+	case <-c.done:
+		c.entries = append(c.entries, entry)
+		c.entriesChanged()
+	}
+	c.emit(Event{Type: EntryAdded, ID: entry.ID, Name: entry.Name})
+	return entry.ID, nil
+}
+
+// Remove removes the entry with the given ID, so that its job is not run
+// anymore.  Jobs of the entry which are running keep running.  Removing an
+// unknown ID has no effect.
+func (c *Cron) Remove(id EntryID) {
+	if !c.running {
+		c.removeEntry(id)
+		return
+	}
+
+	select {
+	case c.remove <- id:
+	case <-c.done:
+		c.removeEntry(id)
+	}
+}
+
+// removeEntry removes the entry with the given ID from the entries, which
+// frees its name.
+func (c *Cron) removeEntry(id EntryID) {
+	for i, e := range c.entries {
+		if e.ID == id {
+			c.entries = append(c.entries[:i], c.entries[i+1:]...)
+			c.entriesChanged()
+			c.mu.Lock()
+			if e.Name != "" {
+				delete(c.names, e.Name)
+			}
+			c.removeDependencies(id)
+			c.mu.Unlock()
+			c.emit(Event{Type: EntryRemoved, ID: e.ID, Name: e.Name})
+			return
+		}
+	}
+}
+
+// Tag adds tags to the entry with the given ID.
+func (c *Cron) Tag(id EntryID, tags ...string) {
+	c.updateEntries(func(bool) {
+		for _, e := range c.entries {
+			if e.ID == id {
+				for _, tag := range tags {
+					if !e.hasTag(tag) {
+						e.Tags = append(e.Tags, tag)
+					}
+				}
+			}
+		}
+	})
+}
+
+// UpdateSchedule changes the schedule of the entry with the given ID to the
+// spec, keeping its ID, name, tags and previous run.  A running Cron activates
+// the entry next by the new schedule.  It returns an error if the spec is
+// invalid, or if there is no such entry.
+func (c *Cron) UpdateSchedule(id EntryID, spec string) error {
+	schedule, err := c.parse(spec)
 	if err != nil {
 		return err
 	}
-	c.Schedule(schedule, cmd)
+	return c.updateEntry(id, func(e *Entry, running bool) {
+		e.Spec, e.Schedule = spec, schedule
+		if running {
+			e.Next = e.next(c.now())
+		}
+	})
+}
+
+// UpdateJob replaces the job of the entry with the given ID, from its next
+// activation on.  Jobs of the entry which are running keep running.  It
+// returns an error if there is no such entry.
+func (c *Cron) UpdateJob(id EntryID, cmd Job) error {
+	cmd = c.chain.Then(cmd)
+	return c.updateEntry(id, func(e *Entry, running bool) {
+		e.Job = cmd
+	})
+}
+
+// RunNow runs the job of the entry with the given ID right away, in its own
+// goroutine like at its activations, e.g. to backfill.  Paused entries are run
+// as well, and the next activation of the entry is kept.  It returns an error
+// if there is no such entry, or if the Cron is not running.
+func (c *Cron) RunNow(id EntryID) error {
+	var err error
+	if updateErr := c.updateEntry(id, func(e *Entry, running bool) {
+		if !running {
+			err = errors.New("Cron is not running")
+			return
+		}
+		c.dispatch(e, c.now())
+	}); updateErr != nil {
+		return updateErr
+	}
+	return err
+}
+
+// updateEntry calls the func to update the entry with the given ID, like
+// updateEntries.  It returns an error if there is no such entry.
+func (c *Cron) updateEntry(id EntryID, f func(e *Entry, running bool)) error {
+	found := false
+	c.updateEntries(func(running bool) {
+		for _, e := range c.entries {
+			if e.ID == id {
+				f(e, running)
+				found = true
+			}
+		}
+	})
+	if !found {
+		return fmt.Errorf("No entry with ID %d", id)
+	}
 	return nil
 }
 
-// Schedule adds a Job to the Cron to be run on the given schedule.
-func (c *Cron) Schedule(schedule Schedule, cmd Job) {
-	entry := &Entry{
-		Schedule: schedule,
-		Job:      cmd,
+// EntriesByTag returns a snapshot of the entries with the given tag.
+func (c *Cron) EntriesByTag(tag string) []*Entry {
+	var entries []*Entry
+	for _, e := range c.Entries() {
+		if e.hasTag(tag) {
+			entries = append(entries, e)
+		}
 	}
+	return entries
+}
+
+// RemoveByTag removes the entries with the given tag like Remove, and returns
+// their number.
+func (c *Cron) RemoveByTag(tag string) int {
+	var ids []EntryID
+	c.updateEntries(func(bool) {
+		for _, e := range c.entries {
+			if e.hasTag(tag) {
+				ids = append(ids, e.ID)
+			}
+		}
+		for _, id := range ids {
+			c.removeEntry(id)
+		}
+	})
+	return len(ids)
+}
+
+// PauseByTag pauses the entries with the given tag, and returns their number.
+// Their schedules keep advancing, but their jobs are not run until they are
+// resumed.  Jobs which are running keep running.
+func (c *Cron) PauseByTag(tag string) int {
+	return c.setPaused(func(e *Entry) bool { return e.hasTag(tag) }, true)
+}
+
+// ResumeByTag resumes the paused entries with the given tag, and returns the
+// number of entries with the tag.  Their jobs are run from their next
+// activation on.
+func (c *Cron) ResumeByTag(tag string) int {
+	return c.setPaused(func(e *Entry) bool { return e.hasTag(tag) }, false)
+}
+
+// Pause pauses the entry with the given ID, until it is resumed.  Its schedule
+// keeps advancing, but its job is not run.  Jobs of the entry which are running
+// keep running.
+func (c *Cron) Pause(id EntryID) {
+	c.setPaused(func(e *Entry) bool { return e.ID == id }, true)
+}
+
+// Resume resumes the entry with the given ID, whose job is run from its next
+// activation on.
+func (c *Cron) Resume(id EntryID) {
+	c.setPaused(func(e *Entry) bool { return e.ID == id }, false)
+}
+
+// setPaused sets whether the matching entries are paused, and returns their
+// number.
+func (c *Cron) setPaused(match func(e *Entry) bool, paused bool) int {
+	count := 0
+	c.updateEntries(func(running bool) {
+		for _, e := range c.entries {
+			if match(e) {
+				e.Paused = paused
+				count++
+				if !paused && !c.paused && running {
+					c.resumeCoalesced(e)
+				}
+			}
+		}
+	})
+	return count
+}
+
+// ResumePolicy determines what ResumeAll does with the activations, which have
+// been suppressed while the Cron has been paused.
+type ResumePolicy int
+
+const (
+	// SkipMissed skips the suppressed activations.
+	SkipMissed ResumePolicy = iota
+
+	// CoalesceMissed runs the job of each entry with suppressed activations
+	// once, when the Cron is resumed.
+	CoalesceMissed
+)
+
+// PauseAll pauses the Cron, until ResumeAll is called.  The entries are kept
+// and their schedules keep advancing, but no jobs are run.  Jobs which are
+// running keep running.
+func (c *Cron) PauseAll() {
+	c.updateEntries(func(bool) { c.paused = true })
+}
+
+// ResumeAll resumes the Cron after PauseAll, handling the activations which
+// have been suppressed in the meantime according to the policy.  Entries which
+// are paused themselves are not run.
+func (c *Cron) ResumeAll(policy ResumePolicy) {
+	c.updateEntries(func(running bool) {
+		c.paused = false
+		now := c.now()
+		for _, e := range c.entries {
+			switch {
+			case e.Paused:
+			case policy == CoalesceMissed && running && len(e.pending) > 0:
+				c.resumeCoalesced(e)
+			case e.missed && policy == CoalesceMissed && running:
+				c.dispatch(e, now)
+			default:
+				e.pending = nil
+			}
+			e.missed = false
+		}
+	})
+}
+
+// updateEntries calls the func to update the entries, in the scheduler
+// goroutine if the Cron is running, which is passed to the func.
+func (c *Cron) updateEntries(f func(running bool)) {
 	if !c.running {
-		c.entries = append(c.entries, entry)
+		f(false)
 		return
 	}
 
-	c.add <- entry
+	updated := make(chan struct{})
+	select {
+	case c.update <- func() { f(true); close(updated) }:
+		<-updated
+	case <-c.done:
+		f(false)
+	}
 }
 
-// Entries returns a snapshot of the cron entries.
+// hasTag returns true if the entry has the given tag.
+func (e *Entry) hasTag(tag string) bool {
+	for _, t := range e.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// EntryByName returns a snapshot of the entry with the given name, or nil if
+// the Cron has no such entry.
+func (c *Cron) EntryByName(name string) *Entry {
+	c.mu.Lock()
+	id, ok := c.names[name]
+	c.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	for _, e := range c.Entries() {
+		if e.ID == id {
+			return e
+		}
+	}
+	return nil
+}
+
+// Entries returns a snapshot of the cron entries, e.g. for a dashboard.  The
+// entries are copies, which are not changed by the Cron, and changing them has
+// no effect on the Cron.
 func (c *Cron) Entries() []*Entry {
 	if c.running {
-		c.snapshot <- nil
-		x := <-c.snapshot
-		return x
+		select {
+		case c.snapshot <- nil:
+			x := <-c.snapshot
+			return x
+		case <-c.done:
+		}
 	}
 	return c.entrySnapshot()
 }
 
 // Start the cron scheduler in its own go-routine.
 func (c *Cron) Start() {
+	c.StartContext(context.Background())
+}
+
+// StartContext starts the cron scheduler in its own go-routine, which stops
+// when Stop is called or the context is done.  Jobs implementing ContextJob
+// are run with a context derived from it, which is canceled when the scheduler
+// stops.
+func (c *Cron) StartContext(ctx context.Context) {
+	c.starting()
+	go c.run(ctx)
+}
+
+// Run runs the cron scheduler in the calling go-routine, like StartContext,
+// until Stop is called or the context is done.  It returns the error of the
+// context if it is done, or nil if the Cron has been stopped, e.g. to be run
+// as part of an errgroup.Group.
+func (c *Cron) Run(ctx context.Context) error {
+	c.starting()
+	return c.run(ctx)
+}
+
+// starting marks the Cron as running, with a new done channel if it has been
+// running before.
+func (c *Cron) starting() {
 	c.running = true
-	go c.run()
+	select {
+	case <-c.done:
+		c.done = make(chan struct{})
+	default:
+	}
 }
 
 // Run the scheduler.. this is private just due to the need to synchronize
 // access to the 'running' state variable.
-func (c *Cron) run() {
+func (c *Cron) run(ctx context.Context) error {
+	defer close(c.done)
+	defer c.emit(Event{Type: SchedulerStopped})
+	defer c.setNextWake(time.Time{})
+
+	// The context of the jobs is canceled by stopping the scheduler, or when
+	// its context is done.
+	c.jobCtx, c.cancelJobs = context.WithCancel(ctx)
+	c.logger.Info("start")
+
+	// The worker pool runs the queued jobs after stopping.
+	if c.maxConcurrency > 0 {
+		c.pool = newPool(c.maxConcurrency)
+		defer c.pool.close()
+	}
+
 	// Figure out the next activation times for each entry.
-	now := time.Now().Local()
+	now := c.now()
 	for _, entry := range c.entries {
-		entry.Next = entry.Schedule.Next(now)
+		c.activate(entry, now)
 	}
 
+	// Followers of an Elector do not wake up for the activations.
+	elections, leading := c.elect(c.jobCtx)
+
 	for {
 		// Determine the next entry to run.
 		sort.Sort(byTime(c.entries))
 
 		var effective time.Time
-		if len(c.entries) == 0 || c.entries[0].Next.IsZero() {
+		if len(c.entries) == 0 || c.entries[0].Next.IsZero() || !leading {
 			// If there are no entries yet, just sleep - it still handles new entries
 			// and stop requests.
 			effective = now.AddDate(10, 0, 0)
+			c.setNextWake(time.Time{})
 		} else {
 			effective = c.entries[0].Next
+			c.setNextWake(effective)
 		}
 
 		select {
-		case now = <-time.After(effective.Sub(now)):
+		case now = <-c.clock.After(effective.Sub(now)):
+			now = now.In(c.location)
+			c.logger.Info("wake", "now", now)
 			// Run every entry whose next time was this effective time.
 			for _, e := range c.entries {
 				if e.Next != effective {
 					break
 				}
-				go e.Job.Run()
-				e.Prev = e.Next
-				e.Next = e.Schedule.Next(effective)
+				e.Next = e.next(effective)
+				if activations := c.catchUp(e, effective, now); len(activations) > 0 {
+					c.fire(e, activations)
+				}
 			}
 			continue
 
 		case newEntry := <-c.add:
 			c.entries = append(c.entries, newEntry)
-			newEntry.Next = newEntry.Schedule.Next(now)
+			c.entriesChanged()
+			c.activate(newEntry, now)
+
+		case id := <-c.remove:
+			c.removeEntry(id)
+
+		case leader, ok := <-elections:
+			if !ok {
+				elections = nil
+			}
+			if leader = leader && ok; leader != leading {
+				leading = leader
+				c.logger.Info("leadership", "leader", leading)
+				if leading {
+					c.lead(c.now())
+				}
+			}
+
+		case f := <-c.update:
+			f()
 
 		case <-c.snapshot:
 			c.snapshot <- c.entrySnapshot()
 
 		case <-c.stop:
-			return
+			return nil
+
+		case <-ctx.Done():
+			return ctx.Err()
 		}
 
 		// 'now' should be updated after newEntry and snapshot cases.
-		now = time.Now().Local()
+		now = c.now()
+	}
+}
+
+// now returns the current time in the location of the Cron.
+func (c *Cron) now() time.Time {
+	return c.clock.Now().In(c.location)
+}
+
+// firstActivation returns the first activation time of the entry, when it is
+// added to the running Cron at the given time.  A RebootSchedule activates
+// immediately.
+func (e *Entry) firstActivation(now time.Time) time.Time {
+	if _, ok := e.Schedule.(RebootSchedule); ok {
+		return now
+	}
+	return e.next(now)
+}
+
+// next returns the next activation of the entry after the given time, in the
+// location of the given time.  The schedule is evaluated in the location of
+// the entry, if any.
+func (e *Entry) next(t time.Time) time.Time {
+	if e.Location == nil {
+		return e.Schedule.Next(t)
+	}
+	next := e.Schedule.Next(t.In(e.Location))
+	if next.IsZero() {
+		return next
+	}
+	return next.In(t.Location())
+}
+
+// fire runs the job of the entry once for the activations, unless it or the
+// Cron is paused, or the entry is backed off.
+func (c *Cron) fire(e *Entry, activations []time.Time) {
+	activation := activations[len(activations)-1]
+	switch {
+	case c.paused:
+		e.missed = !e.Paused
+		e.suppress(activations)
+		c.skipped(e, activation, "paused")
+	case e.Paused:
+		e.suppress(activations)
+		c.skipped(e, activation, "entry paused")
+	case c.backedOff(e, activation):
+		c.skipped(e, activation, "backed off")
+	default:
+		c.dispatchAll(e, activations)
+	}
+}
+
+// skipped logs and emits an activation of the entry, which is skipped for the
+// given reason.
+func (c *Cron) skipped(e *Entry, activation time.Time, reason string) {
+	c.logger.Info("skip", "entry", e.ID, "name", e.Name, "scheduled", activation, "reason", reason)
+	c.emit(Event{Type: RunSkipped, ID: e.ID, Name: e.Name, Scheduled: activation})
+	if c.metrics != nil {
+		c.observeRun(RunInfo{Entry: e.run(activation), Scheduled: activation, Err: ErrSkipped})
+	}
+}
+
+// dispatch runs the job of the entry in its own goroutine, as activated at the
+// given time.
+func (c *Cron) dispatch(e *Entry, activation time.Time) {
+	c.dispatchAll(e, []time.Time{activation})
+}
+
+// dispatchAll runs the job of the entry once for the activations, like
+// dispatch at the latest one, passing them by the context of the run.
+func (c *Cron) dispatchAll(e *Entry, activations []time.Time) {
+	activation := activations[len(activations)-1]
+	c.startJob(e)
+	run := e.run(activation)
+	c.emitRun(RunScheduled, RunInfo{Entry: run, Scheduled: activation})
+	ctx := withActivations(c.jobCtx, activations)
+	update, done := c.update, c.done
+	c.execute(run.priority, func() {
+		release, err := c.admit(ctx, run)
+		start := c.started(e, activation)
+		if err == nil {
+			info := RunInfo{Entry: run, Scheduled: activation, Start: start}
+			c.saveRun(run, activation, false)
+			c.before(info)
+			err = c.trace(ctx, info)
+			release()
+			c.saveRun(run, activation, true)
+		}
+		finish := c.clock.Now()
+		c.finished(RunInfo{Entry: run, Scheduled: activation, Start: start, Duration: finish.Sub(start), Err: err})
+		c.finishJob(e, start, finish, err)
+		if err == nil {
+			c.triggerDependents(run.ID, update, done)
+		}
+	})
+	e.Prev = activation
+}
+
+// execute runs the func in its own goroutine, or in the worker pool of the
+// maximum concurrency by the priority.
+func (c *Cron) execute(priority int, f func()) {
+	if c.pool != nil {
+		c.pool.submit(priority, f)
+		return
 	}
+	go f()
 }
 
-// Stop the cron scheduler.
-func (c *Cron) Stop() {
-	c.stop <- struct{}{}
+// run returns a copy of the entry for its run at the activation, without its
+// statistics, e.g. to be passed to handlers.
+func (e *Entry) run(activation time.Time) *Entry {
+	return &Entry{
+		ID:       e.ID,
+		Name:     e.Name,
+		Tags:     append([]string(nil), e.Tags...),
+		Paused:   e.Paused,
+		Spec:     e.Spec,
+		Schedule: e.Schedule,
+		Location: e.Location,
+		Next:     e.Next,
+		Prev:     activation,
+		Job:      e.Job,
+		onError:  e.onError,
+		retry:    e.retry,
+		timeout:  e.timeout,
+		slots:    e.slots,
+		priority: e.priority,
+	}
+}
+
+// startJob counts a job of the entry as running, which may wait in the queue
+// of the worker pool.
+func (c *Cron) startJob(e *Entry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.jobs.Add(1)
+	c.active[e]++
+}
+
+// started records the start of a run of the entry at the activation, and
+// returns it.
+func (c *Cron) started(e *Entry, activation time.Time) time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	start := c.clock.Now()
+	e.Stats.start(start)
+	c.debug.DispatchLatency = start.Sub(activation)
+	c.debug.InFlight++
+	c.inFlightChanged()
+	return start
+}
+
+// finishJob counts a job of the entry as completed, with the error of its run.
+func (c *Cron) finishJob(e *Entry, start, finish time.Time, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e.Stats.finish(start, finish, err)
+	c.debug.InFlight--
+	c.inFlightChanged()
+	if c.active[e]--; c.active[e] == 0 {
+		delete(c.active, e)
+	}
+	c.jobs.Done()
+}
+
+// runJob runs the job, with the context if it is a ContextJob or an ErrorJob,
+// and returns the error of an ErrorJob.
+func runJob(ctx context.Context, job Job) error {
+	switch j := job.(type) {
+	case ErrorJob:
+		return j.RunError(ctx)
+	case ContextJob:
+		j.RunContext(ctx)
+		return nil
+	}
+	job.Run()
+	return nil
+}
+
+// Stop the cron scheduler, and cancel the context of the running jobs.
+// Stopping a scheduler whose context is done already returns immediately.
+// Jobs which are running keep running; the returned context is done when all
+// of them have completed, e.g. to wait for them before exiting:
+//
+//	<-c.Stop().Done()
+func (c *Cron) Stop() context.Context {
+	ctx := c.stopScheduler()
+	c.cancelJobs()
+	return ctx
+}
+
+// StopWithTimeout stops the cron scheduler, and waits up to the timeout for the
+// running jobs to complete, e.g. within the grace period of a termination.  It
+// then cancels the context of the jobs which are still running, and returns a
+// snapshot of their entries.
+func (c *Cron) StopWithTimeout(timeout time.Duration) []*Entry {
+	ctx := c.stopScheduler()
+	defer c.cancelJobs()
+	c.logger.Info("waiting for jobs", "timeout", timeout)
+	select {
+	case <-ctx.Done():
+		c.logger.Info("jobs completed")
+		return nil
+	case <-c.clock.After(timeout):
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var entries []*Entry
+	for _, e := range c.entries {
+		if c.active[e] > 0 {
+			entries = append(entries, e.snapshot())
+		}
+	}
+	c.logger.Info("canceling jobs", "entries", len(entries))
+	return entries
+}
+
+// stopScheduler stops the scheduler, and returns a context which is done when
+// all running jobs have completed.
+func (c *Cron) stopScheduler() context.Context {
+	select {
+	case c.stop <- struct{}{}:
+	case <-c.done:
+	}
 	c.running = false
+	c.logger.Info("stop")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		c.jobs.Wait()
+		cancel()
+	}()
+	return ctx
 }
 
 // entrySnapshot returns a copy of the current cron entry list.
 func (c *Cron) entrySnapshot() []*Entry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	entries := []*Entry{}
 	for _, e := range c.entries {
-		entries = append(entries, &Entry{
-			Schedule: e.Schedule,
-			Next:     e.Next,
-			Prev:     e.Prev,
-			Job:      e.Job,
-		})
+		entries = append(entries, e.snapshot())
 	}
 	return entries
 }
+
+// snapshot returns a copy of the entry, whose Cron's mutex must be held.
+func (e *Entry) snapshot() *Entry {
+	entry := *e
+	entry.Tags = append([]string(nil), e.Tags...)
+	entry.Stats = e.Stats.snapshot()
+	return &entry
+}
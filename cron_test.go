@@ -1,6 +1,8 @@
 package cron
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"testing"
@@ -146,6 +148,24 @@ func TestRunningJobTwice(t *testing.T) {
 	}
 }
 
+// Test that a spec with milliseconds runs within the second.
+func TestRunningMilliseconds(t *testing.T) {
+	wg := &sync.WaitGroup{}
+	wg.Add(3)
+
+	cron := New()
+	cron.AddFunc("*.0/100 * * * * ?", func() { wg.Done() })
+
+	cron.Start()
+	defer cron.Stop()
+
+	select {
+	case <-time.After(ONE_SECOND / 2):
+		t.FailNow()
+	case <-wait(wg):
+	}
+}
+
 func TestRunningMultipleSchedules(t *testing.T) {
 	wg := &sync.WaitGroup{}
 	wg.Add(2)
@@ -189,6 +209,190 @@ func TestLocalTimezone(t *testing.T) {
 	}
 }
 
+// Test that the specs are parsed with the parser given to the Cron.
+func TestCustomParser(t *testing.T) {
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+
+	cron := NewWithParser(NewParser(Second | Minute | Hour))
+	if _, err := cron.AddFunc("* * * * *", func() {}); err == nil {
+		t.Error("expected an error adding a spec with too many fields")
+	}
+	if _, err := cron.AddFunc("* * *", func() { wg.Done() }); err != nil {
+		t.Fatal(err)
+	}
+	cron.Start()
+	defer cron.Stop()
+
+	select {
+	case <-time.After(ONE_SECOND):
+		t.FailNow()
+	case <-wait(wg):
+	}
+}
+
+// Test that a @reboot entry runs once, immediately after starting cron.
+func TestReboot(t *testing.T) {
+	runs := make(chan struct{}, 2)
+
+	cron := New()
+	cron.AddFunc("@reboot", func() { runs <- struct{}{} })
+	cron.Start()
+	defer cron.Stop()
+
+	select {
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("expected @reboot entry to run on start")
+	case <-runs:
+	}
+
+	select {
+	case <-time.After(ONE_SECOND):
+	case <-runs:
+		t.Error("expected @reboot entry to run only once")
+	}
+}
+
+// Canceling the context stops the scheduler and cancels the context of the
+// running jobs.
+func TestStartContext(t *testing.T) {
+	started, canceled := make(chan struct{}, 1), make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	cron := New()
+	cron.AddContextFunc("@reboot", func(ctx context.Context) {
+		started <- struct{}{}
+		<-ctx.Done()
+		close(canceled)
+	})
+	cron.StartContext(ctx)
+
+	select {
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("expected the job to run on start")
+	case <-started:
+	}
+	cancel()
+	select {
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("expected the context of the job to be canceled")
+	case <-canceled:
+	}
+
+	// Stop returns, and new entries are kept, after the scheduler stopped.
+	select {
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("expected Stop to return")
+	case <-stop(cron):
+	}
+	cron.AddFunc("@daily", func() {})
+	if entries := cron.Entries(); len(entries) != 2 {
+		t.Errorf("(expected) 2 entries != %d (actual)", len(entries))
+	}
+}
+
+// The context returned by Stop is done when the running jobs have completed.
+func TestStopWaitsForJobs(t *testing.T) {
+	started, finish := make(chan struct{}), make(chan struct{})
+
+	cron := New()
+	cron.AddFunc("@reboot", func() {
+		close(started)
+		<-finish
+	})
+	cron.Start()
+	<-started
+
+	ctx := cron.Stop()
+	select {
+	case <-ctx.Done():
+		t.Fatal("expected the context to wait for the running job")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(finish)
+	select {
+	case <-ctx.Done():
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("expected the context to be done after the job completed")
+	}
+
+	// Without running jobs, the context is done right away.
+	cron = New()
+	cron.Start()
+	select {
+	case <-cron.Stop().Done():
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("expected the context to be done")
+	}
+}
+
+// StopWithTimeout waits for the running jobs, until it cancels their context.
+func TestStopWithTimeout(t *testing.T) {
+	started, canceled := make(chan struct{}, 2), make(chan struct{})
+
+	quick := &sync.WaitGroup{}
+	quick.Add(1)
+
+	cron := New()
+	cron.AddJob("@reboot", testJob{quick, "quick"})
+	cron.AddContextFunc("@reboot", func(ctx context.Context) {
+		started <- struct{}{}
+		<-ctx.Done()
+		close(canceled)
+	})
+	cron.Start()
+	<-started
+
+	begin := time.Now()
+	entries := cron.StopWithTimeout(50 * time.Millisecond)
+	if elapsed := time.Since(begin); elapsed < 50*time.Millisecond {
+		t.Errorf("expected to wait for the timeout, returned after %v", elapsed)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("(expected) 1 entry != %d (actual)", len(entries))
+	}
+	if _, ok := entries[0].Job.(ContextFuncJob); !ok {
+		t.Errorf("(expected) the blocking job != %v (actual)", entries[0].Job)
+	}
+	select {
+	case <-canceled:
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("expected the context of the job to be canceled")
+	}
+
+	// Jobs completing within the timeout are not reported.
+	cron = New()
+	cron.AddFunc("@reboot", func() { time.Sleep(10 * time.Millisecond) })
+	cron.Start()
+	time.Sleep(5 * time.Millisecond)
+	if entries := cron.StopWithTimeout(time.Second); entries != nil {
+		t.Errorf("(expected) no entries != %v (actual)", entries)
+	}
+}
+
+// Run blocks until the context is done, or the Cron is stopped.
+func TestRun(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := New().Run(ctx); err != context.DeadlineExceeded {
+		t.Errorf("(expected) %v != %v (actual)", context.DeadlineExceeded, err)
+	}
+
+	cron := New()
+	result := make(chan error)
+	go func() { result <- cron.Run(context.Background()) }()
+	time.Sleep(10 * time.Millisecond)
+	cron.Stop()
+	select {
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("expected Run to return after Stop")
+	case err := <-result:
+		if err != nil {
+			t.Errorf("unexpected error %v", err)
+		}
+	}
+}
+
 type testJob struct {
 	wg   *sync.WaitGroup
 	name string
@@ -253,3 +457,360 @@ func stop(cron *Cron) chan bool {
 	}()
 	return ch
 }
+
+// Entries get increasing IDs, by which they can be removed while running.
+func TestRemove(t *testing.T) {
+	removed, kept := make(chan struct{}, 10), make(chan struct{}, 10)
+
+	cron := New()
+	first, _ := cron.AddFunc("* * * * * ?", func() { removed <- struct{}{} })
+	second, _ := cron.AddFunc("* * * * * ?", func() { kept <- struct{}{} })
+	if first != 1 || second != 2 {
+		t.Errorf("(expected) IDs 1, 2 != %d, %d (actual)", first, second)
+	}
+	cron.Start()
+	defer cron.Stop()
+
+	cron.Remove(first)
+	cron.Remove(first) // Removing an unknown ID has no effect.
+	entries := cron.Entries()
+	if len(entries) != 1 || entries[0].ID != second {
+		t.Fatalf("(expected) the entry %d != %v (actual)", second, entries)
+	}
+	if id := cron.Schedule(Every(time.Hour), FuncJob(func() {})); id != 3 {
+		t.Errorf("(expected) ID 3 != %d (actual)", id)
+	}
+
+	// The removed entry may have run right before its removal.
+	time.Sleep(10 * time.Millisecond)
+	for len(removed) > 0 {
+		<-removed
+	}
+	for len(kept) > 0 {
+		<-kept
+	}
+	select {
+	case <-kept:
+	case <-time.After(ONE_SECOND):
+		t.Fatal("expected the kept entry to run")
+	}
+	select {
+	case <-removed:
+		t.Error("expected the removed entry not to run")
+	default:
+	}
+}
+
+// Names of entries are unique, and are freed by removing their entry.
+func TestAddNamedJob(t *testing.T) {
+	cron := New()
+	id, err := cron.AddNamedJob("backup", "@midnight", FuncJob(func() {}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cron.AddNamedJob("backup", "@hourly", FuncJob(func() {})); err == nil {
+		t.Error("expected an error for a duplicate name")
+	}
+	if _, err := cron.AddNamedJob("report", "* * *", FuncJob(func() {})); err == nil {
+		t.Error("expected an error for an invalid spec")
+	}
+	cron.Start()
+	defer cron.Stop()
+
+	if e := cron.EntryByName("backup"); e == nil || e.ID != id || e.Name != "backup" || e.Next.IsZero() {
+		t.Errorf("(expected) the entry %d != %v (actual)", id, e)
+	}
+	if e := cron.EntryByName("report"); e != nil {
+		t.Errorf("(expected) no entry != %v (actual)", e)
+	}
+
+	cron.Remove(id)
+	if e := cron.EntryByName("backup"); e != nil {
+		t.Errorf("(expected) no entry != %v (actual)", e)
+	}
+	if _, err := cron.AddNamedJob("backup", "@hourly", FuncJob(func() {})); err != nil {
+		t.Errorf("expected the name to be free, got %v", err)
+	}
+}
+
+// Tagged entries are looked up, paused, resumed and removed in bulk.
+func TestTags(t *testing.T) {
+	var acme, other = make(chan struct{}, 10), make(chan struct{}, 10)
+	drain := func() {
+		for len(acme) > 0 {
+			<-acme
+		}
+	}
+
+	cron := New()
+	a1, _ := cron.AddFunc("* * * * * ?", func() { acme <- struct{}{} })
+	a2, _ := cron.AddFunc("@hourly", func() {})
+	o, _ := cron.AddFunc("* * * * * ?", func() { other <- struct{}{} })
+	cron.Tag(a1, "tenant:acme", "billing")
+	cron.Start()
+	defer cron.Stop()
+	cron.Tag(a2, "tenant:acme", "tenant:acme")
+	cron.Tag(o, "tenant:other")
+
+	entries := cron.EntriesByTag("tenant:acme")
+	if len(entries) != 2 || entries[0].ID+entries[1].ID != a1+a2 {
+		t.Fatalf("(expected) the entries %d, %d != %v (actual)", a1, a2, entries)
+	}
+	if e := cron.EntriesByTag("tenant:acme"); len(e[0].Tags)+len(e[1].Tags) != 3 {
+		t.Errorf("(expected) 3 tags != %v, %v (actual)", e[0].Tags, e[1].Tags)
+	}
+
+	if n := cron.PauseByTag("tenant:acme"); n != 2 {
+		t.Errorf("(expected) 2 paused != %d (actual)", n)
+	}
+	time.Sleep(10 * time.Millisecond)
+	drain()
+	<-other
+	<-other
+	select {
+	case <-acme:
+		t.Error("expected the paused entry not to run")
+	default:
+	}
+
+	if n := cron.ResumeByTag("billing"); n != 1 {
+		t.Errorf("(expected) 1 resumed != %d (actual)", n)
+	}
+	select {
+	case <-acme:
+	case <-time.After(ONE_SECOND):
+		t.Error("expected the resumed entry to run")
+	}
+
+	if n := cron.RemoveByTag("tenant:acme"); n != 2 {
+		t.Errorf("(expected) 2 removed != %d (actual)", n)
+	}
+	if entries := cron.Entries(); len(entries) != 1 || entries[0].ID != o {
+		t.Errorf("(expected) the entry %d != %v (actual)", o, entries)
+	}
+}
+
+// A paused entry keeps its schedule, but does not run until it is resumed.
+func TestPause(t *testing.T) {
+	ran := make(chan struct{}, 10)
+
+	cron := New()
+	id, _ := cron.AddFunc("* * * * * ?", func() { ran <- struct{}{} })
+	cron.Pause(id)
+	cron.Start()
+	defer cron.Stop()
+
+	time.Sleep(ONE_SECOND)
+	entries := cron.Entries()
+	if !entries[0].Paused || entries[0].Next.IsZero() {
+		t.Errorf("expected a paused entry with a schedule, got %+v", entries[0])
+	}
+	select {
+	case <-ran:
+		t.Error("expected the paused entry not to run")
+	default:
+	}
+
+	cron.Resume(id)
+	select {
+	case <-ran:
+	case <-time.After(ONE_SECOND):
+		t.Error("expected the resumed entry to run")
+	}
+}
+
+// Activations suppressed by PauseAll are skipped or coalesced when resuming.
+func TestPauseAll(t *testing.T) {
+	tests := []struct {
+		policy   ResumePolicy
+		expected int
+	}{
+		{SkipMissed, 0},
+		{CoalesceMissed, 1},
+	}
+	for _, c := range tests {
+		var mu sync.Mutex
+		runs := 0
+
+		cron := New()
+		cron.AddFunc("* * * * * ?", func() { mu.Lock(); runs++; mu.Unlock() })
+		id, _ := cron.AddFunc("* * * * * ?", func() { t.Error("expected the paused entry not to run") })
+		cron.Pause(id)
+		cron.PauseAll()
+		cron.Start()
+
+		time.Sleep(2 * time.Second)
+		cron.ResumeAll(c.policy)
+		time.Sleep(10 * time.Millisecond)
+		<-cron.Stop().Done()
+
+		mu.Lock()
+		if runs != c.expected {
+			t.Errorf("%v: (expected) %d runs != %d (actual)", c.policy, c.expected, runs)
+		}
+		mu.Unlock()
+		if entries := cron.Entries(); len(entries) != 2 || entries[0].Next.IsZero() {
+			t.Errorf("%v: expected the entries to be kept, got %v", c.policy, entries)
+		}
+	}
+}
+
+// Updating an entry keeps its ID and tags, and activates it by the new
+// schedule.
+func TestUpdateSchedule(t *testing.T) {
+	ran := make(chan string, 10)
+
+	cron := New()
+	id, _ := cron.AddFunc("@yearly", func() { ran <- "old" })
+	cron.Tag(id, "reports")
+	cron.Start()
+	defer cron.Stop()
+
+	if err := cron.UpdateSchedule(id, "* * * * * ?"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cron.UpdateJob(id, FuncJob(func() { ran <- "new" })); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case job := <-ran:
+		if job != "new" {
+			t.Errorf("(expected) the new job != the %s job (actual)", job)
+		}
+	case <-time.After(ONE_SECOND):
+		t.Fatal("expected the entry to run by its new schedule")
+	}
+
+	entries := cron.EntriesByTag("reports")
+	if len(entries) != 1 || entries[0].ID != id || entries[0].Prev.IsZero() {
+		t.Errorf("(expected) the entry %d != %v (actual)", id, entries)
+	}
+
+	if err := cron.UpdateSchedule(id, "* * *"); err == nil {
+		t.Error("expected an error for an invalid spec")
+	}
+	if err := cron.UpdateSchedule(id+1, "@daily"); err == nil {
+		t.Error("expected an error for an unknown ID")
+	}
+	if err := cron.UpdateJob(id+1, FuncJob(func() {})); err == nil {
+		t.Error("expected an error for an unknown ID")
+	}
+}
+
+// RunNow runs the job of an entry right away, keeping its next activation.
+func TestRunNow(t *testing.T) {
+	ran := make(chan struct{}, 1)
+
+	cron := New()
+	id, _ := cron.AddFunc("@yearly", func() { ran <- struct{}{} })
+	if err := cron.RunNow(id); err == nil {
+		t.Error("expected an error if the Cron is not running")
+	}
+	cron.Start()
+	defer cron.Stop()
+
+	next := cron.Entries()[0].Next
+	if err := cron.RunNow(id); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-ran:
+	case <-time.After(ONE_SECOND):
+		t.Fatal("expected the job to run")
+	}
+	if e := cron.Entries()[0]; !e.Next.Equal(next) || e.Prev.IsZero() {
+		t.Errorf("(expected) next %v and a previous run != %v, %v (actual)", next, e.Next, e.Prev)
+	}
+	if err := cron.RunNow(id + 1); err == nil {
+		t.Error("expected an error for an unknown ID")
+	}
+}
+
+// Entries are snapshots, including the spec of each entry.
+func TestEntriesSnapshot(t *testing.T) {
+	cron := New()
+	id, _ := cron.AddNamedJob("report", "@every 1h", FuncJob(func() {}))
+	cron.Schedule(Every(time.Minute), FuncJob(func() {}))
+	cron.Tag(id, "reports")
+	cron.Start()
+	defer cron.Stop()
+
+	entries := cron.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("(expected) 2 entries != %d (actual)", len(entries))
+	}
+	var e *Entry
+	for _, entry := range entries {
+		if entry.ID == id {
+			e = entry
+		} else if entry.Spec != "" {
+			t.Errorf("(expected) no spec != %q (actual)", entry.Spec)
+		}
+	}
+	if e == nil || e.Name != "report" || e.Spec != "@every 1h" || e.Next.IsZero() {
+		t.Fatalf("(expected) the report entry != %+v (actual)", e)
+	}
+
+	// Changing the snapshot has no effect on the Cron.
+	e.Tags[0], e.Spec = "changed", "changed"
+	if e := cron.EntryByName("report"); e.Tags[0] != "reports" || e.Spec != "@every 1h" {
+		t.Errorf("expected the entry to be unchanged, got %+v", e)
+	}
+
+	cron.UpdateSchedule(id, "@every 2h")
+	if e := cron.EntryByName("report"); e.Spec != "@every 2h" {
+		t.Errorf("(expected) the new spec != %q (actual)", e.Spec)
+	}
+}
+
+// Entries record the statistics of their runs, failing by ErrorJobs.
+func TestEntryStats(t *testing.T) {
+	done := make(chan struct{}, 10)
+
+	cron := New()
+	id, _ := cron.AddJob("* * * * * ?", ErrorFuncJob(func(ctx context.Context) error {
+		defer func() { done <- struct{}{} }()
+		return errors.New("failed")
+	}))
+	cron.Start()
+	defer cron.Stop()
+
+	select {
+	case <-done:
+	case <-time.After(ONE_SECOND):
+		t.Fatal("expected the job to run")
+	}
+	time.Sleep(10 * time.Millisecond)
+	for _, e := range cron.Entries() {
+		if e.ID != id {
+			continue
+		}
+		s := e.Stats
+		if s.Runs != 1 || s.Failures != 1 || s.LastError == nil || s.LastStart.IsZero() || s.LastFinish.Before(s.LastStart) {
+			t.Errorf("unexpected stats %+v", s)
+		}
+	}
+}
+
+// Entries added with a location are scheduled in that location.
+func TestAddJobIn(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cron := New()
+	cron.AddJobIn(tokyo, "0 0 9 * * *", FuncJob(func() {}))
+	cron.AddJobIn(time.UTC, "CRON_TZ=Asia/Tokyo 0 0 9 * * *", FuncJob(func() {}))
+	cron.Start()
+	defer cron.Stop()
+
+	for _, e := range cron.Entries() {
+		next := e.Next.In(tokyo)
+		if next.Hour() != 9 || next.Minute() != 0 || e.Next.Location() != time.Local {
+			t.Errorf("%s: (expected) 09:00 in Tokyo != %v (actual)", e.Spec, e.Next)
+		}
+		if e.Location == nil {
+			t.Errorf("%s: expected a location", e.Spec)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package cron
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// CrontabEntry is a job line of a crontab file.
+type CrontabEntry struct {
+	Line     int    // Line number in the file, starting at 1
+	Spec     string // Schedule as written, e.g. "*/5 * * * *" or "@daily"
+	Schedule Schedule
+
+	// Command is the command of the line up to the first unescaped "%", with
+	// "\%" replaced by "%".  Input is the rest of the line, with each further
+	// "%" replaced by a newline, which cron passes to the standard input of
+	// the command.
+	Command string
+	Input   string
+
+	// Env holds the environment variables assigned in the file before the
+	// entry.
+	Env map[string]string
+}
+
+// CrontabError describes why a line of a crontab file could not be parsed.
+type CrontabError struct {
+	Line int   // Line number in the file, starting at 1
+	Err  error // Error of the line, a *ParseError if the schedule is invalid
+}
+
+// Error returns the description of the error, including the line number.
+func (e *CrontabError) Error() string {
+	return fmt.Sprintf("Line %d: %s", e.Line, e.Err)
+}
+
+// ParseCrontab parses a crontab(5) file.  Blank lines and lines starting with
+// "#" are ignored.  Lines of the form "name = value" assign environment
+// variables, where the value may be quoted to keep leading or trailing blanks.
+// Job lines consist of the five fields of ParseStandard, or a descriptor like
+// "@daily" or "@every 5m", followed by the command.  A CRON_TZ variable
+// evaluates the schedules of the following entries in its time zone.
+// It returns a *CrontabError for the first line which can not be parsed.
+func ParseCrontab(r io.Reader) ([]CrontabEntry, error) {
+	return parseCrontab(r, NewParser(Minute|Hour|Dom|Month|Dow|Descriptor), 5)
+}
+
+// ParseCrontabWithParser parses a crontab file like ParseCrontab, whose job
+// lines consist of the fields the parser has been configured with, e.g. six
+// fields including the seconds.  Optional fields are not part of the lines.
+func ParseCrontabWithParser(r io.Reader, p Parser) ([]CrontabEntry, error) {
+	count := 0
+	for _, place := range places {
+		if p.options&place > 0 {
+			count++
+		}
+	}
+	return parseCrontab(r, p, count)
+}
+
+// parseCrontab parses the lines of a crontab file, whose job lines start with
+// the given number of fields.
+func parseCrontab(r io.Reader, p Parser, count int) ([]CrontabEntry, error) {
+	var (
+		entries []CrontabEntry
+		env     = make(map[string]string)
+		scanner = bufio.NewScanner(r)
+	)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if name, value, ok := parseAssignment(text); ok {
+			env[name] = value
+			continue
+		}
+
+		entry, err := parseCrontabEntry(text, p, count, env["CRON_TZ"])
+		if err != nil {
+			return nil, &CrontabError{Line: line, Err: err}
+		}
+		entry.Line = line
+		entry.Env = make(map[string]string, len(env))
+		for name, value := range env {
+			entry.Env[name] = value
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// parseAssignment returns the name and value of an environment variable
+// assignment "name = value", or false if the line is not one.
+func parseAssignment(text string) (name, value string, ok bool) {
+	end := strings.IndexFunc(text, func(r rune) bool { return r == '=' || unicode.IsSpace(r) })
+	if end <= 0 {
+		return "", "", false
+	}
+	name, rest := text[:end], strings.TrimSpace(text[end:])
+	if !strings.HasPrefix(rest, "=") {
+		return "", "", false
+	}
+	value = strings.TrimSpace(rest[1:])
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		value = value[1 : len(value)-1]
+	}
+	return name, value, true
+}
+
+// parseCrontabEntry parses a job line, whose schedule consists of the given
+// number of fields or a descriptor.
+func parseCrontabEntry(text string, p Parser, count int, location string) (CrontabEntry, error) {
+	fields := strings.Fields(text)
+	if strings.HasPrefix(fields[0], "@") {
+		count = 1
+		if fields[0] == "@every" || fields[0] == "@at" {
+			count = 2
+		}
+		if fields[0] == "@every" && len(fields) > 2 && fields[2] == "at" {
+			count = 4
+		}
+		if fields[0] == "@weekday" || fields[0] == "@weekend" {
+			// The time of the day is optional.
+			if len(fields) > 2 {
+				if _, err := time.Parse("15:04", fields[1]); err == nil {
+					count = 2
+				}
+			}
+		}
+	}
+	if len(fields) <= count {
+		return CrontabEntry{}, fmt.Errorf("Expected %d fields followed by a command, found %d: %s", count, len(fields), text)
+	}
+
+	// The command keeps its own whitespace.
+	rest := text
+	for i := 0; i < count; i++ {
+		rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+		rest = rest[len(fields[i]):]
+	}
+	spec := strings.Join(fields[:count], " ")
+	command, input := splitCommand(strings.TrimSpace(rest))
+
+	toParse := spec
+	if location != "" {
+		toParse = "CRON_TZ=" + location + " " + spec
+	}
+	schedule, err := p.Parse(toParse)
+	if err != nil {
+		return CrontabEntry{}, err
+	}
+	return CrontabEntry{Spec: spec, Schedule: schedule, Command: command, Input: input}, nil
+}
+
+// splitCommand splits the command of a job line at the first unescaped "%"
+// into the command and its input, whose further "%" are newlines.
+func splitCommand(text string) (command, input string) {
+	var b bytes.Buffer
+	for i := 0; i < len(text); i++ {
+		switch {
+		case text[i] == '\\' && i+1 < len(text) && text[i+1] == '%':
+			b.WriteByte('%')
+			i++
+		case text[i] == '%':
+			return b.String(), strings.Replace(text[i+1:], "%", "\n", -1)
+		default:
+			b.WriteByte(text[i])
+		}
+	}
+	return b.String(), ""
+}
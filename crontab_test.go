@@ -0,0 +1,105 @@
+package cron
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseCrontab(t *testing.T) {
+	file := `# Backups
+SHELL=/bin/bash
+MAILTO = "ops@example.com"
+
+*/5 * * * *  /usr/local/bin/poll --verbose
+  30 2 * * MON-FRI	backup.sh full
+@daily       rotate-logs
+@every 90m   sync
+0 9 * * * mail -s "Reminder" ops%Check the backups%today
+0 0 1 * * date +\%Y-\%m
+GREETING=' hello '
+CRON_TZ=Europe/Zurich
+0 6 * * * wake-up
+@every 24h at 03:00 report --daily
+@weekday 07:00 standup
+@weekend backup.sh
+`
+	entries, err := ParseCrontab(strings.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []struct {
+		line                 int
+		spec, command, input string
+		schedule             string
+	}{
+		{5, "*/5 * * * *", "/usr/local/bin/poll --verbose", "", "0 */5 * * * *"},
+		{6, "30 2 * * MON-FRI", "backup.sh full", "", "0 30 2 * * 1-5"},
+		{7, "@daily", "rotate-logs", "", "0 0 0 * * *"},
+		{8, "@every 90m", "sync", "", "@every 1h30m0s"},
+		{9, "0 9 * * *", `mail -s "Reminder" ops`, "Check the backups\ntoday", "0 0 9 * * *"},
+		{10, "0 0 1 * *", "date +%Y-%m", "", "0 0 0 1 * *"},
+		{13, "0 6 * * *", "wake-up", "", "CRON_TZ=Europe/Zurich 0 0 6 * * *"},
+		{14, "@every 24h at 03:00", "report --daily", "", "CRON_TZ=Europe/Zurich @every 24h0m0s at 03:00"},
+		{15, "@weekday 07:00", "standup", "", "CRON_TZ=Europe/Zurich 0 0 7 * * 1-5"},
+		{16, "@weekend", "backup.sh", "", "CRON_TZ=Europe/Zurich 0 0 0 * * 0,6"},
+	}
+	if len(entries) != len(expected) {
+		t.Fatalf("(expected) %d entries != %d (actual): %+v", len(expected), len(entries), entries)
+	}
+	for i, e := range expected {
+		actual := entries[i]
+		if actual.Line != e.line || actual.Spec != e.spec || actual.Command != e.command || actual.Input != e.input {
+			t.Errorf("%d: (expected) %d %q %q %q != %d %q %q %q (actual)", i,
+				e.line, e.spec, e.command, e.input, actual.Line, actual.Spec, actual.Command, actual.Input)
+		}
+		if schedule, _ := normalize(actual.Schedule); schedule != e.schedule {
+			t.Errorf("%d: (expected) schedule %q != %q (actual)", i, e.schedule, schedule)
+		}
+	}
+
+	// The entries see the variables assigned before them.
+	if env := map[string]string{"SHELL": "/bin/bash", "MAILTO": "ops@example.com"}; !reflect.DeepEqual(entries[0].Env, env) {
+		t.Errorf("(expected) %v != %v (actual)", env, entries[0].Env)
+	}
+	if env := entries[6].Env; env["GREETING"] != " hello " || env["CRON_TZ"] != "Europe/Zurich" {
+		t.Errorf("(expected) GREETING and CRON_TZ != %v (actual)", env)
+	}
+}
+
+func TestParseCrontabWithParser(t *testing.T) {
+	p := NewParser(Second | Minute | Hour | Dom | Month | Dow | Descriptor)
+	entries, err := ParseCrontabWithParser(strings.NewReader("*/10 * * * * * tick\n@hourly chime\n"), p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].Spec != "*/10 * * * * *" || entries[0].Command != "tick" || entries[1].Command != "chime" {
+		t.Errorf("unexpected entries: %+v", entries)
+	}
+}
+
+func TestParseCrontabErrors(t *testing.T) {
+	tests := []struct {
+		file string
+		line int
+	}{
+		{"* * * * *\n", 1},
+		{"# comment\n0 25 * * * cmd\n", 2},
+		{"A=1\n\n@every\n", 3},
+		{"@sometimes cmd\n", 1},
+		{"CRON_TZ=Nowhere/Nothing\n0 0 * * * cmd\n", 2},
+	}
+	for _, c := range tests {
+		_, err := ParseCrontab(strings.NewReader(c.file))
+		e, ok := err.(*CrontabError)
+		if !ok || e.Line != c.line {
+			t.Errorf("%q: (expected) error in line %d != %v (actual)", c.file, c.line, err)
+		}
+	}
+
+	// Errors of the schedule are kept.
+	_, err := ParseCrontab(strings.NewReader("0 25 * * * cmd\n"))
+	if e, ok := err.(*CrontabError); !ok || e.Err.(*ParseError).Name != "hour" {
+		t.Errorf("(expected) a *ParseError of the hour != %v (actual)", err)
+	}
+}
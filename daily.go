@@ -0,0 +1,108 @@
+package cron
+
+import (
+	"fmt"
+	"time"
+)
+
+// DailySchedule activates every Days days at a wall clock time, e.g.
+// "@every 24h at 03:00".  Unlike a ConstantDelaySchedule of 24 hours, the
+// activations stay at the same time of the day when the clocks change for
+// daylight saving time.
+type DailySchedule struct {
+	// Days between the activations.  The cadence of several days starts on
+	// January 1st 2000.
+	Days int
+
+	// Clock is the time of the day of the activations, as the time elapsed
+	// since midnight.  A time skipped by the clocks jumping forward activates
+	// as late as the clocks have jumped, and a repeated time activates at its
+	// first occurrence.
+	Clock time.Duration
+
+	// Location is the time zone of the wall clock.  A nil Location uses the
+	// location of the time passed to Next.
+	Location *time.Location
+}
+
+// EveryDaysAt returns a Schedule that activates every given number of days at
+// the given time of the day, as the time elapsed since midnight.  Numbers of
+// days which are not positive are rounded up to one.
+func EveryDaysAt(days int, clock time.Duration) DailySchedule {
+	if days < 1 {
+		days = 1
+	}
+	return DailySchedule{Days: days, Clock: clock}
+}
+
+// Next returns the first activation after the given time.
+func (s DailySchedule) Next(t time.Time) time.Time {
+	local := s.in(t)
+	day := civilDay(local)
+	day += mod(-day, s.Days)
+	for {
+		if next := s.on(day, local.Location()); next.After(t) {
+			return next.In(t.Location())
+		}
+		day += s.Days
+	}
+}
+
+// Prev returns the last activation before the given time.
+func (s DailySchedule) Prev(t time.Time) time.Time {
+	local := s.in(t)
+	day := civilDay(local)
+	day -= mod(day, s.Days)
+	for {
+		if prev := s.on(day, local.Location()); prev.Before(t) {
+			return prev.In(t.Location())
+		}
+		day -= s.Days
+	}
+}
+
+// MarshalText returns the schedule as an "@every" descriptor, e.g.
+// "@every 24h0m0s at 03:00", preceded by the time zone if the schedule has a
+// Location.  Times of the day with seconds can not be written as a descriptor.
+func (s DailySchedule) MarshalText() ([]byte, error) {
+	if s.Clock%time.Minute != 0 {
+		return nil, fmt.Errorf("Time of the day %v can not be written as a descriptor", s.Clock)
+	}
+	text := "@every " + (time.Duration(s.Days) * 24 * time.Hour).String() + " at " + clock(s.Clock)
+	if s.Location != nil {
+		text = "CRON_TZ=" + s.Location.String() + " " + text
+	}
+	return []byte(text), nil
+}
+
+// in returns the given time in the location of the schedule.
+func (s DailySchedule) in(t time.Time) time.Time {
+	if s.Location != nil {
+		return t.In(s.Location)
+	}
+	return t
+}
+
+// on returns the activation on the day counted from January 1st 2000.
+func (s DailySchedule) on(day int, loc *time.Location) time.Time {
+	t := time.Date(2000, time.January, 1+day, 0, 0, 0, int(s.Clock), loc)
+
+	// time.Date may resolve a skipped time to the wall clock before the gap.
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	if clock := wall.Sub(time.Date(2000, time.January, 1+day, 0, 0, 0, 0, time.UTC)); clock < s.Clock {
+		t = t.Add(s.Clock - clock)
+	}
+	return t
+}
+
+// civilDay returns the number of days from January 1st 2000 to the date of the
+// given time.
+func civilDay(t time.Time) int {
+	date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return int(date.Sub(time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)) / (24 * time.Hour))
+}
+
+// mod returns the non-negative remainder of a divided by b.
+func mod(a, b int) int {
+	return (a%b + b) % b
+}
@@ -0,0 +1,78 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDailySchedule(t *testing.T) {
+	tests := []struct {
+		spec           string
+		time, expected string
+	}{
+		{"@every 24h at 03:00", "Mon Jul 9 02:00 2012", "Mon Jul 9 03:00 2012"},
+		{"@every 24h at 03:00", "Mon Jul 9 03:00 2012", "Tue Jul 10 03:00 2012"},
+		{"@every 24h at 23:30", "Mon Jul 9 23:45 2012", "Tue Jul 10 23:30 2012"},
+
+		// The wall clock time is kept across daylight saving time, which begins
+		// on March 11th and ends on November 4th 2012 in New York.
+		{"@every 24h at 03:00", "2012-03-10T03:00:00-0500", "2012-03-11T03:00:00-0400"},
+		{"@every 24h at 03:00", "2012-11-03T03:00:00-0400", "2012-11-04T03:00:00-0500"},
+
+		// A skipped time activates as late as the clocks jumped, a repeated
+		// time at its first occurrence.
+		{"@every 24h at 02:30", "2012-03-10T02:30:00-0500", "2012-03-11T03:30:00-0400"},
+		{"@every 24h at 01:30", "2012-11-03T01:30:00-0400", "2012-11-04T01:30:00-0400"},
+		{"@every 24h at 01:30", "2012-11-04T01:30:00-0400", "2012-11-05T01:30:00-0500"},
+
+		// The cadence of several days starts on January 1st 2000, e.g. on the
+		// even days since then.
+		{"@every 2d at 03:00", "Sat Jan 1 00:00 2000", "Sat Jan 1 03:00 2000"},
+		{"@every 48h at 03:00", "Sat Jan 1 03:00 2000", "Mon Jan 3 03:00 2000"},
+		{"@every 48h at 03:00", "Sun Jan 2 12:00 2000", "Mon Jan 3 03:00 2000"},
+		{"@every 72h at 00:00", "Fri Dec 31 12:00 1999", "Sat Jan 1 00:00 2000"},
+		{"@every 72h at 00:00", "Tue Dec 28 12:00 1999", "Wed Dec 29 00:00 1999"},
+
+		// The schedule is evaluated in its location.
+		{"CRON_TZ=Europe/Zurich @every 24h at 06:00", "2012-07-09T00:00:00-0400", "2012-07-10T00:00:00-0400"},
+	}
+	for _, c := range tests {
+		sched, err := Parse(c.spec)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		actual := sched.Next(getTime(c.time))
+		if expected := getTime(c.expected); !actual.Equal(expected) {
+			t.Errorf("%s, \"%s\": (expected) %v != %v (actual)", c.time, c.spec, expected, actual)
+		}
+		if prev := sched.(DailySchedule).Prev(actual); !prev.Before(getTime(c.time).Add(time.Second)) {
+			t.Errorf("%s, \"%s\": (expected) previous before %s != %v (actual)", c.time, c.spec, c.time, prev)
+		}
+	}
+}
+
+func TestDailySchedulePrev(t *testing.T) {
+	sched := EveryDaysAt(2, 3*time.Hour)
+	tests := []struct {
+		time, expected string
+	}{
+		{"Mon Jan 3 03:00 2000", "Sat Jan 1 03:00 2000"},
+		{"Mon Jan 3 03:01 2000", "Mon Jan 3 03:00 2000"},
+		{"Sat Jan 1 02:00 2000", "Thu Dec 30 03:00 1999"},
+	}
+	for _, c := range tests {
+		actual := sched.Prev(getTime(c.time))
+		if expected := getTime(c.expected); !actual.Equal(expected) {
+			t.Errorf("%s: (expected) %v != %v (actual)", c.time, expected, actual)
+		}
+	}
+}
+
+func TestDailyScheduleErrors(t *testing.T) {
+	for _, spec := range []string{"@every 36h at 03:00", "@every -24h at 03:00", "@every 24h at 25:00", "@every 24h at"} {
+		if _, err := Parse(spec); err == nil {
+			t.Errorf("%s: expected an error", spec)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package cron
+
+import (
+	"expvar"
+	"time"
+)
+
+// DebugStats is a snapshot of the internals of a Cron, for debugging.
+type DebugStats struct {
+	Entries  int       `json:"entries"`  // Number of entries
+	NextWake time.Time `json:"nextWake"` // Next wake-up, zero if there is none or the Cron is not running
+	InFlight int       `json:"inFlight"` // Runs which have started but not completed
+
+	// DispatchLatency is the time the last run started after its activation,
+	// including the time it waited for the pool or the limits.
+	DispatchLatency time.Duration `json:"dispatchLatency"`
+}
+
+// DebugStats returns a snapshot of the internals of the Cron, e.g. to be
+// logged, without waiting for the scheduler.
+func (c *Cron) DebugStats() DebugStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.debug
+}
+
+// Publish publishes the DebugStats of the Cron as the expvar variable of the
+// given name, e.g. to be served on /debug/vars.  Like expvar.Publish, it panics
+// if the name is already registered.
+func (c *Cron) Publish(name string) {
+	expvar.Publish(name, expvar.Func(func() interface{} {
+		return c.DebugStats()
+	}))
+}
+
+// setNextWake records the next wake-up of the scheduler.
+func (c *Cron) setNextWake(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.debug.NextWake = t
+}
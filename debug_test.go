@@ -0,0 +1,51 @@
+package cron
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestDebugStats(t *testing.T) {
+	started, release := make(chan struct{}, 10), make(chan struct{})
+	cron := New()
+	cron.AddFunc("* * * * * ?", func() {
+		started <- struct{}{}
+		<-release
+	})
+	cron.AddFunc("@yearly", func() {})
+	if s := cron.DebugStats(); s.Entries != 2 || !s.NextWake.IsZero() || s.InFlight != 0 {
+		t.Errorf("before start: unexpected %+v", s)
+	}
+
+	cron.Start()
+	time.Sleep(10 * time.Millisecond)
+	if s := cron.DebugStats(); s.NextWake.IsZero() || s.NextWake.After(time.Now().Add(time.Second)) {
+		t.Errorf("(expected) next wake-up within a second != %v (actual)", s.NextWake)
+	}
+	select {
+	case <-started:
+	case <-time.After(ONE_SECOND):
+		t.Fatal("expected the job to run")
+	}
+	if s := cron.DebugStats(); s.InFlight != 1 || s.DispatchLatency < 0 || s.DispatchLatency > 100*time.Millisecond {
+		t.Errorf("while running: unexpected %+v", s)
+	}
+
+	// The names of expvar variables can not be reused, e.g. with -count.
+	name := fmt.Sprintf("TestDebugStats%d", time.Now().UnixNano())
+	cron.Publish(name)
+	var published DebugStats
+	if err := json.Unmarshal([]byte(expvar.Get(name).String()), &published); err != nil || published.Entries != 2 {
+		t.Errorf("unexpected published %+v: %v", published, err)
+	}
+
+	close(release)
+	<-cron.Stop().Done()
+	time.Sleep(10 * time.Millisecond)
+	if s := cron.DebugStats(); !s.NextWake.IsZero() || s.InFlight != 0 {
+		t.Errorf("after stop: unexpected %+v", s)
+	}
+}
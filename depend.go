@@ -0,0 +1,128 @@
+package cron
+
+import (
+	"fmt"
+	"time"
+)
+
+// TriggerAfter triggers the entry with the given ID upon each successful run
+// of the upstream entry, in addition to its own schedule, e.g. to run the
+// stages of a pipeline one after the other.  The triggered runs are activated
+// at the completion of the upstream run, and are skipped while the entry or
+// the Cron is paused.  Runs of Simulate do not trigger the entries.  It returns
+// an error if there is no such entry, or if the trigger would form a cycle.
+func (c *Cron) TriggerAfter(id, upstream EntryID) error {
+	var err error
+	c.updateEntries(func(bool) {
+		for _, check := range []EntryID{id, upstream} {
+			if !c.hasEntry(check) {
+				err = fmt.Errorf("No entry with ID %d", check)
+				return
+			}
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.triggers(id, upstream) {
+			err = fmt.Errorf("Triggering entry %d after entry %d would form a cycle", id, upstream)
+			return
+		}
+		for _, dependent := range c.dependents[upstream] {
+			if dependent == id {
+				return
+			}
+		}
+		c.dependents[upstream] = append(c.dependents[upstream], id)
+	})
+	return err
+}
+
+// AddJobAfter adds a Job to the Cron to be run upon each successful run of the
+// upstream entry, rather than on a schedule, and returns the ID of its entry.
+// It returns an error if there is no such upstream entry.
+func (c *Cron) AddJobAfter(upstream EntryID, cmd Job, opts ...EntryOption) (EntryID, error) {
+	id, err := c.addEntry(&Entry{Schedule: triggeredSchedule{}, Job: cmd}, opts)
+	if err != nil {
+		return 0, err
+	}
+	if err := c.TriggerAfter(id, upstream); err != nil {
+		c.Remove(id)
+		return 0, err
+	}
+	return id, nil
+}
+
+// triggeredSchedule never activates, for the entries of AddJobAfter.
+type triggeredSchedule struct{}
+
+func (triggeredSchedule) Next(t time.Time) time.Time {
+	return time.Time{}
+}
+
+// hasEntry returns true if there is an entry with the given ID.
+func (c *Cron) hasEntry(id EntryID) bool {
+	for _, e := range c.entries {
+		if e.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// triggers returns true if the entry with the given ID triggers the other one,
+// or is the same, with c.mu held.
+func (c *Cron) triggers(id, other EntryID) bool {
+	if id == other {
+		return true
+	}
+	for _, dependent := range c.dependents[id] {
+		if c.triggers(dependent, other) {
+			return true
+		}
+	}
+	return false
+}
+
+// removeDependencies removes the triggers of the removed entry with the given
+// ID, and the ones after it, with c.mu held.
+func (c *Cron) removeDependencies(id EntryID) {
+	delete(c.dependents, id)
+	for upstream, dependents := range c.dependents {
+		kept := dependents[:0]
+		for _, dependent := range dependents {
+			if dependent != id {
+				kept = append(kept, dependent)
+			}
+		}
+		if len(kept) == 0 {
+			delete(c.dependents, upstream)
+		} else {
+			c.dependents[upstream] = kept
+		}
+	}
+}
+
+// triggerDependents triggers the entries after the successful run of the entry
+// with the given ID, in the goroutine of the scheduler by its update channel,
+// unless it has stopped.
+func (c *Cron) triggerDependents(id EntryID, update chan func(), done chan struct{}) {
+	c.mu.Lock()
+	dependents := append([]EntryID(nil), c.dependents[id]...)
+	c.mu.Unlock()
+	if len(dependents) == 0 {
+		return
+	}
+
+	select {
+	case update <- func() {
+		now := c.now()
+		for _, e := range c.entries {
+			for _, dependent := range dependents {
+				if e.ID == dependent {
+					c.fire(e, []time.Time{now})
+				}
+			}
+		}
+	}:
+	case <-done:
+	}
+}
@@ -0,0 +1,91 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// Entries are triggered by the successful runs of their upstream entries.
+func TestTriggerAfter(t *testing.T) {
+	ran := make(chan string, 10)
+	job := func(name string, err error) Job {
+		return ErrorFuncJob(func(ctx context.Context) error {
+			ran <- name
+			return err
+		})
+	}
+
+	cron := New()
+	extract, _ := cron.AddNamedJob("extract", "* * * * * ?", job("extract", nil))
+	transform, _ := cron.AddJobAfter(extract, job("transform", nil))
+	cron.AddJobAfter(transform, job("load", nil))
+	report, _ := cron.AddNamedJob("report", "@yearly", job("report", nil))
+	cron.TriggerAfter(report, extract)
+	broken, _ := cron.AddNamedJob("broken", "* * * * * ?", job("broken", errors.New("broken")))
+	cron.AddJobAfter(broken, job("never", nil))
+	cron.Start()
+	defer cron.Stop()
+
+	runs := map[string]int{}
+	for timeout := time.After(ONE_SECOND + 50*time.Millisecond); runs["load"] == 0; {
+		select {
+		case name := <-ran:
+			runs[name]++
+		case <-timeout:
+			t.Fatalf("expected the pipeline to run, got %v", runs)
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+	for len(ran) > 0 {
+		runs[<-ran]++
+	}
+	for _, c := range []struct {
+		name string
+		ran  bool
+	}{
+		{"extract", true},
+		{"transform", true},
+		{"load", true},
+		{"report", true},
+		{"broken", true},
+		{"never", false},
+	} {
+		if (runs[c.name] > 0) != c.ran {
+			t.Errorf("%s: unexpected %d runs", c.name, runs[c.name])
+		}
+	}
+}
+
+func TestTriggerAfterErrors(t *testing.T) {
+	cron := New()
+	a, _ := cron.AddFunc("@daily", func() {})
+	b, _ := cron.AddJobAfter(a, FuncJob(func() {}))
+	c, _ := cron.AddJobAfter(b, FuncJob(func() {}))
+	tests := []struct {
+		id, upstream EntryID
+		valid        bool
+	}{
+		{c, 42, false},
+		{42, a, false},
+		{a, a, false},
+		{a, c, false},
+		{c, a, true},
+		{c, a, true},
+	}
+	for _, test := range tests {
+		if err := cron.TriggerAfter(test.id, test.upstream); (err == nil) != test.valid {
+			t.Errorf("%d after %d: unexpected error %v", test.id, test.upstream, err)
+		}
+	}
+	if _, err := cron.AddJobAfter(42, FuncJob(func() {})); err == nil || len(cron.Entries()) != 3 {
+		t.Errorf("(expected) an error and 3 entries != %v, %d (actual)", err, len(cron.Entries()))
+	}
+
+	// Removing entries removes their triggers.
+	cron.Remove(b)
+	if len(cron.dependents) != 1 || len(cron.dependents[a]) != 1 || cron.dependents[a][0] != c {
+		t.Errorf("(expected) only %d after %d != %v (actual)", c, a, cron.dependents)
+	}
+}
@@ -0,0 +1,293 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Describe returns a human readable description of the schedule in English,
+// e.g. "at 06:30 on Monday through Friday".  Schedules of unknown types are
+// described as "custom schedule".
+func Describe(schedule Schedule) string {
+	return English.describe(schedule)
+}
+
+// DescribeSpec returns a human readable description of the given spec, which
+// is parsed with Parse.
+// It returns a descriptive error if the spec is not valid.
+func DescribeSpec(spec string) (string, error) {
+	return DescribeSpecIn(spec, "en")
+}
+
+// DescribeIn returns a human readable description of the schedule in the
+// language of the given locale, e.g. "de" or "fr-CH".  A region is ignored if
+// there is no locale registered for it, and English is used if there is no
+// locale registered for the language either.
+func DescribeIn(schedule Schedule, locale string) string {
+	return lookupLocale(locale).describe(schedule)
+}
+
+// DescribeSpecIn returns a human readable description of the given spec in the
+// language of the given locale, like DescribeIn.
+// It returns a descriptive error if the spec is not valid.
+func DescribeSpecIn(spec, locale string) (string, error) {
+	schedule, err := Parse(spec)
+	if err != nil {
+		return "", err
+	}
+	return DescribeIn(schedule, locale), nil
+}
+
+func (l *Locale) describe(schedule Schedule) string {
+	switch s := schedule.(type) {
+	case *SpecSchedule:
+		return l.describeSpec(s)
+	case ConstantDelaySchedule:
+		if s.Jitter > 0 {
+			return l.msg("everyJitter", s.Delay, s.Jitter)
+		}
+		return l.msg("every", s.Delay)
+	case DailySchedule:
+		desc := l.msg("every", time.Duration(s.Days)*24*time.Hour) + " " + l.msg("at", clock(s.Clock))
+		if s.Location != nil {
+			desc += " (" + s.Location.String() + ")"
+		}
+		return desc
+	case OnceSchedule:
+		return l.msg("once", s.At.Format(time.RFC3339))
+	case RebootSchedule:
+		return l.msg("reboot")
+	case WindowSchedule:
+		return l.msg("window", l.describe(s.Schedule), clock(s.Start), clock(s.End))
+	}
+	return l.msg("custom")
+}
+
+// describeSpec returns the description of the time of day, followed by the
+// description of the days.
+func (l *Locale) describeSpec(s *SpecSchedule) string {
+	desc := l.describeTime(s)
+	if days := l.describeDays(s); days != "" {
+		desc += " " + days
+	}
+	if s.Location != nil {
+		desc += " (" + s.Location.String() + ")"
+	}
+	return desc
+}
+
+// describeTime returns the description of the second, minute and hour fields.
+func (l *Locale) describeTime(s *SpecSchedule) string {
+	secs, mins, hrs := bitValues(s.Second, seconds), bitValues(s.Minute, minutes), bitValues(s.Hour, hours)
+
+	// Describe a few times of the day by the times themselves.
+	if len(s.millis) <= 1 && len(secs) == 1 && len(mins) == 1 && !isAll(s.Hour, hours) && step(hrs, hours) == 0 && len(hrs) <= 4 {
+		var times []string
+		for _, h := range hrs {
+			t := fmt.Sprintf("%02d:%02d", h, mins[0])
+			if secs[0] != 0 || len(s.millis) > 0 {
+				t += fmt.Sprintf(":%02d", secs[0])
+			}
+			if len(s.millis) > 0 {
+				t += fmt.Sprintf(".%03d", s.millis[0])
+			}
+			times = append(times, t)
+		}
+		return l.msg("at", l.join(times))
+	}
+
+	var parts []string
+	if d := step(s.millis, milliseconds); d > 0 {
+		parts = append(parts, l.msg("everyMilliseconds", d))
+	} else if len(s.millis) > 0 {
+		parts = append(parts, l.msg(plural(s.millis, "millisecond", "milliseconds"), l.describeValues(s.millis, strconv.Itoa)))
+	}
+	switch {
+	case isAll(s.Second, seconds):
+		if len(parts) == 0 {
+			parts = append(parts, l.msg("everySecond"))
+		}
+	case step(secs, seconds) > 0:
+		parts = append(parts, l.msg("everySeconds", step(secs, seconds)))
+	case len(secs) == 1 && secs[0] == 0 && len(parts) == 0:
+	default:
+		parts = append(parts, l.msg(plural(secs, "second", "seconds"), l.describeValues(secs, strconv.Itoa)))
+	}
+	switch {
+	case isAll(s.Minute, minutes):
+		if len(parts) == 0 {
+			parts = append(parts, l.msg("everyMinute"))
+		}
+	case step(mins, minutes) > 0:
+		parts = append(parts, l.msg("everyMinutes", step(mins, minutes)))
+	case len(mins) == 1 && mins[0] == 0 && len(parts) == 0:
+		if isAll(s.Hour, hours) || step(hrs, hours) == 0 {
+			parts = append(parts, l.msg("everyHour"))
+		}
+	default:
+		parts = append(parts, l.msg(plural(mins, "minute", "minutes"), l.describeValues(mins, strconv.Itoa)))
+	}
+	switch {
+	case isAll(s.Hour, hours):
+	case step(hrs, hours) > 0:
+		parts = append(parts, l.msg("everyHours", step(hrs, hours)))
+	case len(runs(hrs)) == 1:
+		run := runs(hrs)[0]
+		parts = append(parts, l.msg("hourRange", fmt.Sprintf("%02d:00", run[0]), fmt.Sprintf("%02d:59", run[len(run)-1])))
+	default:
+		parts = append(parts, l.msg(plural(hrs, "hour", "hours"), l.describeValues(hrs, strconv.Itoa)))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// describeDays returns the description of the day of month, month, day of
+// week and year fields, or "" if the schedule runs on every day.
+func (l *Locale) describeDays(s *SpecSchedule) string {
+	// As in dayMatches, the day fields are ORed unless either has a star.  A
+	// field containing every day then matches on every day.
+	var (
+		domAll, dowAll     = isAll(s.Dom, dom), isAll(s.Dow, dow)
+		or                 = !s.dayAnd && s.Dom&starBit == 0 && s.Dow&starBit == 0
+		domParts, dowParts []string
+	)
+	if !domAll && !(or && dowAll) {
+		if days := bitValues(s.Dom, dom); len(days) > 0 {
+			domParts = append(domParts, l.msg(plural(days, "dom", "doms"), l.describeValues(days, strconv.Itoa)))
+		}
+		if s.lastDom {
+			domParts = append(domParts, l.msg("lastDom"))
+		}
+		if s.lastWeekdayDom {
+			domParts = append(domParts, l.msg("lastWeekdayDom"))
+		}
+		if days := bitValues(s.weekdayDom, dom); len(days) > 0 {
+			domParts = append(domParts, l.msg(plural(days, "weekdayDom", "weekdayDoms"), l.describeValues(days, strconv.Itoa)))
+		}
+	}
+	if !dowAll && !(or && domAll) {
+		if days := bitValues(s.Dow, dow); len(days) > 0 {
+			dowParts = append(dowParts, l.msg("dow", l.describeValues(days, l.weekday)))
+		}
+		if days := bitValues(s.lastDow, dow); len(days) > 0 {
+			dowParts = append(dowParts, l.msg("lastDow", l.describeValues(days, l.weekday)))
+		}
+		for nth := 0; nth < len(l.Ordinals); nth++ {
+			if days := bitValues(s.nthDow>>uint(8*nth), dow); len(days) > 0 {
+				dowParts = append(dowParts, l.msg("nthDow", l.Ordinals[nth], l.describeValues(days, l.weekday)))
+			}
+		}
+	}
+
+	var parts []string
+	switch {
+	case len(domParts) > 0 && len(dowParts) > 0 && or:
+		parts = append(parts, strings.Join(append(domParts, dowParts...), l.Or))
+	default:
+		parts = append(parts, domParts...)
+		parts = append(parts, dowParts...)
+	}
+	if !isAll(s.Month, months) {
+		parts = append(parts, l.msg("months", l.describeValues(bitValues(s.Month, months), l.month)))
+	}
+	if len(s.Year) > 0 {
+		parts = append(parts, l.msg("years", l.describeValues(s.Year, strconv.Itoa)))
+	}
+	return strings.Join(parts, " ")
+}
+
+// bitValues returns the values within the bounds which are set in the bits.
+func bitValues(bits uint64, r bounds) []int {
+	var values []int
+	for i := r.min; i <= r.max; i++ {
+		if bits&(1<<i) > 0 {
+			values = append(values, int(i))
+		}
+	}
+	return values
+}
+
+// isAll returns true if the bits contain every value of the bounds.  The star
+// bit does not count, since a stepped star like "*/15" contains only some.
+func isAll(bits uint64, r bounds) bool {
+	return bits&getBits(r.min, r.max, 1) == getBits(r.min, r.max, 1)
+}
+
+// step returns the step size if the values are spaced evenly over the whole
+// bounds starting at their minimum, e.g. 0, 15, 30, 45 for the minutes, and 0
+// otherwise.
+func step(values []int, r bounds) int {
+	if len(values) < 2 || values[0] != int(r.min) {
+		return 0
+	}
+	d := values[1] - values[0]
+	for i := 2; i < len(values); i++ {
+		if values[i]-values[i-1] != d {
+			return 0
+		}
+	}
+	if values[len(values)-1]+d <= int(r.max) {
+		return 0
+	}
+	return d
+}
+
+// runs splits the ascending values into runs of consecutive values.
+func runs(values []int) [][]int {
+	var result [][]int
+	for i, v := range values {
+		if i > 0 && v == values[i-1]+1 {
+			result[len(result)-1] = append(result[len(result)-1], v)
+			continue
+		}
+		result = append(result, []int{v})
+	}
+	return result
+}
+
+// describeValues returns the values as a list, where runs of three or more
+// consecutive values are described as ranges, e.g. "1, 3 and 5 through 9".
+func (l *Locale) describeValues(values []int, name func(int) string) string {
+	var items []string
+	for _, run := range runs(values) {
+		if len(run) >= 3 {
+			items = append(items, name(run[0])+l.Through+name(run[len(run)-1]))
+			continue
+		}
+		for _, v := range run {
+			items = append(items, name(v))
+		}
+	}
+	return l.join(items)
+}
+
+// join returns the items as a list, e.g. "a, b and c".
+func (l *Locale) join(items []string) string {
+	if len(items) <= 1 {
+		return strings.Join(items, "")
+	}
+	return strings.Join(items[:len(items)-1], ", ") + l.And + items[len(items)-1]
+}
+
+// plural returns the singular message key if there is exactly one value, and
+// the plural otherwise.
+func plural(values []int, singular, plural string) string {
+	if len(values) == 1 {
+		return singular
+	}
+	return plural
+}
+
+func (l *Locale) weekday(day int) string {
+	return l.Weekdays[day]
+}
+
+func (l *Locale) month(month int) string {
+	return l.Months[month-1]
+}
+
+// clock returns the time of day as HH:MM.
+func clock(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d/time.Hour), int(d%time.Hour/time.Minute))
+}
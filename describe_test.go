@@ -0,0 +1,80 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDescribeSpec(t *testing.T) {
+	tests := []struct {
+		spec, expected string
+	}{
+		{"0 30 6 * * MON-FRI", "at 06:30 on Monday through Friday"},
+		{"0 30 6,18 * * *", "at 06:30 and 18:30"},
+		{"15 30 6 * * *", "at 06:30:15"},
+		{"* * * * * *", "every second"},
+		{"0 * * * * *", "every minute"},
+		{"0 0 * * * *", "every hour"},
+		{"0/10 * * * * *", "every 10 seconds"},
+		{"*/10 * * * * *", "every 10 seconds"},
+		{"30 * * * * *", "at second 30 past the minute"},
+		{"*.0/500 * 9-17 * * MON-FRI", "every 500 milliseconds, between 09:00 and 17:59 on Monday through Friday"},
+		{"*.0/250 * * * * *", "every 250 milliseconds"},
+		{"0.100,900 * * * * *", "at milliseconds 100 and 900 past the second, at second 0 past the minute"},
+		{"0.250 30 6 * * *", "at 06:30:00.250"},
+		{"0 0/15 * * * *", "every 15 minutes"},
+		{"0 */15 * * * *", "every 15 minutes"},
+		{"0 5,35 * * * *", "at minutes 5 and 35 past the hour"},
+		{"0 0 0/2 * * *", "every 2 hours"},
+		{"0 0 */2 * * *", "every 2 hours"},
+		{"0 0 0 */2 * *", "at 00:00 on days 1, 3, 5, 7, 9, 11, 13, 15, 17, 19, 21, 23, 25, 27, 29 and 31 of the month"},
+		{"0 0 0 */10 * MON", "at 00:00 on days 1, 11, 21 and 31 of the month on Monday"},
+		{"0 0 0 1-31 * MON", "at 00:00"},
+		{"0 0 0 * */3 *", "at 00:00 in January, April, July and October"},
+		{"0 0 9-17 * * *", "every hour, between 09:00 and 17:59"},
+		{"0 0/5 9-17 * * MON-FRI", "every 5 minutes, between 09:00 and 17:59 on Monday through Friday"},
+		{"0 0 1,3,5,7,9 * * *", "every hour, in the hours 1, 3, 5, 7 and 9"},
+		{"0 0 0 1 * *", "at 00:00 on day 1 of the month"},
+		{"0 0 0 1,15 * *", "at 00:00 on days 1 and 15 of the month"},
+		{"0 0 0 L * *", "at 00:00 on the last day of the month"},
+		{"0 0 18 LW * *", "at 18:00 on the last weekday of the month"},
+		{"0 0 0 15W * *", "at 00:00 on the weekday nearest to day 15 of the month"},
+		{"0 0 0 * * 5L", "at 00:00 on the last Friday of the month"},
+		{"0 0 0 * * 1#2", "at 00:00 on the second Monday of the month"},
+		{"0 0 0 1 * MON", "at 00:00 on day 1 of the month or on Monday"},
+		{"0 0 0 1 1 *", "at 00:00 on day 1 of the month in January"},
+		{"0 0 0 * 6-8 SAT,SUN", "at 00:00 on Sunday and Saturday in June through August"},
+		{"0 0 12 1 1 * 2030", "at 12:00 on day 1 of the month in January in 2030"},
+		{"CRON_TZ=Europe/Zurich 0 0 6 * * *", "at 06:00 (Europe/Zurich)"},
+		{"@daily", "at 00:00"},
+		{"@every 1h30m", "every 1h30m0s"},
+		{"@every 5m~30s", "every 5m0s with a jitter of up to 30s"},
+		{"@every 48h at 03:00", "every 48h0m0s at 03:00"},
+		{"@weekday 07:15", "at 07:15 on Monday through Friday"},
+		{"@reboot", "once at startup"},
+		{"@at 2030-01-01T00:00:00Z", "once at 2030-01-01T00:00:00Z"},
+		{"0 0/5 * * * * @between 08:00-18:00", "every 5 minutes, only between 08:00 and 18:00"},
+	}
+
+	for _, c := range tests {
+		actual, err := DescribeSpec(c.spec)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if actual != c.expected {
+			t.Errorf("%s: (expected) %q != %q (actual)", c.spec, c.expected, actual)
+		}
+	}
+
+	if _, err := DescribeSpec("0 0 25 * * *"); err == nil {
+		t.Error("expected an error describing an invalid spec")
+	}
+}
+
+func TestDescribeCustom(t *testing.T) {
+	start := time.Date(2012, time.July, 9, 6, 0, 0, 0, time.UTC)
+	if actual := Describe(&RRuleSchedule{Start: start, Freq: Daily, Interval: 1}); actual != "custom schedule" {
+		t.Errorf("(expected) %q != %q (actual)", "custom schedule", actual)
+	}
+}
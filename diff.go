@@ -0,0 +1,55 @@
+package cron
+
+import (
+	"fmt"
+	"time"
+)
+
+// Divergence is the first activation at which two schedules differ.
+type Divergence struct {
+	After time.Time // The last activation in common, or the start of the comparison
+	A, B  time.Time // The next activation of either schedule, or zero if there is none within the horizon
+}
+
+// String describes the divergence, e.g. for a test failure.
+func (d Divergence) String() string {
+	format := func(t time.Time) string {
+		if t.IsZero() {
+			return "never"
+		}
+		return t.Format(time.RFC3339)
+	}
+	return fmt.Sprintf("after %s, a activates %s and b activates %s", format(d.After), format(d.A), format(d.B))
+}
+
+// Diff compares the activations of two schedules after the given time, up to
+// the horizon.  It returns the first divergence, or nil if they activate at the
+// same times, e.g. to verify that a refactored spec behaves the same.
+// Schedules with a random jitter diverge.
+func Diff(a, b Schedule, from time.Time, horizon time.Duration) *Divergence {
+	end := from.Add(horizon)
+	within := func(t time.Time) time.Time {
+		if t.After(end) {
+			return time.Time{}
+		}
+		return t
+	}
+	for t := from; ; {
+		nextA, nextB := within(a.Next(t)), within(b.Next(t))
+		if !nextA.Equal(nextB) {
+			return &Divergence{After: t, A: nextA, B: nextB}
+		}
+		// Neither activates anymore, or they do not advance.
+		if nextA.IsZero() || !nextA.After(t) {
+			return nil
+		}
+		t = nextA
+	}
+}
+
+// Equivalent returns true if the two schedules activate at the same times from
+// now on, up to the horizon.  Diff compares them from a given time instead,
+// e.g. the current time of a Clock.
+func Equivalent(a, b Schedule, horizon time.Duration) bool {
+	return Diff(a, b, time.Now(), horizon) == nil
+}
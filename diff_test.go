@@ -0,0 +1,72 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		a, b    string
+		from    string
+		horizon time.Duration
+		diff    *Divergence
+	}{
+		{"0 0/15 * * * *", "0 0,15,30,45 * * * *", "Mon Jul 9 14:45 2012", 24 * time.Hour, nil},
+		{"@daily", "0 0 0 * * *", "Mon Jul 9 14:45 2012", 31 * 24 * time.Hour, nil},
+		{"0 0 9 * * MON-FRI", "0 0 9 * * 1-5", "Mon Jul 9 14:45 2012", 31 * 24 * time.Hour, nil},
+		{"0 0 0 31 * *", "0 0 0 L * *", "Mon Jul 30 12:00 2012", 31 * 24 * time.Hour, nil},
+		{"0 0 0 31 * *", "0 0 0 L * *", "Mon Jul 30 12:00 2012", 62 * 24 * time.Hour, &Divergence{
+			After: getTime("Fri Aug 31 00:00 2012"),
+			A:     time.Time{}, // Oct 31 is beyond the horizon
+			B:     getTime("Sun Sep 30 00:00 2012"),
+		}},
+		{"0 0 0 30 * *", "0 0 0 L * *", "Mon Jul 9 14:45 2012", 31 * 24 * time.Hour, &Divergence{
+			After: getTime("Mon Jul 9 14:45 2012"),
+			A:     getTime("Mon Jul 30 00:00 2012"),
+			B:     getTime("Tue Jul 31 00:00 2012"),
+		}},
+		{"0 0 9 * * MON-FRI", "0 0 9 * * MON-SAT", "Mon Jul 9 14:45 2012", 7 * 24 * time.Hour, &Divergence{
+			After: getTime("Fri Jul 13 09:00 2012"),
+			A:     getTime("Mon Jul 16 09:00 2012"),
+			B:     getTime("Sat Jul 14 09:00 2012"),
+		}},
+		{"0 0 0 1 1 * 2030", "0 0 0 1 1 * 2031", "Mon Jul 9 14:45 2012", 24 * time.Hour, nil},
+	}
+	for _, c := range tests {
+		a, err := Parse(c.a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := Parse(c.b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual := Diff(a, b, getTime(c.from), c.horizon)
+		switch {
+		case c.diff == nil && actual != nil:
+			t.Errorf("%s, %s: (expected) no divergence != %v (actual)", c.a, c.b, actual)
+		case c.diff != nil && actual == nil:
+			t.Errorf("%s, %s: (expected) %v != no divergence (actual)", c.a, c.b, c.diff)
+		case c.diff != nil && (!actual.After.Equal(c.diff.After) || !actual.A.Equal(c.diff.A) || !actual.B.Equal(c.diff.B)):
+			t.Errorf("%s, %s: (expected) %v != %v (actual)", c.a, c.b, c.diff, actual)
+		}
+	}
+}
+
+func TestEquivalent(t *testing.T) {
+	a, _ := Parse("0 */30 * * * *")
+	b, _ := Parse("0 0,30 * * * *")
+	c, _ := Parse("0 0,31 * * * *")
+	if !Equivalent(a, b, 24*time.Hour) {
+		t.Error("expected the schedules to be equivalent")
+	}
+	if Equivalent(a, c, 24*time.Hour) {
+		t.Error("expected the schedules to differ")
+	}
+
+	d := Divergence{After: getTime("Mon Jul 9 14:45 2012"), A: getTime("Mon Jul 9 15:00 2012")}
+	if expected := "after 2012-07-09T14:45:00Z, a activates 2012-07-09T15:00:00Z and b activates never"; d.String() != expected {
+		t.Errorf("(expected) %q != %q (actual)", expected, d.String())
+	}
+}
@@ -4,7 +4,20 @@ Package cron implements a cron spec parser and job runner.
 Usage
 
 Callers may register Funcs to be invoked on a given schedule.  Cron will run
-them in their own goroutines.
+them in their own goroutines.  New takes options to configure the Cron, e.g.
+WithLocation to evaluate the schedules in a time zone other than the local one,
+or WithClock to take the time from a Clock other than the SystemClock, such as
+a fake clock in tests.  Panics of the jobs are recovered and logged by the
+Logger of WithLogger, or passed to the handler of WithPanicHandler.
+WithMaxConcurrency runs the jobs on a bounded pool of goroutines, rather than
+on a goroutine per run, and WithRateLimit bounds the rate at which runs start
+across all entries.  WithMetrics records the runs, failures, skips and
+lateness of the entries, e.g. for Prometheus, and WithTracer traces the runs,
+e.g. with OpenTelemetry spans carried by the contexts of the jobs.  The package
+does not depend on Prometheus or OpenTelemetry, and provides no adapters for
+them: Metrics and Tracer are implemented on top of their client libraries by
+the callers.  DebugStats returns a snapshot of the internals of the Cron, which
+Publish publishes as an expvar variable.
 
 	c := cron.New()
 	c.AddFunc("0 30 * * * *", func() { fmt.Println("Every hour on the half hour") })
@@ -15,29 +28,231 @@ them in their own goroutines.
 	// Funcs are invoked in their own goroutine, asynchronously.
 	...
 	// Funcs may also be added to a running Cron
-	c.AddFunc("@daily", func() { fmt.Println("Every day") })
+	id, _ := c.AddFunc("@daily", func() { fmt.Println("Every day") })
+	..
+	// Entries may be removed from a running Cron by their ID.
+	c.Remove(id)
+	..
+	// Entries may be pinned to a time zone, e.g. the one of a customer.
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	c.AddJobIn(tokyo, "0 0 9 * * *", job)
+	..
+	// Named entries can be looked up by their unique name.
+	c.AddNamedJob("backup", "@midnight", backup)
+	inspect(c.EntryByName("backup"))
+	..
+	// The schedule and job of an entry may be changed in place.
+	c.UpdateSchedule(id, "@every 12h")
+	..
+	// The job of an entry may be run right away, e.g. for a backfill.
+	c.RunNow(id)
+	..
+	// Entries may be triggered by the successful runs of others, in addition
+	// to their own schedule or instead of one, e.g. for the stages of a pipeline.
+	extract, _ := c.AddFunc("@hourly", extractData)
+	c.AddJobAfter(extract, transform)
+	c.TriggerAfter(report, extract)
+	..
+	// Entries keep the statistics of their runs, e.g. to monitor the jobs.
+	// Jobs implementing ErrorJob, like ErrorFuncJob, fail by returning an error.
+	c.AddJob("@hourly", cron.ErrorFuncJob(func(ctx context.Context) error { return sync(ctx) }))
+	for _, e := range c.Entries() {
+		log.Printf("%s: %d runs, %d failures, p95 %v", e.Spec, e.Stats.Runs, e.Stats.Failures, e.Stats.Percentile(95))
+	}
+	..
+	// Failed runs are passed to the handler of WithErrorHandler, and to the
+	// one of their entry.  The hooks of WithBeforeRun and WithAfterRun are
+	// called around all runs, e.g. for audit logging.
+	c.OnError(id, func(run cron.RunInfo) { alert(run.Entry.Name, run.Err) })
+	..
+	// Failed runs may be retried before the next activation of their entry.
+	c.SetRetryPolicy(id, cron.RetryPolicy{MaxAttempts: 3, Backoff: time.Second, Multiplier: 2})
+	..
+	// Jobs may be wrapped by a Chain of JobWrappers, or those of all entries
+	// by WithChain.
+	c.AddJob("@hourly", cron.NewChain(logged, cron.SkipIfStillRunning).Then(job))
+	..
+	// Runs of a job may be skipped while its previous run is still running.
+	c.AddJob("@every 1m", cron.SkipIfStillRunning(job))
+	..
+	// Or delayed until it has completed, keeping track of the delay.
+	serial := cron.DelayIfStillRunning(job)
+	c.AddJob("@every 1m", serial)
+	log.Printf("delayed by %v", serial.Delay())
+	..
+	// Or limited to a number of concurrent runs.
+	c.AddJob("@every 1m", job, cron.WithEntryConcurrency(2))
+	..
+	// Runs of a higher priority are run first by the pool of WithMaxConcurrency.
+	c.SetPriority(id, 10)
+	..
+	// Runs may be limited in time, canceling their context when exceeded.
+	c.SetTimeout(id, 10*time.Minute)
+	..
+	// Consecutive failures may stretch the interval of an entry, up to a cap.
+	c.SetFailureBackoff(id, cron.FailureBackoff{Multiplier: 2, Max: time.Hour})
+	..
+	// The events of the Cron, like added entries and finished runs, may be
+	// subscribed to, e.g. by a control plane.
+	events, unsubscribe := c.Subscribe(100)
+	..
+	// Entries may be paused, e.g. during an incident, and resumed.
+	c.Pause(id)
+	c.Resume(id)
+	..
+	// The whole Cron may be paused, and run the jobs whose activations have
+	// been missed once when it is resumed.
+	c.PauseAll()
+	c.ResumeAll(cron.CoalesceMissed)
+	..
+	// Activations missed while the process was asleep, or down since the last
+	// fire of WithLastFire, may be run once rather than back to back.
+	c.SetMisfirePolicy(id, cron.MisfireRunOnce)
+	..
+	// Or coalesced into one run, which is passed all of them, like the runs
+	// while the previous one is still running by CoalesceIfStillRunning.
+	c.SetMisfirePolicy(id, cron.MisfireCoalesce)
+	c.AddJob("@every 1m", cron.CoalesceIfStillRunning(cron.ErrorFuncJob(func(ctx context.Context) error {
+		return process(cron.Activations(ctx))
+	})))
+	..
+	// The runs of named entries may be persisted across restarts by a Store,
+	// such as a FileStore, to detect the missed activations.
+	c := cron.New(cron.WithStore(cron.NewFileStore("/var/lib/app/cron.json")))
+	..
+	// The runs of named entries may be locked by a Locker, e.g. backed by
+	// Redis, so that only one replica of a service runs each activation.
+	c := cron.New(cron.WithLocker(locker))
+	..
+	// Or the whole Cron may run the jobs only while it leads the replicas,
+	// according to an Elector.
+	c := cron.New(cron.WithElector(elector), cron.WithStore(store))
+	..
+	// Tagged entries are managed in bulk, e.g. by tenant.
+	c.Tag(id, "tenant:acme")
+	c.PauseByTag("tenant:acme")
+	c.ResumeByTag("tenant:acme")
+	c.RemoveByTag("tenant:acme")
 	..
 	// Inspect the cron job entries' next and previous run times.
 	inspect(c.Entries())
 	..
-	c.Stop()  // Stop the scheduler (does not stop any jobs already running).
+	ctx := c.Stop()  // Stop the scheduler (does not stop any jobs already running).
+	<-ctx.Done()     // Wait for the running jobs to complete.
+
+The scheduler may also be bound to a context: StartContext stops it when the
+context is done, and Run does the same in the calling goroutine, e.g. as part
+of an errgroup.Group.  Funcs added with AddContextFunc, and jobs implementing
+ContextJob, get a context which is canceled when the scheduler stops.
+
+	g.Go(func() error { return c.Run(ctx) })
+	c.AddContextFunc("@every 1m", func(ctx context.Context) { poll(ctx) })
+
+Stop cancels that context right away.  StopWithTimeout gives the running jobs
+up to a grace period to complete, then cancels the context of the others and
+returns their entries:
+
+	for _, e := range c.StopWithTimeout(25 * time.Second) {
+		log.Printf("job of %v did not complete", e.Prev)
+	}
+
+Simulate runs the jobs of a Cron which is not running in virtual time, one
+after the other, and returns their activations, e.g. to verify in a test what
+would run in the next 30 days:
+
+	activations, err := c.Simulate(now, now.AddDate(0, 0, 30))
 
 CRON Expression Format
 
-A cron expression represents a set of times, using 6 space-separated fields.
+A cron expression represents a set of times, using 6 space-separated fields
+(and an optional year).
 
 	Field name   | Mandatory? | Allowed values  | Allowed special characters
 	----------   | ---------- | --------------  | --------------------------
 	Seconds      | Yes        | 0-59            | * / , -
 	Minutes      | Yes        | 0-59            | * / , -
 	Hours        | Yes        | 0-23            | * / , -
-	Day of month | Yes        | 1-31            | * / , - ?
+	Day of month | Yes        | 1-31            | * / , - ? L W
 	Month        | Yes        | 1-12 or JAN-DEC | * / , -
-	Day of week  | Yes        | 0-6 or SUN-SAT  | * / , - ?
+	Day of week  | No         | 0-6 or SUN-SAT  | * / , - ? L #
+	Year         | No         | 1970-2099       | * / , -
+
+The Day of week field may be omitted, in which case it is equivalent to '*'.
+
+An optional seventh field restricts the schedule to the given years (1970-2099),
+e.g. "0 0 0 1 1 * 2030" runs only at midnight of January 1st 2030.  The year may
+also be appended to a spec in which the Day of week field has been omitted.
+Once the last of the years has passed, the schedule does not activate anymore.
+
+Specs in the standard crontab layout, which has no seconds field, may be parsed
+with ParseStandard.  It accepts both 5 fields (minute, hour, day of month, month,
+day of week) and 6 fields, where the additional leading field holds the seconds.
+A year may be appended in both cases.
+
+	ParseStandard("0/5 * * * *")      // Every five minutes
+	ParseStandard("0/10 * * * * *")   // Every ten seconds
+
+Expressions of the Quartz scheduler may be parsed with ParseQuartz.  They
+consist of 6 or 7 fields (second, minute, hour, day of month, month, day of
+week, year), where the days of the week are numbered from 1 (SUN) to 7 (SAT)
+and the optional year is within 1970-2099.
+
+	ParseQuartz("0 15 10 ? * 2-6")     // 10:15 every Monday to Friday
+	ParseQuartz("0 0 12 1 1 ? 2030")   // Noon at January 1st 2030 only
+
+Schedule expressions of Amazon EventBridge rules may be parsed with ParseAWS.
+Cron expressions consist of 6 fields (minute, hour, day of month, month, day of
+week, year), numbering the days of the week like Quartz, where exactly one of
+the day of month and the day of week must be "?".  Rate expressions are
+accepted as well.  NewParser(AWS) configures a parser for the cron expressions.
+
+	ParseAWS("cron(0 18 ? * MON-FRI *)") // 18:00 every Monday to Friday
+	ParseAWS("rate(5 minutes)")          // Every five minutes
+
+Other layouts may be configured with NewParser, by selecting the accepted
+fields with ParseOption flags.  Fields which are not selected take on their
+default value.  A Cron may be created with a custom parser by NewWithParser.
+
+	p := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+	c := cron.NewWithParser(p)
+	c.AddFunc("30 6 * * 1-5", func() { fmt.Println("Every weekday at 6:30") })
+
+With the Strict option, a parser rejects specs which can never be activated,
+such as "0 0 31 2 *", instead of returning a schedule which never runs.
+
+If both the day of month and the day of week are restricted, a day matching
+either of them activates the schedule, as in Vixie cron: "0 0 13 * FRI" runs on
+every 13th and on every Friday.  With the DomDowAnd option, both must match, so
+that the same spec runs on Friday the 13th only.  Such schedules can not be
+written back as a spec.
+
+Specs which can not be parsed are reported as a *ParseError, which locates the
+offending field and token in the spec and suggests a fix, e.g. "hour 25 out of
+range 0-23" for "0 0 25 * * *".
+ValidateSpec and Parser.Validate check a spec without keeping the schedule,
+e.g. to lint configurations.
+
+Specs supplied by untrusted users, e.g. through an API, may be bounded in
+length, in the number of items of a field and in the steps by Parser.WithLimits.
+Exceeding a limit is reported by a *ParseError with a *LimitError:
+
+	p := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow).
+		WithLimits(cron.DefaultLimits)
+
+Normalize returns the canonical form of a spec, so that equivalent specs may be
+compared as strings: "0 0 9 * * mon-fri,SAT" and "0 0 9 ? * 1-6" both become
+"0 0 9 * * 1-6".  Schedules implement encoding.TextMarshaler as well, to be
+stored in configurations without keeping the original spec.
 
 Note: Month and Day-of-week field values are case insensitive.  "SUN", "Sun",
 and "sun" are equally accepted.
 
+The full English names, such as "Thursday" or "January", are accepted as well.
+Parser.WithNames configures a parser to accept the names of the months and days
+of the week of other locales, so that specs written by operators in their own
+language parse, e.g. "30 6 * janvier Montag-Freitag" with the French and German
+names.
+
 Special Characters
 
 Asterisk ( * )
@@ -70,6 +285,59 @@ Question mark ( ? )
 Question mark may be used instead of '*' for leaving either day-of-month or
 day-of-week blank.
 
+L
+
+In the day-of-month field, "L" stands for the last day of the month, e.g. the
+31st of January or the 28th of February in a non-leap year.  In the day-of-week
+field, a weekday followed by "L" stands for the last such weekday of the month,
+e.g. "5L" or "FRIL" for the last Friday of the month.
+
+W
+
+In the day-of-month field, a day followed by "W" stands for the weekday (Monday
+to Friday) nearest to that day, e.g. "15W" runs on Friday the 14th if the 15th
+is a Saturday, and on Monday the 16th if the 15th is a Sunday.  The nearest
+weekday never leaves the month: "1W" runs on Monday the 3rd if the 1st is a
+Saturday.  "LW" stands for the last weekday of the month, e.g. for month-end
+jobs; LastBusinessDay also skips the holidays of a Calendar.
+
+Hash ( # )
+
+In the day-of-week field, "n#k" stands for the k-th weekday n of the month,
+where k is within 1-5.  For example "2#3" (or "TUE#3") stands for the third
+Tuesday of the month.
+
+Tilde ( ~ )
+
+The "~" token stands for a value picked at random within the range of the
+field when the spec is parsed, as in OpenBSD cron.  "0~29" in the minutes field
+picks one minute within 0-29, which stays the same for the entry, while each
+entry added with the same spec picks its own.  This spreads fleets of
+identical jobs without a hash key.  A bound may be omitted, e.g. "~" picks any
+minute and "20~" in the hours field one hour within 20-23.  In the day-of-month
+field, an upper bound defaults to 28.
+
+Period ( . )
+
+In the seconds field, the seconds may be followed by "." and the milliseconds
+within each of them, given as a list of values or ranges within 0-999.  For
+example "*.0/500 * 9-17 * * MON-FRI" runs every 500 milliseconds during business
+hours, and "0.250" in the seconds field runs a quarter second past the minute.
+The milliseconds are kept exact, the Cron does not round them to the second.
+
+H
+
+The "H" (hash) token stands for a value within the range of the field that is
+derived from a hash key, when the spec is parsed with ParseWithHashKey.  This
+spreads jobs with the same spec over the range, while a job with the same key
+always runs at the same time, even across restarts.  "H" may be followed by a
+step ("H/15" in the minutes field runs every 15 minutes, starting at a hashed
+minute below 15) and may be limited to a range ("H(8-17)" in the hours field
+picks one hour between 8 and 17).  In the day-of-month field, "H" picks one of
+the days 1-28, which exist in every month.
+
+	ParseWithHashKey("0 H H/4 * * *", "report")  // Every four hours, at a minute derived from "report"
+
 Predefined schedules
 
 You may use one of several pre-defined schedules in place of a cron expression.
@@ -81,6 +349,15 @@ You may use one of several pre-defined schedules in place of a cron expression.
 	@weekly                | Run once a week, midnight on Sunday        | 0 0 0 * * 0
 	@daily (or @midnight)  | Run once a day, midnight                   | 0 0 0 * * *
 	@hourly                | Run once an hour, beginning of hour        | 0 0 * * * *
+	@weekday [HH:MM]       | Run Monday to Friday, at midnight or HH:MM | 0 M H * * 1-5
+	@weekend [HH:MM]       | Run Saturday and Sunday, likewise          | 0 M H * * 0,6
+	@reboot                | Run once, when the Cron is started         | -
+
+Additional descriptors may be registered on a Parser with WithDescriptor, which
+maps the name of the descriptor to a schedule provided by the caller.
+
+	p := cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor).
+		WithDescriptor("@quarterly", quarterly)
 
 Intervals
 
@@ -90,11 +367,22 @@ formatting the cron spec like this:
     @every <duration>
 
 where "duration" is a string accepted by time.ParseDuration
-(http://golang.org/pkg/time/#ParseDuration).
+(http://golang.org/pkg/time/#ParseDuration), which may start with a number of
+weeks "w" and days "d" of 24 hours, e.g. "1d" or "2d12h".
+
+Intervals of years "y" and months "mo", e.g. "@every 1mo" or "@every 1y6mo",
+advance the calendar date instead, starting when the spec is parsed.  They keep
+the day of the month of the start, but not beyond the end of the month: monthly
+from January 31st activates on February 28th, March 31st and so on.
 
 For example, "@every 1h30m10s" would indicate a schedule that activates every
 1 hour, 30 minutes, 10 seconds.
 
+The interval is rounded to the second.  For shorter intervals, e.g. to sample
+every 250 milliseconds, use EveryExact, which is not rounded:
+
+	c.Schedule(cron.EveryExact(250*time.Millisecond), sample)
+
 Note: The interval does not take the job runtime into account.  For example,
 if a job takes 3 minutes to run, and it is scheduled to run every 5 minutes,
 it will have only 2 minutes of idle time between each run.
@@ -111,13 +399,299 @@ a random delay between 0s (immediatly) and <duration>.
 This option helps to distribute jobs inside the same interval to better
 distribute the load for the system.
 
+The random delay changes with every restart.  The hash token "H" derives a
+stable offset within the interval from the hash key of ParseWithHashKey, e.g.
+the name of the job, instead:
+
+    @every <duration>,H
+
+Jobs with the same key activate at the same times on every replica and across
+restarts.  EveryWithKey returns such a schedule for a key.
+
+Each activation of a fixed interval may also be delayed by a random amount of up
+to a given jitter, which again spreads the load of jobs with the same interval:
+
+    @every <duration>~<jitter>
+
+For example, "@every 5m~30s" runs every five minutes, each time delayed by up to
+30 seconds.  The jitter does not accumulate, the activations stay on the cadence
+of the interval.
+
+Intervals run relative to the start of the process by default, so a restart
+shifts them.  The special string "@aligned" aligns them to the wall clock
+instead, in the local time zone or the one given by CRON_TZ:
+
+    @every <duration>,@aligned
+
+For example, "@every 5m,@aligned" runs at :00, :05, :10 and so on, across
+restarts.  EveryAligned returns such a schedule for a location.
+
+Intervals of whole days drift by an hour when the clocks change for daylight
+saving time.  Giving a time of the day keeps them at that wall clock time:
+
+    @every <duration> at HH:MM
+
+where the duration is a number of days.  For example, "@every 1d at 03:00" runs
+every day at 3am, and "@every 2d at 03:00" every other day, counted from January 1st 2000.  EveryDaysAt returns such
+a schedule.
+
+ISO 8601 repeating intervals
+
+Schedules exchanged with other systems as ISO 8601 repeating intervals may be
+parsed with ParseRepeatingInterval:
+
+	ParseRepeatingInterval("R5/2025-01-01T00:00:00Z/PT6H")  // 5 times, every 6 hours
+	ParseRepeatingInterval("R/2025-01-31T00:00:00Z/P1M")    // Every month, forever
+
+The schedule activates at the start and after every period.  Years, months,
+weeks and days of the period are added to the calendar date.  Months keep the
+day of the month of the start, but not beyond the end of the month: monthly from
+January 31st activates on February 28th, March 31st, April 30th and so on.
+
+Recurrence rules
+
+Schedules kept in calendar systems may be parsed from iCalendar (RFC 5545)
+recurrence rules with ParseRRule, using the DTSTART of the event as start:
+
+	ParseRRule("FREQ=WEEKLY;BYDAY=MO,WE,FR", start)      // Every Monday, Wednesday and Friday
+	ParseRRule("FREQ=MONTHLY;BYDAY=-1FR;COUNT=12", start) // The last Friday of the next 12 months
+
+The rule parts FREQ (MINUTELY to YEARLY), INTERVAL, COUNT, UNTIL, BYDAY,
+BYMONTHDAY, BYMONTH and WKST are supported.  The activations have the time of
+day of the start.
+
+systemd calendar expressions
+
+Services migrated off systemd timers may keep their OnCalendar= expressions,
+which are parsed with ParseOnCalendar:
+
+	ParseOnCalendar("Mon..Fri *-*-* 06:00:00")     // Weekdays at 6am
+	ParseOnCalendar("*-*-01 04:00 Europe/Berlin") // The first of the month at 4am in Berlin
+	ParseOnCalendar("weekly")                     // Mondays at midnight
+
+Unlike cron specs, both the weekdays and the date must match.  Last days of
+the month ("~") are not supported.
+
+Crontab files
+
+Whole crontab(5) files are parsed by ParseCrontab into a CrontabEntry per job
+line, with its schedule, command and the environment variables assigned before
+it, to be bound to jobs by the caller:
+
+	entries, err := cron.ParseCrontab(file)
+	for _, e := range entries {
+		c.Schedule(e.Schedule, commandJob(e.Command, e.Env))
+	}
+
+Comments, "name = value" assignments, "%" in commands and descriptors such as
+"@daily" are handled as by cron.  A CRON_TZ assignment applies its time zone
+to the following entries.  ParseCrontabWithParser accepts other layouts, e.g.
+with a seconds field.
+
+Building schedules
+
+Schedules may be constructed in code, without writing a spec, by a Builder:
+
+	cron.On().Weekdays().At(9, 30).InLocation(loc).Build()         // 09:30 every Monday to Friday
+	cron.On().DaysOfMonth(1, 15).At(6, 0).Build()                  // 06:00 on the 1st and 15th
+	cron.On().Weekdays().EveryMinutes(15).Between(9, 17).Build()   // Every quarter hour during business hours
+
+Build reports invalid values, such as At(24, 0), as an error.  As in systemd
+calendar expressions, days of the month and days of the week must both match.
+
+Configuration files may hold a Spec instead of a spec string, listing the items
+of each field, which is converted by NewSpecSchedule:
+
+	{"minutes": [0, 30], "hours": ["9-17"], "daysOfWeek": ["MON-FRI"]}
+
+Values of a Spec unmarshal from JSON and YAML lists of strings and numbers,
+or a single item.  Fields without items take on their defaults.
+
+Windows
+
+Any schedule may be constrained to a daily window, by appending the window to
+the spec:
+
+    <spec> @between <start>-<end>
+
+where "start" and "end" are times of the day in the format HH:MM.  Activations
+outside of the window are skipped.  The window includes the start but excludes
+the end.  If the end is before the start, the window spans midnight.
+
+For example, "0 0/5 * * * * @between 08:00-18:00" runs every five minutes from
+8am to 5:55pm.
+
+Composite schedules
+
+Schedules may be combined into a single schedule.  ScheduleUnion activates at
+the activations of all of its schedules:
+
+	mondays, _ := cron.Parse("0 0 9 * * Mon")
+	firsts, _ := cron.Parse("0 0 7 1 * *")
+	c.Schedule(cron.ScheduleUnion(mondays, firsts), job)
+
+ScheduleIntersection activates only when all of its schedules activate at the
+same instant, e.g. every 15 minutes, but only on weekdays:
+
+	quarters, _ := cron.Parse("0 0/15 * * * *")
+	weekdays, _ := cron.Parse("* * * * * Mon-Fri")
+	c.Schedule(cron.ScheduleIntersection(quarters, weekdays), job)
+
+Except skips the activations of a schedule which coincide with another one,
+e.g. hourly, except during a nightly backup from 2am to 4am:
+
+	hourly, _ := cron.Parse("@hourly")
+	backup, _ := cron.Parse("* * 2-3 * * *")
+	c.Schedule(cron.Except(hourly, backup), job)
+
+Holidays
+
+Activations on holidays may be skipped, or shifted to the following day which
+is not a holiday, by WithCalendar.  A Calendar tells which days are holidays;
+NewStaticCalendar lists them, and ParseICS reads them from an iCalendar feed:
+
+	holidays, err := cron.ParseICS(feed)
+	daily, _ := cron.Parse("0 0 18 * * Mon-Fri")
+	c.Schedule(cron.WithCalendar(daily, holidays, cron.SkipHolidays), job)
+
+Business days
+
+NthBusinessDay activates on the n-th business day of every month, counted from
+the end of the month if n is negative, LastBusinessDay on the last one, and
+OnBusinessDay on a day of the month rolled to a business day by a RollPolicy,
+e.g. to the following business day, unless that is in the next month:
+
+	c.Schedule(cron.NthBusinessDay(3, 6, 0), job)
+	c.Schedule(cron.OnBusinessDay(15, 6, 0, cron.RollModifiedFollowing), job)
+
+Business days are Monday to Friday by default.  The Weekend and Holidays fields
+of a BusinessDaySchedule change which days are not business days.
+
+Fiscal calendars
+
+EveryFiscalPeriod activates at the start of the periods of a FiscalCalendar,
+e.g. on the first day of each fiscal quarter at 02:00.  MonthlyFiscalCalendar
+has calendar months in a fiscal year starting in any month, and NewCalendar445
+returns a 4-4-5 calendar of weeks.  Other calendars implement FiscalYear:
+
+	quarters := cron.EveryFiscalPeriod(cron.MonthlyFiscalCalendar{Start: time.October}, 3, 2, 0)
+	periods := cron.EveryFiscalPeriod(cron.NewCalendar445(time.September, time.Saturday), 1, 2, 0)
+
+Calendar systems
+
+InCalendarSystem matches the months and the days of the month of a schedule
+against another calendar system, e.g. the Hijri calendar for the first day of
+Ramadan, while the times of the day and the days of the week are kept.  Calendar
+systems implement Date and DaysIn; Gregorian is the calendar of the schedules
+themselves:
+
+	ramadan, err := cron.InCalendarSystem(firstOfNinthMonth, hijri)
+
+Weeks of the year
+
+InWeeks restricts a schedule to ISO 8601 week numbers, given like a field
+within 1-53, e.g. only in the even weeks for alternating shift rotations:
+
+	evenWeeks, err := cron.InWeeks(daily, "2/2")
+	firstHalf, err := cron.InWeeks(daily, "1-26")
+
+Solar schedules
+
+Sunrise and Sunset activate daily when the sun rises or sets at a latitude and
+longitude, plus an offset, e.g. 30 minutes before sunset in Zurich:
+
+	c.Schedule(cron.Sunset(47.3769, 8.5417, -30*time.Minute), lightsOn)
+
+Bounded schedules
+
+Limit activates like a schedule for its first n activations from now on only,
+e.g. for a bounded number of retries.  Afterwards, Next returns the zero time
+and the entry no longer runs.  The activations are counted from the time
+Limit is called, so activations missed while the Cron is stopped count too:
+
+	c.Schedule(cron.Limit(cron.Every(time.Minute), 3), retry)
+
+NotAfter stops a schedule after a time, e.g. at the end of a contract, and
+StartingAt suppresses its activations before a time:
+
+	c.Schedule(cron.NotAfter(cron.StartingAt(daily, contractStart), contractEnd), job)
+
+One-shot schedules
+
+A job may be scheduled to run exactly once, at a given time:
+
+    @at <timestamp>
+
+where "timestamp" is a time in the RFC 3339 format
+(http://golang.org/pkg/time/#pkg-constants), e.g. "@at 2025-07-01T03:00:00Z".
+After the job has run, the schedule does not activate anymore.
+
+Upcoming activations
+
+Occurrences returns the next activations of any schedule, e.g. to show the
+upcoming runs of a job:
+
+	sched, _ := cron.Parse("0 30 9 * * MON-FRI")
+	for _, t := range cron.Occurrences(sched, time.Now(), 5) {
+		fmt.Println(t)
+	}
+
+OccurrencesBetween returns the activations within a period instead, e.g. to plan
+a backfill or to check that a schedule activates at most a few times a day, and
+EachOccurrence iterates over them without keeping them in memory.
+
+Diff compares the activations of two schedules up to a horizon and returns the
+first Divergence, if any, and Equivalent checks that they do not diverge from
+now on.  This proves that a refactored spec behaves as before:
+
+	if d := cron.Diff(old, refactored, time.Now(), 366*24*time.Hour); d != nil {
+		log.Fatalf("schedules differ: %v", d)
+	}
+
+In reverse, InferSpec proposes the tightest spec activating at exactly a series
+of times, e.g. the past runs of a legacy scheduler, or reports that none exists:
+
+	spec, err := cron.InferSpec(runs) // e.g. "0 0,30 9-17 * * 1-5"
+
 Time zones
 
 All interpretation and scheduling is done in the machine's local time zone (as
 provided by the Go time package (http://www.golang.org/pkg/time).
 
+A spec may be evaluated in a different time zone by prefixing it with
+"CRON_TZ=" or "TZ=" and the name of the location, as accepted by
+time.LoadLocation.  For example, "CRON_TZ=Europe/Zurich 0 0 6 * * *" runs at
+6am in Zurich, regardless of the machine's local time zone.
+
 Be aware that jobs scheduled during daylight-savings leap-ahead transitions will
-not be run!
+not be run by default!  The GapPolicy of a SpecSchedule chooses between
+skipping them (GapSkip) and running them once at the first valid instant after
+the gap (GapRunAfter), e.g. a job scheduled at 02:30 runs at 03:00 when the
+clocks jump from 02:00 to 03:00.  Parser.WithGapPolicy sets the policy of the
+parsed schedules:
+
+	p := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow).
+		WithGapPolicy(cron.GapRunAfter)
+
+When the clocks fall back at the end of daylight saving time, the wall clock
+times of the repeated hour activate twice by default.  The OverlapPolicy of a
+SpecSchedule restricts them to their first (OverlapFirst) or second
+(OverlapSecond) occurrence, and is set by Parser.WithOverlapPolicy.
+
+Descriptions
+
+Schedules may be described in English for display, e.g. in an admin interface,
+by Describe, or by DescribeSpec for a spec:
+
+	DescribeSpec("0 30 6 * * MON-FRI")       // "at 06:30 on Monday through Friday"
+	DescribeSpec("0 0/5 9-17 * * *")         // "every 5 minutes, between 09:00 and 17:59"
+	DescribeSpec("0 0 0 L * *")              // "at 00:00 on the last day of the month"
+
+Descriptions in other languages are returned by DescribeIn and DescribeSpecIn
+for the name of a locale.  German ("de"), French ("fr"), Italian ("it") and
+Spanish ("es") are built in, further locales may be added with RegisterLocale.
+
+	DescribeSpecIn("0 30 6 * * MON-FRI", "de") // "um 06:30 am Montag bis Freitag"
 
 Thread safety
 
@@ -133,7 +707,8 @@ Cron entries are stored in an array, sorted by their next activation time.  Cron
 sleeps until the next job is due to be run.
 
 Upon waking:
- - it runs each entry that is active on that second
+ - it runs each entry that is active at that time, which may be within a
+   second for specs with milliseconds
  - it calculates the next run times for the jobs that were run
  - it re-sorts the array of entries by next activation time.
  - it goes to sleep until the soonest job.
@@ -0,0 +1,138 @@
+package cron
+
+import "time"
+
+// GapPolicy chooses whether a SpecSchedule activates at a wall clock time which
+// does not exist, because the clocks jump forward at the start of daylight
+// saving time, e.g. at 02:30 when the clocks jump from 02:00 to 03:00.
+type GapPolicy int
+
+const (
+	GapSkip     GapPolicy = iota // Do not activate at the skipped time (default)
+	GapRunAfter                  // Activate at the first valid instant after the gap, e.g. at 03:00
+)
+
+// OverlapPolicy chooses how often a SpecSchedule activates at a wall clock time
+// which occurs twice, because the clocks fall back at the end of daylight saving
+// time, e.g. at 01:30 when the clocks fall back from 02:00 to 01:00.
+type OverlapPolicy int
+
+const (
+	OverlapBoth   OverlapPolicy = iota // Activate at both occurrences (default)
+	OverlapFirst                       // Activate at the first occurrence only
+	OverlapSecond                      // Activate at the second occurrence only
+)
+
+// skipsRepeat returns true if the policy skips the activation at the given
+// instant, because it is an occurrence of a repeated wall clock time which the
+// policy does not run.
+func (s *SpecSchedule) skipsRepeat(t time.Time) bool {
+	if s.Location != nil {
+		t = t.In(s.Location)
+	}
+	switch occurrence(t) {
+	case 1:
+		return s.Overlap == OverlapSecond
+	case 2:
+		return s.Overlap == OverlapFirst
+	}
+	return false
+}
+
+// occurrence returns 1 or 2 if the wall clock time of the instant occurs twice
+// in its location, for its first and second occurrence, and 0 otherwise.
+func occurrence(t time.Time) int {
+	fallBack := func(before, after int) bool { return after < before }
+	for _, end := range transitionsBetween(t.Add(-24*time.Hour), t.Add(24*time.Hour), fallBack) {
+		_, before := end.Add(-time.Second).Zone()
+		_, after := end.Zone()
+		repeated := time.Duration(before-after) * time.Second
+		switch {
+		case !t.Before(end.Add(-repeated)) && t.Before(end):
+			return 1
+		case !t.Before(end) && t.Before(end.Add(repeated)):
+			return 2
+		}
+	}
+	return 0
+}
+
+// nextAfterGap returns the end of the first gap within (t, next), in which the
+// schedule would have activated, or next if there is none.  If next is zero,
+// the gaps within five years are considered.
+func (s *SpecSchedule) nextAfterGap(t, next time.Time) time.Time {
+	loc := t.Location()
+	if s.Location != nil {
+		loc = s.Location
+	}
+	end := next
+	if end.IsZero() {
+		end = t.AddDate(5, 0, 0)
+	}
+	for _, gap := range gapsBetween(t.In(loc), end.In(loc)) {
+		if gap.After(t) && (next.IsZero() || gap.Before(next)) && s.matchesGap(gap) {
+			return gap.In(t.Location())
+		}
+	}
+	return next
+}
+
+// matchesGap returns true if the schedule matches a wall clock time which is
+// skipped by the clocks jumping forward at the given instant.
+func (s *SpecSchedule) matchesGap(gap time.Time) bool {
+	if 1<<uint(gap.Month())&s.Month == 0 || !dayMatches(s, gap) || !s.yearMatches(gap.Year()) {
+		return false
+	}
+	_, before := gap.Add(-time.Second).Zone()
+	_, after := gap.Zone()
+	end := gap.Hour()*3600 + gap.Minute()*60 + gap.Second()
+	for clock := end - (after - before); clock < end; clock++ {
+		if clock >= 0 && 1<<uint(clock/3600)&s.Hour > 0 && 1<<uint(clock/60%60)&s.Minute > 0 && 1<<uint(clock%60)&s.Second > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// gapsBetween returns the instants within (from, to], at which the clocks of
+// their location jump forward, in order.
+func gapsBetween(from, to time.Time) []time.Time {
+	return transitionsBetween(from, to, func(before, after int) bool { return after > before })
+}
+
+// transitionsBetween returns the instants within (from, to], at which the
+// offset of their location changes in the way accepted by the function, in
+// order.  At most one transition per day is found.
+func transitionsBetween(from, to time.Time, accept func(before, after int) bool) []time.Time {
+	var (
+		transitions []time.Time
+		loc         = from.Location()
+		_, offset   = from.Zone()
+	)
+	for day := from.Unix(); day < to.Unix(); day += 24 * 60 * 60 {
+		next := day + 24*60*60
+		if next > to.Unix() {
+			next = to.Unix()
+		}
+		_, nextOffset := time.Unix(next, 0).In(loc).Zone()
+		if nextOffset == offset {
+			continue
+		}
+
+		// Find the first second with the new offset.
+		low, high := day, next
+		for high-low > 1 {
+			mid := low + (high-low)/2
+			if _, o := time.Unix(mid, 0).In(loc).Zone(); o == offset {
+				low = mid
+			} else {
+				high = mid
+			}
+		}
+		if accept(offset, nextOffset) {
+			transitions = append(transitions, time.Unix(high, 0).In(loc))
+		}
+		offset = nextOffset
+	}
+	return transitions
+}
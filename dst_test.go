@@ -0,0 +1,115 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGapPolicy(t *testing.T) {
+	runAfter := standardParser.WithGapPolicy(GapRunAfter)
+	tests := []struct {
+		parser         Parser
+		spec           string
+		time, expected string
+	}{
+		// Daylight saving time starts on March 11th 2012 in New York, at 02:00
+		// EST, which is skipped to 03:00 EDT.
+		{standardParser, "30 2 * * *", "2012-03-11T00:00:00-0500", "2012-03-12T02:30:00-0400"},
+		{runAfter, "30 2 * * *", "2012-03-11T00:00:00-0500", "2012-03-11T03:00:00-0400"},
+		{runAfter, "30 2 * * *", "2012-03-11T03:00:00-0400", "2012-03-12T02:30:00-0400"},
+		{runAfter, "0 2 * * *", "2012-03-11T01:59:59-0500", "2012-03-11T03:00:00-0400"},
+		{runAfter, "59 2 11 3 *", "2012-03-10T00:00:00-0500", "2012-03-11T03:00:00-0400"},
+
+		// Several skipped times activate once.
+		{runAfter, "*/15 2 * * *", "2012-03-11T01:00:00-0500", "2012-03-11T03:00:00-0400"},
+		{runAfter, "*/15 2 * * *", "2012-03-11T03:00:00-0400", "2012-03-12T02:00:00-0400"},
+
+		// A schedule activating at the end of the gap activates once.
+		{runAfter, "0 2,3 * * *", "2012-03-11T01:00:00-0500", "2012-03-11T03:00:00-0400"},
+		{runAfter, "0 2,3 * * *", "2012-03-11T03:00:00-0400", "2012-03-12T02:00:00-0400"},
+
+		// Schedules which do not match the gap are not affected.
+		{runAfter, "30 1 * * *", "2012-03-11T00:00:00-0500", "2012-03-11T01:30:00-0500"},
+		{runAfter, "30 2 * * MON", "2012-03-11T00:00:00-0500", "2012-03-12T02:30:00-0400"},
+		{runAfter, "30 2 12 3 *", "2012-03-11T00:00:00-0500", "2012-03-12T02:30:00-0400"},
+
+		// Only the start of daylight saving time leaves a gap.
+		{runAfter, "30 2 4 11 *", "2012-11-01T00:00:00-0400", "2012-11-04T02:30:00-0500"},
+
+		// A gap before the next activation of a rare schedule.
+		{runAfter, "30 2 11 3 * 2012", "2012-01-01T00:00:00-0500", "2012-03-11T03:00:00-0400"},
+		{runAfter, "@daily", "2012-03-11T00:00:00-0500", "2012-03-12T00:00:00-0400"},
+	}
+	for _, c := range tests {
+		sched, err := c.parser.Parse(c.spec)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		actual := sched.Next(getTime(c.time))
+		if expected := getTime(c.expected); !actual.Equal(expected) {
+			t.Errorf("%s, \"%s\": (expected) %v != %v (actual)", c.time, c.spec, expected, actual)
+		}
+	}
+
+	// The policy applies in the location of the schedule.
+	sched, _ := runAfter.Parse("CRON_TZ=Europe/Zurich 30 2 * * *")
+	from, expected := time.Date(2021, time.March, 28, 0, 0, 0, 0, time.UTC), time.Date(2021, time.March, 28, 1, 0, 0, 0, time.UTC)
+	if actual := sched.Next(from); !actual.Equal(expected) || actual.Location() != time.UTC {
+		t.Errorf("(expected) %v != %v (actual)", expected, actual)
+	}
+
+	// The policy is not part of the spec.
+	if _, err := sched.(*SpecSchedule).MarshalText(); err == nil {
+		t.Error("expected an error marshaling a schedule with a gap policy")
+	}
+}
+
+func TestOverlapPolicy(t *testing.T) {
+	first := standardParser.WithOverlapPolicy(OverlapFirst)
+	second := standardParser.WithOverlapPolicy(OverlapSecond)
+	tests := []struct {
+		parser         Parser
+		spec           string
+		time, expected string
+	}{
+		// Daylight saving time ends on November 4th 2012 in New York, at 02:00
+		// EDT, which falls back to 01:00 EST.
+		{standardParser, "30 1 * * *", "2012-11-04T00:00:00-0400", "2012-11-04T01:30:00-0400"},
+		{standardParser, "30 1 * * *", "2012-11-04T01:30:00-0400", "2012-11-04T01:30:00-0500"},
+		{first, "30 1 * * *", "2012-11-04T00:00:00-0400", "2012-11-04T01:30:00-0400"},
+		{first, "30 1 * * *", "2012-11-04T01:30:00-0400", "2012-11-05T01:30:00-0500"},
+		{second, "30 1 * * *", "2012-11-04T00:00:00-0400", "2012-11-04T01:30:00-0500"},
+		{second, "30 1 * * *", "2012-11-04T01:30:00-0500", "2012-11-05T01:30:00-0500"},
+
+		// The policy applies to every repeated time.
+		{first, "0 * * * *", "2012-11-04T01:00:00-0400", "2012-11-04T02:00:00-0500"},
+		{second, "0 * * * *", "2012-11-04T00:00:00-0400", "2012-11-04T01:00:00-0500"},
+		{first, "*/20 1 * * *", "2012-11-04T01:40:00-0400", "2012-11-05T01:00:00-0500"},
+
+		// Other times are not affected.
+		{first, "30 0,2 * * *", "2012-11-04T00:00:00-0400", "2012-11-04T00:30:00-0400"},
+		{first, "30 0,2 * * *", "2012-11-04T00:30:00-0400", "2012-11-04T02:30:00-0500"},
+		{second, "30 1 * * *", "2012-11-05T00:00:00-0500", "2012-11-05T01:30:00-0500"},
+		{second, "30 1 * * *", "2012-07-09T00:00:00-0400", "2012-07-09T01:30:00-0400"},
+	}
+	for _, c := range tests {
+		sched, err := c.parser.Parse(c.spec)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		actual := sched.Next(getTime(c.time))
+		if expected := getTime(c.expected); !actual.Equal(expected) {
+			t.Errorf("%s, \"%s\": (expected) %v != %v (actual)", c.time, c.spec, expected, actual)
+		}
+	}
+
+	// The policy applies in the location of the schedule, where daylight
+	// saving time ends on October 28th 2012 at 03:00 CEST.
+	sched, _ := first.Parse("CRON_TZ=Europe/Zurich 30 2 * * *")
+	from, expected := time.Date(2012, time.October, 28, 0, 30, 0, 0, time.UTC), time.Date(2012, time.October, 29, 1, 30, 0, 0, time.UTC)
+	if actual := sched.Next(from); !actual.Equal(expected) {
+		t.Errorf("(expected) %v != %v (actual)", expected, actual)
+	}
+}
@@ -0,0 +1,50 @@
+package cron
+
+import (
+	"context"
+	"time"
+)
+
+// Elector elects the leader among the replicas of a service, e.g. with a
+// Kubernetes lease or an etcd election, so that only the leader runs the jobs.
+type Elector interface {
+	// Elect campaigns for the leadership until the context is done, and
+	// returns a channel receiving true when the leadership is gained, and
+	// false when it is lost.  Closing the channel loses the leadership.
+	Elect(ctx context.Context) <-chan bool
+}
+
+// WithElector runs the jobs only while the Cron leads according to the given
+// elector.  The Cron starts as a follower, and stops running the jobs as soon
+// as the leadership is lost.  When it is gained, the entries are activated as
+// when the Cron starts, so that the activations after the last fire of
+// WithLastFire or WithStore are handled by their misfire policy, e.g. the ones
+// missed between the loss of the previous leader and the election.  Entries
+// with a RebootSchedule, e.g. "@reboot", run on the first leadership only.
+// RunNow runs the jobs regardless of the leadership.
+func WithElector(elector Elector) Option {
+	return func(c *Cron) {
+		c.elector = elector
+	}
+}
+
+// elect returns the channel of the leadership changes of the elector, if any,
+// and whether the Cron leads initially.
+func (c *Cron) elect(ctx context.Context) (<-chan bool, bool) {
+	if c.elector == nil {
+		return nil, true
+	}
+	return c.elector.Elect(ctx), false
+}
+
+// lead activates the entries when the leadership has been gained.  Entries
+// with a RebootSchedule which have already activated, leaving no next
+// activation, are not activated again.
+func (c *Cron) lead(now time.Time) {
+	for _, e := range c.entries {
+		if _, ok := e.Schedule.(RebootSchedule); ok && e.Next.IsZero() {
+			continue
+		}
+		c.activate(e, now)
+	}
+}
@@ -0,0 +1,116 @@
+package cron
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// chanElector sends the leadership changes of its channel.
+type chanElector chan bool
+
+func (e chanElector) Elect(ctx context.Context) <-chan bool {
+	return e
+}
+
+// Only the leader runs the jobs, catching up on the activations missed since
+// the last fire by the misfire policy.
+func TestWithElector(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2016, time.March, 1, hour, 0, 0, 0, time.UTC) }
+	clock := newFakeClock(time.Date(2016, time.March, 1, 12, 0, 30, 0, time.UTC))
+	elections := make(chanElector)
+	ran := make(chan time.Time, 10)
+	cron := New(WithClock(clock), WithLocation(time.UTC), WithElector(elections),
+		WithBeforeRun(func(run RunInfo) { ran <- run.Scheduled }),
+		WithLastFire(func(e *Entry) time.Time { return at(12) }))
+	id, _ := cron.AddFunc("0 0 * * * *", func() {})
+	cron.SetMisfirePolicy(id, MisfireRunOnce)
+	cron.Start()
+	defer cron.Stop()
+
+	expectRuns := func(expected ...time.Time) {
+		var actual []time.Time
+		for waiting := true; waiting; {
+			select {
+			case t := <-ran:
+				actual = append(actual, t)
+			case <-time.After(50 * time.Millisecond):
+				waiting = false
+			}
+		}
+		if len(actual) != len(expected) || len(actual) > 0 && !actual[0].Equal(expected[0]) {
+			t.Errorf("(expected) %v != %v (actual)", expected, actual)
+		}
+	}
+
+	// Followers do not run the jobs.
+	clock.waitForTimers(t, 1)
+	clock.Advance(2*time.Hour + 29*time.Minute + 30*time.Second)
+	expectRuns()
+
+	// The leader runs the activations missed since 12:00 once.
+	elections <- true
+	expectRuns(at(14))
+
+	// The jobs stop running as soon as the leadership is lost.
+	elections <- false
+	clock.Advance(time.Hour)
+	expectRuns()
+
+	// Closing the channel loses the leadership as well.
+	elections <- true
+	expectRuns(at(15))
+	close(elections)
+	clock.Advance(time.Hour)
+	expectRuns()
+}
+
+// Entries with a RebootSchedule run on the first leadership only, not when the
+// leadership is lost and gained again.
+func TestWithElectorReboot(t *testing.T) {
+	clock := newFakeClock(time.Date(2016, time.March, 1, 12, 0, 0, 0, time.UTC))
+	elections := make(chanElector)
+	ran := make(chan string, 10)
+	cron := New(WithClock(clock), WithLocation(time.UTC), WithElector(elections),
+		WithBeforeRun(func(run RunInfo) { ran <- run.Entry.Name }))
+	if _, err := cron.AddNamedJob("reboot", "@reboot", FuncJob(func() {})); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cron.AddNamedJob("hourly", "0 0 * * * *", FuncJob(func() {})); err != nil {
+		t.Fatal(err)
+	}
+	cron.Start()
+	defer cron.Stop()
+
+	expectRuns := func(expected ...string) {
+		var actual []string
+		for waiting := true; waiting; {
+			select {
+			case name := <-ran:
+				actual = append(actual, name)
+			case <-time.After(50 * time.Millisecond):
+				waiting = false
+			}
+		}
+		if fmt.Sprint(actual) != fmt.Sprint(expected) {
+			t.Errorf("(expected) %v != %v (actual)", expected, actual)
+		}
+	}
+
+	// Followers do not run the jobs, not even the reboot one.
+	expectRuns()
+
+	// The first leadership runs the reboot job.
+	elections <- true
+	expectRuns("reboot")
+
+	// Losing and gaining the leadership again does not run it again.
+	elections <- false
+	expectRuns()
+	elections <- true
+	expectRuns()
+	clock.waitForTimers(t, 1)
+	clock.Advance(time.Hour)
+	expectRuns("hourly")
+}
@@ -0,0 +1,148 @@
+package cron
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+)
+
+// ParseError describes why a spec could not be parsed, and where.
+type ParseError struct {
+	Spec string // The spec which could not be parsed
+
+	// Field is the position of the offending field in the spec, starting at 0,
+	// or -1 if the error does not concern a single field, e.g. if the number
+	// of fields is wrong.
+	Field int
+	Name  string // Name of the offending field, e.g. "hour"
+
+	Token  string // Offending token, e.g. "25"
+	Offset int    // Byte offset of the token in the spec
+
+	Message string // Description of the error
+	Hint    string // Suggestion how to fix the error, e.g. "hour 25 out of range 0-23"
+
+	// Limit is the limit of the parser exceeded by the spec, if any.
+	Limit *LimitError
+}
+
+// LimitError describes a limit of a parser exceeded by a spec.
+type LimitError struct {
+	Limit string // Name of the limit, e.g. "MaxLength"
+	Value int    // Value exceeding the limit, e.g. the length of the spec
+	Max   int    // Maximum allowed by the limit
+}
+
+// Error returns the description of the error.
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%s exceeded: %d above %d", e.Limit, e.Value, e.Max)
+}
+
+// panicLimit logs the exceeded limit and panics with a *LimitError, like
+// log.Panicf.
+func panicLimit(limit string, value, max int) {
+	e := &LimitError{Limit: limit, Value: value, Max: max}
+	log.Print(e)
+	panic(e)
+}
+
+// Error returns the description of the error.
+func (e *ParseError) Error() string {
+	return e.Message
+}
+
+// fieldNames lists the names of the fields, in the order of places.
+var fieldNames = []string{
+	"second",
+	"minute",
+	"hour",
+	"day of month",
+	"month",
+	"day of week",
+	"year",
+}
+
+// rangeError is panicked if a value is not within the bounds of its field.
+type rangeError struct {
+	expr    string
+	value   uint
+	message string
+}
+
+// panicRange logs the message and panics with a rangeError, like log.Panicf.
+func panicRange(expr string, value uint, message string) {
+	log.Print(message)
+	panic(&rangeError{expr, value, message})
+}
+
+// newParseError returns the *ParseError for the value recovered while parsing
+// the spec.
+func newParseError(spec string, recovered interface{}) *ParseError {
+	if e, ok := recovered.(*ParseError); ok {
+		e.Spec = spec
+		return e
+	}
+	e := &ParseError{Spec: spec, Field: -1, Message: fmt.Sprint(recovered)}
+	if le, ok := recovered.(*LimitError); ok {
+		e.Limit = le
+	}
+	return e
+}
+
+// fieldError returns the *ParseError for the value recovered while parsing the
+// field at the given index of the full layout, which is at the given position
+// and offset in the spec.
+func fieldError(recovered interface{}, field string, index int, r bounds, position, offset int) *ParseError {
+	e := &ParseError{
+		Field:   position,
+		Name:    fieldNames[index],
+		Token:   field,
+		Offset:  offset,
+		Message: fmt.Sprint(recovered),
+	}
+	if re, ok := recovered.(*rangeError); ok {
+		e.Message = re.message
+		if i := strings.Index(field, re.expr); i >= 0 {
+			e.Token, e.Offset = re.expr, offset+i
+		}
+		e.Hint = fmt.Sprintf("%s %d out of range %d-%d", e.Name, re.value, r.min, r.max)
+		return e
+	}
+	if le, ok := recovered.(*LimitError); ok {
+		e.Limit = le
+		return e
+	}
+
+	e.Hint = fmt.Sprintf("%s must be within %d-%d", e.Name, r.min, r.max)
+	if len(r.names) > 0 {
+		e.Hint += " or one of " + strings.Join(boundNames(r), ", ")
+	}
+	return e
+}
+
+// boundNames returns the shortest name of each value of the bounds, e.g. "mon"
+// rather than "monday", ordered by their values.
+func boundNames(r bounds) []string {
+	var names []string
+	for v := r.min; v <= r.max; v++ {
+		var same []string
+		for name, n := range r.names {
+			if n == v {
+				same = append(same, name)
+			}
+		}
+		if len(same) == 0 {
+			continue
+		}
+		sort.Strings(same)
+		shortest := same[0]
+		for _, name := range same {
+			if len(name) < len(shortest) {
+				shortest = name
+			}
+		}
+		names = append(names, shortest)
+	}
+	return names
+}
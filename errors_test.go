@@ -0,0 +1,100 @@
+package cron
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseError(t *testing.T) {
+	limited := defaultParser.WithLimits(Limits{MaxLength: 24, MaxItems: 3, MaxStep: 30})
+	tests := []struct {
+		spec     string
+		parser   Parser
+		expected ParseError
+	}{
+		{"0 0 25 * * *", defaultParser, ParseError{
+			Field: 2, Name: "hour", Token: "25", Offset: 4,
+			Message: "End of range (25) above maximum (23): 25",
+			Hint:    "hour 25 out of range 0-23",
+		}},
+		{"0 0 1,8-30 * * *", defaultParser, ParseError{
+			Field: 2, Name: "hour", Token: "8-30", Offset: 6,
+			Message: "End of range (30) above maximum (23): 8-30",
+			Hint:    "hour 30 out of range 0-23",
+		}},
+		{"0 0 0 * * XYZ", defaultParser, ParseError{
+			Field: 5, Name: "day of week", Token: "XYZ", Offset: 10,
+			Message: `Failed to parse int from XYZ: strconv.Atoi: parsing "XYZ": invalid syntax`,
+			Hint:    "day of week must be within 0-6 or one of sun, mon, tue, wed, thu, fri, sat",
+		}},
+		{"0 0 0 1 1 * 1969", defaultParser, ParseError{
+			Field: 6, Name: "year", Token: "1969", Offset: 12,
+			Message: "Beginning of range (1969) below minimum (1970): 1969",
+			Hint:    "year 1969 out of range 1970-2099",
+		}},
+
+		// Positions count the fields as given in the spec.
+		{"*/5 * * * 8", standardParser, ParseError{
+			Field: 4, Name: "day of week", Token: "8", Offset: 10,
+			Message: "End of range (8) above maximum (6): 8",
+			Hint:    "day of week 8 out of range 0-6",
+		}},
+		{"CRON_TZ=UTC  0 60 * * * *", defaultParser, ParseError{
+			Field: 1, Name: "minute", Token: "60", Offset: 15,
+			Message: "End of range (60) above maximum (59): 60",
+			Hint:    "minute 60 out of range 0-59",
+		}},
+		{"0 0/5 * * * * @between 08:00-18:00", defaultParser, ParseError{}},
+		{"0 0/5 * 32 * * @between 08:00-18:00", defaultParser, ParseError{
+			Field: 3, Name: "day of month", Token: "32", Offset: 8,
+			Message: "End of range (32) above maximum (31): 32",
+			Hint:    "day of month 32 out of range 1-31",
+		}},
+
+		// Errors concerning the whole spec.
+		{"* * *", defaultParser, ParseError{
+			Field:   -1,
+			Message: "Expected 5 to 7 fields, found 3: * * *",
+		}},
+
+		// Limits of the parser.
+		{"0 0,15,30 */30 * * *", limited, ParseError{}},
+		{"0 0,15,30,45 * * * *", limited, ParseError{
+			Field: 1, Name: "minute", Token: "0,15,30,45", Offset: 2,
+			Message: "MaxItems exceeded: 4 above 3",
+			Limit:   &LimitError{Limit: "MaxItems", Value: 4, Max: 3},
+		}},
+		{"0 H/45 * * * *", limited, ParseError{
+			Field: 1, Name: "minute", Token: "H/45", Offset: 2,
+			Message: "MaxStep exceeded: 45 above 30",
+			Limit:   &LimitError{Limit: "MaxStep", Value: 45, Max: 30},
+		}},
+		{"CRON_TZ=Europe/Zurich 0 0 6 * * *", limited, ParseError{
+			Field:   -1,
+			Message: "MaxLength exceeded: 33 above 24",
+			Limit:   &LimitError{Limit: "MaxLength", Value: 33, Max: 24},
+		}},
+	}
+
+	for _, c := range tests {
+		_, err := c.parser.Parse(c.spec)
+		if c.expected.Message == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", c.spec, err)
+			}
+			continue
+		}
+		actual, ok := err.(*ParseError)
+		if !ok {
+			t.Errorf("%s: expected a *ParseError, got %#v", c.spec, err)
+			continue
+		}
+		c.expected.Spec = c.spec
+		if !reflect.DeepEqual(*actual, c.expected) {
+			t.Errorf("%s:\n(expected) %#v\n(actual)   %#v", c.spec, c.expected, *actual)
+		}
+		if actual.Field >= 0 && c.spec[actual.Offset:actual.Offset+len(actual.Token)] != actual.Token {
+			t.Errorf("%s: token %q not at offset %d", c.spec, actual.Token, actual.Offset)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package cron
+
+import (
+	"sync"
+	"time"
+)
+
+// EventType is the type of an Event.
+type EventType int
+
+const (
+	EntryAdded       EventType = iota // An entry has been added
+	EntryRemoved                      // An entry has been removed
+	RunScheduled                      // A run has been dispatched at its activation
+	RunStarted                        // A run has started
+	RunFinished                       // A run has completed, Err is its error
+	RunSkipped                        // An activation has been skipped
+	SchedulerStopped                  // The scheduler has stopped
+)
+
+var eventTypes = []string{"EntryAdded", "EntryRemoved", "RunScheduled", "RunStarted", "RunFinished", "RunSkipped", "SchedulerStopped"}
+
+// String returns the name of the event type, e.g. "RunStarted".
+func (t EventType) String() string {
+	if t < 0 || int(t) >= len(eventTypes) {
+		return "EventType(?)"
+	}
+	return eventTypes[t]
+}
+
+// Event is a change of the state of a Cron, sent to its subscribers.
+type Event struct {
+	Type EventType
+	Time time.Time // Time of the event by the clock of the Cron
+
+	// ID and Name identify the entry of the event, unless it concerns the
+	// whole scheduler.
+	ID   EntryID
+	Name string
+
+	Scheduled time.Time // Activation of a run
+	Err       error     // Error of a finished run, or ErrSkipped
+}
+
+// subscribers holds the channels of the subscribers of a Cron.
+type subscribers struct {
+	mu       sync.Mutex
+	channels map[chan Event]struct{}
+}
+
+// Subscribe returns a channel, which receives the events of the Cron, and a
+// func to unsubscribe, which closes the channel.  The channel buffers the given
+// number of events; events are dropped rather than blocking the Cron while it
+// is full, so it should be drained promptly.
+func (c *Cron) Subscribe(buffer int) (<-chan Event, func()) {
+	ch := make(chan Event, buffer)
+	c.subscribers.mu.Lock()
+	if c.subscribers.channels == nil {
+		c.subscribers.channels = make(map[chan Event]struct{})
+	}
+	c.subscribers.channels[ch] = struct{}{}
+	c.subscribers.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			c.subscribers.mu.Lock()
+			delete(c.subscribers.channels, ch)
+			c.subscribers.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// emit sends the event to the subscribers, setting its time.
+func (c *Cron) emit(event Event) {
+	c.subscribers.mu.Lock()
+	defer c.subscribers.mu.Unlock()
+	if len(c.subscribers.channels) == 0 {
+		return
+	}
+	event.Time = c.clock.Now()
+	for ch := range c.subscribers.channels {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// emitRun sends an event of the run to the subscribers.
+func (c *Cron) emitRun(t EventType, run RunInfo) {
+	c.emit(Event{Type: t, ID: run.Entry.ID, Name: run.Entry.Name, Scheduled: run.Scheduled, Err: run.Err})
+}
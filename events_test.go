@@ -0,0 +1,42 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSubscribe(t *testing.T) {
+	cron := New()
+	events, unsubscribe := cron.Subscribe(100)
+	id, _ := cron.AddNamedJob("job", "@reboot", FuncJob(func() {}))
+	paused, _ := cron.AddFunc("@reboot", func() {})
+	cron.Pause(paused)
+	cron.Start()
+	time.Sleep(10 * time.Millisecond)
+	cron.Remove(id)
+	<-cron.Stop().Done()
+	time.Sleep(10 * time.Millisecond)
+	unsubscribe()
+	unsubscribe()
+
+	types := map[EventType]int{}
+	for event := range events {
+		types[event.Type]++
+		if event.Type == RunFinished && (event.ID != id || event.Name != "job" || event.Scheduled.IsZero() || event.Err != nil) {
+			t.Errorf("unexpected event %+v", event)
+		}
+		if event.Time.IsZero() {
+			t.Errorf("expected the time of the event %+v", event)
+		}
+	}
+	expected := map[EventType]int{EntryAdded: 2, EntryRemoved: 1, RunScheduled: 1, RunStarted: 1, RunFinished: 1, RunSkipped: 1, SchedulerStopped: 1}
+	for typ, n := range expected {
+		if types[typ] != n {
+			t.Errorf("%v: (expected) %d events != %d (actual)", typ, n, types[typ])
+		}
+	}
+
+	if s := RunSkipped.String(); s != "RunSkipped" {
+		t.Errorf("(expected) RunSkipped != %s (actual)", s)
+	}
+}
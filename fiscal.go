@@ -0,0 +1,152 @@
+package cron
+
+import "time"
+
+// FiscalCalendar divides time into fiscal years, which consist of fiscal
+// periods, e.g. the months of a fiscal year starting in October, or the weeks
+// of a 4-4-5 calendar.
+type FiscalCalendar interface {
+	// FiscalYear returns the first days of the periods of the fiscal year
+	// containing the day of the given time, in order, and the first day of the
+	// following fiscal year.  The days are at midnight in the location of the
+	// given time.
+	FiscalYear(day time.Time) (periods []time.Time, next time.Time)
+}
+
+// FiscalSchedule activates at the start of fiscal periods, e.g. "the first day
+// of each fiscal quarter at 02:00".
+type FiscalSchedule struct {
+	Calendar FiscalCalendar
+
+	// Periods is the number of periods between activations, counted from the
+	// start of the fiscal year, e.g. 1 for every period and 3 for every
+	// quarter of a calendar with twelve periods.  With 0, the schedule only
+	// activates at the start of the fiscal year.
+	Periods int
+
+	Hour, Minute int
+
+	// Location is the time zone in which the schedule is evaluated.  A nil
+	// Location uses the location of the time passed to Next.
+	Location *time.Location
+}
+
+// EveryFiscalPeriod returns a Schedule that activates on the first day of
+// every n-th period of the fiscal calendar, at the given time of the day.
+func EveryFiscalPeriod(calendar FiscalCalendar, n, hour, minute int) *FiscalSchedule {
+	return &FiscalSchedule{
+		Calendar: calendar,
+		Periods:  n,
+		Hour:     hour,
+		Minute:   minute,
+	}
+}
+
+// Next returns the next activation after the given time.  If there is none
+// within five fiscal years, return the zero time.
+func (s *FiscalSchedule) Next(t time.Time) time.Time {
+	origLocation := t.Location()
+	if s.Location != nil {
+		t = t.In(s.Location)
+	}
+
+	day := t
+	for year := 0; year <= 5; year++ {
+		periods, next := s.Calendar.FiscalYear(day)
+		for i, start := range periods {
+			if s.Periods == 0 && i > 0 || s.Periods > 0 && i%s.Periods != 0 {
+				continue
+			}
+			activation := time.Date(start.Year(), start.Month(), start.Day(), s.Hour, s.Minute, 0, 0, t.Location())
+			if activation.After(t) {
+				return activation.In(origLocation)
+			}
+		}
+		if !next.After(day) {
+			break
+		}
+		day = next
+	}
+	return time.Time{}
+}
+
+// MonthlyFiscalCalendar is a FiscalCalendar whose periods are the calendar
+// months, and whose fiscal year starts on the first day of the Start month,
+// e.g. in October.
+type MonthlyFiscalCalendar struct {
+	Start time.Month
+}
+
+// FiscalYear returns the first days of the months of the fiscal year containing
+// the given day, and the first day of the following fiscal year.
+func (c MonthlyFiscalCalendar) FiscalYear(day time.Time) ([]time.Time, time.Time) {
+	start := c.Start
+	if start == 0 {
+		start = time.January
+	}
+	year := day.Year()
+	if day.Month() < start {
+		year--
+	}
+	periods := make([]time.Time, 12)
+	for i := range periods {
+		periods[i] = time.Date(year, start+time.Month(i), 1, 0, 0, 0, 0, day.Location())
+	}
+	return periods, time.Date(year+1, start, 1, 0, 0, 0, 0, day.Location())
+}
+
+// WeeklyFiscalCalendar is a FiscalCalendar of 52 or 53 weeks, whose fiscal year
+// ends on the last EndWeekday of the EndMonth, e.g. on the last Saturday of
+// September.  The periods consist of whole weeks, repeating the Pattern, e.g.
+// 4, 4 and 5 weeks.  The 53rd week of a year is added to its last period.
+type WeeklyFiscalCalendar struct {
+	EndMonth   time.Month
+	EndWeekday time.Weekday
+	Pattern    []int
+}
+
+// NewCalendar445 returns a 4-4-5 calendar, whose quarters consist of periods
+// of 4, 4 and 5 weeks, ending on the last given weekday of the given month.
+func NewCalendar445(endMonth time.Month, endWeekday time.Weekday) WeeklyFiscalCalendar {
+	return WeeklyFiscalCalendar{
+		EndMonth:   endMonth,
+		EndWeekday: endWeekday,
+		Pattern:    []int{4, 4, 5},
+	}
+}
+
+// FiscalYear returns the first days of the periods of the fiscal year
+// containing the given day, and the first day of the following fiscal year.
+func (c WeeklyFiscalCalendar) FiscalYear(day time.Time) ([]time.Time, time.Time) {
+	date := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	loc := day.Location()
+	year := day.Year()
+	for !c.end(year-1, loc).Before(date) {
+		year--
+	}
+	for c.end(year, loc).Before(date) {
+		year++
+	}
+	start, next := c.end(year-1, loc).AddDate(0, 0, 1), c.end(year, loc).AddDate(0, 0, 1)
+
+	pattern := c.Pattern
+	if len(pattern) == 0 {
+		pattern = []int{4, 4, 5}
+	}
+	var periods []time.Time
+	for i, weeks := 0, 0; weeks < 52; i++ {
+		periods = append(periods, start.AddDate(0, 0, 7*weeks))
+		if pattern[i%len(pattern)] <= 0 {
+			break
+		}
+		weeks += pattern[i%len(pattern)]
+	}
+	return periods, next
+}
+
+// end returns the last day of the fiscal year ending in the given calendar
+// year, at midnight in the given location.
+func (c WeeklyFiscalCalendar) end(year int, loc *time.Location) time.Time {
+	last := time.Date(year, c.EndMonth+1, 0, 0, 0, 0, 0, loc)
+	return last.AddDate(0, 0, -(int(last.Weekday())-int(c.EndWeekday)+7)%7)
+}
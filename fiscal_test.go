@@ -0,0 +1,63 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFiscalNext(t *testing.T) {
+	october := MonthlyFiscalCalendar{Start: time.October}
+	// The fiscal year 2012 of this calendar has 53 weeks, from Sunday,
+	// September 25th 2011 to Saturday, September 29th 2012.
+	retail := NewCalendar445(time.September, time.Saturday)
+
+	tests := []struct {
+		schedule       *FiscalSchedule
+		time, expected string
+	}{
+		// Calendar months
+		{EveryFiscalPeriod(october, 3, 2, 0), "Mon Jul 9 12:00 2012", "Mon Oct 1 02:00 2012"},
+		{EveryFiscalPeriod(october, 3, 2, 0), "Mon Oct 1 02:00 2012", "Tue Jan 1 02:00 2013"},
+		{EveryFiscalPeriod(october, 1, 2, 0), "Mon Oct 1 02:00 2012", "Thu Nov 1 02:00 2012"},
+		{EveryFiscalPeriod(october, 0, 2, 0), "Mon Oct 1 02:00 2012", "Tue Oct 1 02:00 2013"},
+		{EveryFiscalPeriod(MonthlyFiscalCalendar{}, 3, 2, 0), "Mon Jul 9 12:00 2012", "Mon Oct 1 02:00 2012"},
+		{EveryFiscalPeriod(MonthlyFiscalCalendar{Start: time.April}, 3, 2, 0), "Mon Feb 6 12:00 2012", "Sun Apr 1 02:00 2012"},
+
+		// 4-4-5 weeks
+		{EveryFiscalPeriod(retail, 3, 2, 0), "Mon Jul 9 12:00 2012", "Sun Sep 30 02:00 2012"},
+		{EveryFiscalPeriod(retail, 3, 2, 0), "Sun Sep 30 02:00 2012", "Sun Dec 30 02:00 2012"},
+		{EveryFiscalPeriod(retail, 1, 2, 0), "Sun Sep 30 02:00 2012", "Sun Oct 28 02:00 2012"},
+		{EveryFiscalPeriod(retail, 1, 2, 0), "Sun Oct 28 02:00 2012", "Sun Nov 25 02:00 2012"},
+		{EveryFiscalPeriod(retail, 1, 2, 0), "Sun Nov 25 02:00 2012", "Sun Dec 30 02:00 2012"},
+		{EveryFiscalPeriod(retail, 0, 2, 0), "Sun Sep 30 02:00 2012", "Sun Sep 29 02:00 2013"},
+
+		// The 53rd week is part of the last period.
+		{EveryFiscalPeriod(retail, 1, 2, 0), "Mon Aug 20 12:00 2012", "Sun Sep 30 02:00 2012"},
+	}
+
+	for _, c := range tests {
+		actual := c.schedule.Next(getTime(c.time))
+		expected := getTime(c.expected)
+		if !actual.Equal(expected) {
+			t.Errorf("%+v, %s: (expected) %v != %v (actual)", *c.schedule, c.time, expected, actual)
+		}
+	}
+}
+
+func TestFiscalYear(t *testing.T) {
+	retail := NewCalendar445(time.September, time.Saturday)
+	tests := []struct {
+		day          string
+		periods      int
+		start, first string
+	}{
+		{"Sat Sep 29 12:00 2012", 12, "Sun Sep 25 00:00 2011", "Sun Sep 30 00:00 2012"},
+		{"Sun Sep 30 00:00 2012", 12, "Sun Sep 30 00:00 2012", "Sun Sep 29 00:00 2013"},
+	}
+	for _, c := range tests {
+		periods, next := retail.FiscalYear(getTime(c.day))
+		if len(periods) != c.periods || !periods[0].Equal(getTime(c.start)) || !next.Equal(getTime(c.first)) {
+			t.Errorf("%s: (expected) %d periods from %s until %s != %v until %v (actual)", c.day, c.periods, c.start, c.first, periods, next)
+		}
+	}
+}
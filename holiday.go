@@ -0,0 +1,274 @@
+package cron
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+)
+
+// Calendar tells which days are holidays, e.g. the holidays of an exchange.
+type Calendar interface {
+	// IsHoliday returns true if the day of the given time, in its location, is
+	// a holiday.
+	IsHoliday(date time.Time) bool
+}
+
+// HolidayPolicy specifies how a HolidaySchedule treats activations on holidays.
+type HolidayPolicy int
+
+const (
+	SkipHolidays  HolidayPolicy = iota // Skip activations on holidays
+	ShiftHolidays                      // Shift activations on holidays to the following day which is not a holiday
+)
+
+// HolidaySchedule activates like its schedule, except on the holidays of its
+// calendar, where the activations are skipped or shifted according to the
+// policy.
+type HolidaySchedule struct {
+	Schedule Schedule
+	Calendar Calendar
+	Policy   HolidayPolicy
+}
+
+// WithCalendar returns a Schedule that activates like the given schedule, but
+// treats activations on the holidays of the calendar according to the policy.
+func WithCalendar(schedule Schedule, calendar Calendar, policy HolidayPolicy) HolidaySchedule {
+	return HolidaySchedule{
+		Schedule: schedule,
+		Calendar: calendar,
+		Policy:   policy,
+	}
+}
+
+// Next returns the next activation of the schedule which is not on a holiday.
+// With ShiftHolidays, an activation on a holiday is shifted to the same time
+// on the following day which is not a holiday, unless the schedule activates
+// earlier anyway.  If no activation is found within five years, return the
+// zero time.
+func (schedule HolidaySchedule) Next(t time.Time) time.Time {
+	var (
+		limit   = t.AddDate(5, 0, 0)
+		shifted time.Time
+	)
+	for next := schedule.Schedule.Next(t); !next.IsZero() && !next.After(limit); next = schedule.Schedule.Next(next) {
+		if !shifted.IsZero() && !next.Before(shifted) {
+			return shifted
+		}
+		if !schedule.Calendar.IsHoliday(next) {
+			return next
+		}
+		if schedule.Policy == ShiftHolidays && shifted.IsZero() {
+			shifted = schedule.following(next)
+		}
+	}
+	return shifted
+}
+
+// following returns the same time on the following day which is not a holiday,
+// or the zero time if there is none within a year.
+func (schedule HolidaySchedule) following(t time.Time) time.Time {
+	for i := 1; i <= 366; i++ {
+		day := t.AddDate(0, 0, i)
+		if !schedule.Calendar.IsHoliday(day) {
+			return day
+		}
+	}
+	return time.Time{}
+}
+
+// date is a day of the calendar, independent of a location.
+type date struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+func dateOf(t time.Time) date {
+	return date{t.Year(), t.Month(), t.Day()}
+}
+
+// before returns true if the date is before the other date.
+func (d date) before(other date) bool {
+	return d.time().Before(other.time())
+}
+
+// next returns the day after the date.
+func (d date) next() date {
+	return dateOf(d.time().AddDate(0, 0, 1))
+}
+
+func (d date) time() time.Time {
+	return time.Date(d.year, d.month, d.day, 0, 0, 0, 0, time.UTC)
+}
+
+// StaticCalendar is a Calendar with a fixed list of holidays.
+type StaticCalendar struct {
+	days map[date]bool
+}
+
+// NewStaticCalendar returns a Calendar with the days of the given times as
+// holidays.
+func NewStaticCalendar(holidays ...time.Time) *StaticCalendar {
+	c := &StaticCalendar{days: make(map[date]bool)}
+	for _, h := range holidays {
+		c.Add(h)
+	}
+	return c
+}
+
+// Add adds the day of the given time as a holiday.
+func (c *StaticCalendar) Add(holiday time.Time) {
+	c.days[dateOf(holiday)] = true
+}
+
+// IsHoliday returns true if the day of the given time is one of the holidays.
+func (c *StaticCalendar) IsHoliday(t time.Time) bool {
+	return c.days[dateOf(t)]
+}
+
+// icsCalendar is a Calendar of the events of an iCalendar file.
+type icsCalendar struct {
+	days  *StaticCalendar
+	rules []Schedule
+}
+
+// ParseICS returns a Calendar with the days of the events of the iCalendar
+// (RFC 5545) data read from r as holidays, e.g. a feed of public holidays.
+// Each event covers the days from its DTSTART to its DTEND, which is exclusive
+// for all-day events.  Events recurring by an RRULE are holidays on each day
+// of their recurrence.
+// It returns a descriptive error if the data is not valid.
+func ParseICS(r io.Reader) (_ Calendar, err error) {
+	// Convert panics into errors
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("%v", recovered)
+		}
+	}()
+
+	lines, err := unfoldLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &icsCalendar{days: NewStaticCalendar()}
+	var (
+		inEvent    bool
+		start, end time.Time
+		allDay     bool
+		rule       string
+	)
+	for _, line := range lines {
+		name, params, value := splitProperty(line)
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			inEvent, start, end, allDay, rule = true, time.Time{}, time.Time{}, false, ""
+		case name == "END" && value == "VEVENT":
+			if start.IsZero() {
+				log.Panicf("Event without DTSTART")
+			}
+			c.addEvent(start, end, allDay, rule)
+			inEvent = false
+		case !inEvent:
+		case name == "DTSTART":
+			start = parseICSDate(value)
+			allDay = len(value) == len("20060102") || strings.Contains(params, "VALUE=DATE")
+		case name == "DTEND":
+			end = parseICSDate(value)
+		case name == "RRULE":
+			rule = value
+		}
+	}
+	return c, nil
+}
+
+// addEvent adds the days of the event as holidays.
+func (c *icsCalendar) addEvent(start, end time.Time, allDay bool, rule string) {
+	if rule != "" {
+		schedule, err := ParseRRule(rule, start)
+		if err != nil {
+			log.Panicf("%s", err)
+		}
+		c.rules = append(c.rules, schedule)
+		return
+	}
+
+	last := start
+	if !end.IsZero() {
+		last = end
+		// The end of an all-day event, or of an event ending at midnight, is
+		// on the day after the event.
+		if allDay || last.Equal(time.Date(last.Year(), last.Month(), last.Day(), 0, 0, 0, 0, last.Location())) {
+			last = last.AddDate(0, 0, -1)
+		}
+	}
+	for day := dateOf(start); !dateOf(last).before(day); day = day.next() {
+		c.days.days[day] = true
+	}
+}
+
+// IsHoliday returns true if an event covers the day of the given time.
+func (c *icsCalendar) IsHoliday(t time.Time) bool {
+	if c.days.IsHoliday(t) {
+		return true
+	}
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	for _, rule := range c.rules {
+		if next := rule.Next(day.Add(-1 * time.Nanosecond)); !next.IsZero() && dateOf(next) == dateOf(day) {
+			return true
+		}
+	}
+	return false
+}
+
+// unfoldLines returns the lines read from r, with folded lines (continued on
+// a line starting with a space or tab) joined.
+func unfoldLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// splitProperty splits a content line "NAME;PARAMS:VALUE" into its parts.
+func splitProperty(line string) (name, params, value string) {
+	i := strings.Index(line, ":")
+	if i < 0 {
+		return strings.ToUpper(line), "", ""
+	}
+	name, value = line[:i], line[i+1:]
+	if j := strings.Index(name, ";"); j >= 0 {
+		name, params = name[:j], name[j+1:]
+	}
+	return strings.ToUpper(name), strings.ToUpper(params), value
+}
+
+// parseICSDate returns the date or date-time value as a time in UTC.  Only the
+// date of a value with a time zone matters for the holidays.
+func parseICSDate(value string) time.Time {
+	if len(value) < len("20060102") {
+		log.Panicf("Failed to parse date: %s", value)
+	}
+	if len(value) >= len("20060102T150405") {
+		t, err := time.Parse("20060102T150405", value[:len("20060102T150405")])
+		if err != nil {
+			log.Panicf("Failed to parse date %s: %s", value, err)
+		}
+		return t
+	}
+	t, err := time.Parse("20060102", value)
+	if err != nil {
+		log.Panicf("Failed to parse date %s: %s", value, err)
+	}
+	return t
+}
@@ -0,0 +1,124 @@
+package cron
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHolidayNext(t *testing.T) {
+	daily, _ := Parse("0 0 18 * * *")
+	holidays := NewStaticCalendar(
+		getTime("Wed Jul 4 00:00 2012"),
+		getTime("Mon Dec 24 00:00 2012"),
+		getTime("Tue Dec 25 00:00 2012"),
+	)
+
+	tests := []struct {
+		policy         HolidayPolicy
+		time, expected string
+	}{
+		{SkipHolidays, "Tue Jul 3 12:00 2012", "Tue Jul 3 18:00 2012"},
+		{SkipHolidays, "Tue Jul 3 18:00 2012", "Thu Jul 5 18:00 2012"},
+		{SkipHolidays, "Sun Dec 23 18:00 2012", "Wed Dec 26 18:00 2012"},
+
+		// Shifted activations coincide with the regular ones of the next day.
+		{ShiftHolidays, "Tue Jul 3 18:00 2012", "Thu Jul 5 18:00 2012"},
+	}
+	for _, c := range tests {
+		actual := WithCalendar(daily, holidays, c.policy).Next(getTime(c.time))
+		expected := getTime(c.expected)
+		if !actual.Equal(expected) {
+			t.Errorf("%d, %s: (expected) %v != %v (actual)", c.policy, c.time, expected, actual)
+		}
+	}
+
+	// A monthly settlement on a holiday is shifted to the following day.
+	monthly, _ := Parse("0 0 6 24 * *")
+	settlement := WithCalendar(monthly, holidays, ShiftHolidays)
+	shifts := []struct{ time, expected string }{
+		{"Fri Nov 30 12:00 2012", "Wed Dec 26 06:00 2012"},
+		{"Wed Dec 26 06:00 2012", "Thu Jan 24 06:00 2013"},
+	}
+	for _, c := range shifts {
+		actual := settlement.Next(getTime(c.time))
+		expected := getTime(c.expected)
+		if !actual.Equal(expected) {
+			t.Errorf("%s: (expected) %v != %v (actual)", c.time, expected, actual)
+		}
+	}
+	if actual := WithCalendar(monthly, holidays, SkipHolidays).Next(getTime("Fri Nov 30 12:00 2012")); !actual.Equal(getTime("Thu Jan 24 06:00 2013")) {
+		t.Errorf("expected the holiday to be skipped, got %v", actual)
+	}
+}
+
+const testICS = `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//Example//Exchange Holidays//EN
+BEGIN:VEVENT
+UID:1
+DTSTART;VALUE=DATE:20120704
+DTEND;VALUE=DATE:20120705
+SUMMARY:Independence Day
+END:VEVENT
+BEGIN:VEVENT
+UID:2
+DTSTART;VALUE=DATE:20121224
+DTEND;VALUE=DATE:20121226
+SUMMARY:Christmas
+  Holidays
+END:VEVENT
+BEGIN:VEVENT
+UID:3
+DTSTART;VALUE=DATE:20100101
+RRULE:FREQ=YEARLY
+SUMMARY:New Year
+END:VEVENT
+BEGIN:VEVENT
+UID:4
+DTSTART:20121105T090000Z
+DTEND:20121106T000000Z
+SUMMARY:Early close
+END:VEVENT
+END:VCALENDAR
+`
+
+func TestParseICS(t *testing.T) {
+	calendar, err := ParseICS(strings.NewReader(strings.Replace(testICS, "\n", "\r\n", -1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		date     string
+		expected bool
+	}{
+		{"Tue Jul 3 12:00 2012", false},
+		{"Wed Jul 4 12:00 2012", true},
+		{"Thu Jul 5 12:00 2012", false},
+		{"Mon Dec 24 12:00 2012", true},
+		{"Tue Dec 25 23:59 2012", true},
+		{"Wed Dec 26 00:00 2012", false},
+		{"Tue Jan 1 00:00 2013", true},
+		{"Sun Jan 1 18:00 2017", true},
+		{"Mon Jan 2 00:00 2017", false},
+		{"Mon Nov 5 00:00 2012", true},
+		{"Tue Nov 6 00:00 2012", false},
+	}
+	for _, c := range tests {
+		if actual := calendar.IsHoliday(getTime(c.date)); actual != c.expected {
+			t.Errorf("%s: (expected) %v != %v (actual)", c.date, c.expected, actual)
+		}
+	}
+
+	invalid := []string{
+		"BEGIN:VEVENT\nSUMMARY:No start\nEND:VEVENT\n",
+		"BEGIN:VEVENT\nDTSTART:2012\nEND:VEVENT\n",
+		"BEGIN:VEVENT\nDTSTART:20121301\nEND:VEVENT\n",
+		"BEGIN:VEVENT\nDTSTART:20120101\nRRULE:FREQ=SOMETIMES\nEND:VEVENT\n",
+	}
+	for _, ics := range invalid {
+		if _, err := ParseICS(strings.NewReader(ics)); err == nil {
+			t.Errorf("expected an error parsing %q", ics)
+		}
+	}
+}
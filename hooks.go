@@ -0,0 +1,58 @@
+package cron
+
+import "time"
+
+// RunInfo describes a completed run of a job.
+type RunInfo struct {
+	Entry     *Entry        // Copy of the entry, without its statistics
+	Scheduled time.Time     // Activation of the run
+	Start     time.Time     // Start of the run
+	Duration  time.Duration // Duration of the run
+	Err       error         // Error of an ErrorJob, or a *PanicError
+}
+
+// ErrorHandler handles a failed run of a job, e.g. to report its error.
+type ErrorHandler func(run RunInfo)
+
+// RunHook is called around the runs of the jobs, e.g. for audit logging.
+type RunHook func(run RunInfo)
+
+// OnError sets the handler of the failed runs of the entry with the given ID,
+// which is called in addition to the one of WithErrorHandler.  It returns an
+// error if there is no such entry.
+func (c *Cron) OnError(id EntryID, handler ErrorHandler) error {
+	return c.updateEntry(id, func(e *Entry, running bool) {
+		e.onError = handler
+	})
+}
+
+// before calls the hook before the run, whose Duration and Err are not set.
+func (c *Cron) before(run RunInfo) {
+	c.emitRun(RunStarted, run)
+	if c.beforeRun != nil {
+		c.beforeRun(run)
+	}
+}
+
+// finished handles the completed run, calling the hook after the run, and the
+// error handlers if it failed.
+func (c *Cron) finished(run RunInfo) {
+	if run.Err == ErrSkipped {
+		c.emitRun(RunSkipped, run)
+	} else {
+		c.emitRun(RunFinished, run)
+	}
+	c.observeRun(run)
+	if c.afterRun != nil {
+		c.afterRun(run)
+	}
+	if run.Err == nil || run.Err == ErrSkipped {
+		return
+	}
+	if c.errorHandler != nil {
+		c.errorHandler(run)
+	}
+	if run.Entry.onError != nil {
+		run.Entry.onError(run)
+	}
+}
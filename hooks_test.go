@@ -0,0 +1,97 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// Failed runs are passed to the handler of the Cron and the one of the entry.
+func TestErrorHandlers(t *testing.T) {
+	var global, entry []RunInfo
+	failed := errors.New("failed")
+
+	cron := New(
+		WithErrorHandler(func(run RunInfo) { global = append(global, run) }),
+		WithPanicHandler(func(e *Entry, err *PanicError) {}))
+	id, _ := cron.AddNamedJob("sync", "@hourly", ErrorFuncJob(func(ctx context.Context) error { return failed }))
+	cron.AddFunc("@hourly", func() {})
+	cron.AddFunc("0 0 1 * * *", func() { panic("broken") })
+	if err := cron.OnError(id, func(run RunInfo) { entry = append(entry, run) }); err != nil {
+		t.Fatal(err)
+	}
+	if err := cron.OnError(id+3, func(run RunInfo) {}); err == nil {
+		t.Error("expected an error for an unknown ID")
+	}
+
+	from := time.Date(2016, time.March, 1, 0, 30, 0, 0, time.UTC)
+	if _, err := cron.Simulate(from, from.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entry) != 1 || entry[0].Err != failed || entry[0].Entry.Name != "sync" || !entry[0].Scheduled.Equal(from.Add(30*time.Minute)) {
+		t.Errorf("unexpected failed runs of the entry %+v", entry)
+	}
+	if len(global) != 2 {
+		t.Fatalf("(expected) 2 failed runs != %+v (actual)", global)
+	}
+	for _, run := range global {
+		if _, ok := run.Err.(*PanicError); !ok && run.Err != failed {
+			t.Errorf("unexpected error %v", run.Err)
+		}
+	}
+}
+
+// Failed runs of a running Cron include their timing.
+func TestErrorHandlerTiming(t *testing.T) {
+	handled := make(chan RunInfo, 1)
+
+	cron := New(WithErrorHandler(func(run RunInfo) { handled <- run }))
+	cron.AddJob("* * * * * ?", ErrorFuncJob(func(ctx context.Context) error {
+		time.Sleep(10 * time.Millisecond)
+		return errors.New("failed")
+	}))
+	cron.Start()
+	defer cron.Stop()
+
+	select {
+	case run := <-handled:
+		if run.Duration < 10*time.Millisecond || run.Start.Before(run.Scheduled) {
+			t.Errorf("unexpected timing %+v", run)
+		}
+	case <-time.After(2 * ONE_SECOND):
+		t.Fatal("expected the failed run to be handled")
+	}
+}
+
+// The hooks are called around all runs, in order.
+func TestRunHooks(t *testing.T) {
+	var trace []string
+	failed := errors.New("failed")
+	cron := New(
+		WithBeforeRun(func(run RunInfo) {
+			trace = append(trace, "before "+run.Entry.Name)
+			if run.Err != nil || run.Start.IsZero() {
+				t.Errorf("unexpected run before it started %+v", run)
+			}
+		}),
+		WithAfterRun(func(run RunInfo) { trace = append(trace, "after "+run.Entry.Name) }),
+		WithErrorHandler(func(run RunInfo) { trace = append(trace, "error "+run.Entry.Name) }))
+	cron.AddNamedJob("ok", "0 0 1 * * *", FuncJob(func() { trace = append(trace, "run ok") }))
+	cron.AddNamedJob("failed", "0 0 2 * * *", ErrorFuncJob(func(ctx context.Context) error { return failed }))
+
+	from := time.Date(2016, time.March, 1, 0, 0, 0, 0, time.UTC)
+	cron.Simulate(from, from.Add(2*time.Hour))
+
+	expected := []string{"before ok", "run ok", "after ok", "before failed", "after failed", "error failed"}
+	if len(trace) != len(expected) {
+		t.Fatalf("(expected) %v != %v (actual)", expected, trace)
+	}
+	for i := range expected {
+		if trace[i] != expected[i] {
+			t.Errorf("(expected) %v != %v (actual)", expected, trace)
+			break
+		}
+	}
+}
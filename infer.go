@@ -0,0 +1,80 @@
+package cron
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// InferSpec returns the tightest spec, which activates at exactly the given
+// times between the first and the last of them, e.g. "0 */15 9-17 * * 1-5" for
+// the activations of a legacy scheduler.  Each field lists the values of the
+// times, unless every value is just as exact, e.g. "*" rather than the only
+// month of times within a few days.  The fields of the spec are the ones of
+// Parse, and the schedule is evaluated in the location of the first time.
+// It returns an error if there are fewer than two times, or if no spec
+// activates at exactly the given times.
+func InferSpec(times []time.Time) (string, error) {
+	if len(times) < 2 {
+		return "", fmt.Errorf("Expected at least two times, found %d", len(times))
+	}
+
+	var (
+		loc    = times[0].Location()
+		sorted = make([]time.Time, 0, len(times))
+		s      SpecSchedule
+	)
+	for _, t := range times {
+		if t.Nanosecond() != 0 {
+			return "", fmt.Errorf("Time with a fraction of a second can not be matched: %v", t)
+		}
+		t = t.In(loc)
+		sorted = append(sorted, t)
+		s.Second |= 1 << uint(t.Second())
+		s.Minute |= 1 << uint(t.Minute())
+		s.Hour |= 1 << uint(t.Hour())
+		s.Dom |= 1 << uint(t.Day())
+		s.Month |= 1 << uint(t.Month())
+		s.Dow |= 1 << uint(t.Weekday())
+	}
+	sort.Sort(byInstant(sorted))
+
+	// The days are restricted by at most one of the day fields: the days of
+	// the week are tried before the days of the month, since they generalize
+	// beyond the given times more often.
+	for _, days := range [][2]uint64{{all(dom), all(dow)}, {all(dom), s.Dow}, {s.Dom, all(dow)}} {
+		candidate := s
+		candidate.Dom, candidate.Dow = days[0], days[1]
+		if !activatesAt(&candidate, sorted) {
+			continue
+		}
+		for _, field := range []struct {
+			bits *uint64
+			r    bounds
+		}{{&candidate.Month, months}, {&candidate.Hour, hours}, {&candidate.Minute, minutes}, {&candidate.Second, seconds}} {
+			bits := *field.bits
+			if *field.bits = all(field.r); !activatesAt(&candidate, sorted) {
+				*field.bits = bits
+			}
+		}
+		text, err := canonical(&candidate).MarshalText()
+		return string(text), err
+	}
+	return "", fmt.Errorf("No spec activates at exactly the %d times", len(times))
+}
+
+// activatesAt returns true if the schedule activates at exactly the sorted
+// times between the first and the last of them.
+func activatesAt(s *SpecSchedule, sorted []time.Time) bool {
+	next := s.Next(sorted[0].Add(-time.Second))
+	for i, t := range sorted {
+		if i > 0 && t.Equal(sorted[i-1]) {
+			continue
+		}
+		if !next.Equal(t) {
+			return false
+		}
+		next = s.Next(t)
+	}
+	return true
+}
@@ -0,0 +1,51 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInferSpec(t *testing.T) {
+	tests := []struct {
+		spec, from string
+		n          int
+		expected   string
+	}{
+		{"0 30 6 * * *", "Mon Jul 9 00:00 2012", 10, "0 30 6 * * *"},
+		{"0 */15 9-17 * * MON-FRI", "Mon Jul 9 00:00 2012", 200, "0 */15 9-17 * * 1-5"},
+		{"0 0 12 1 * *", "Mon Jul 9 00:00 2012", 6, "0 0 12 1 * *"},
+		{"0 0 0 * * SUN", "Mon Jul 9 00:00 2012", 4, "0 0 0 * * 0"},
+		{"0 0 8,20 * * *", "Mon Jul 9 00:00 2012", 5, "0 0 8,20 * * *"},
+	}
+	for _, c := range tests {
+		sched, _ := Parse(c.spec)
+		times := Occurrences(sched, getTime(c.from), c.n)
+		actual, err := InferSpec(times)
+		if err != nil {
+			t.Errorf("%s: %v", c.spec, err)
+			continue
+		}
+		if actual != c.expected {
+			t.Errorf("%s: (expected) %q != %q (actual)", c.spec, c.expected, actual)
+		}
+	}
+
+	// The order of the times does not matter.
+	reversed := []time.Time{getTime("Wed Jul 11 06:30 2012"), getTime("Tue Jul 10 06:30 2012"), getTime("Mon Jul 9 06:30 2012")}
+	if actual, err := InferSpec(reversed); err != nil || actual != "0 30 6 * * *" {
+		t.Errorf("(expected) \"0 30 6 * * *\" != %q, %v (actual)", actual, err)
+	}
+
+	invalid := [][]time.Time{
+		nil,
+		{getTime("Mon Jul 9 06:30 2012")},
+		// A spec matching both times would activate in between as well.
+		{getTime("Mon Jul 9 06:30 2012"), getTime("Mon Jul 9 07:45 2012")},
+		{getTime("Mon Jul 9 06:30 2012"), getTime("Mon Jul 9 06:30:00.5 2012")},
+	}
+	for _, times := range invalid {
+		if actual, err := InferSpec(times); err == nil {
+			t.Errorf("%v: expected an error, got %q", times, actual)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package cron
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Period is a calendar-aware duration, such as the ISO 8601 duration "P1M6DT12H".
+// The years, months and days are added to the calendar date, the Duration is
+// added as elapsed time.
+type Period struct {
+	Years, Months, Days int
+	Duration            time.Duration
+}
+
+// approx returns the approximate elapsed time of the period.
+func (p Period) approx() time.Duration {
+	const day = 24 * time.Hour
+	return time.Duration(p.Years)*365*day + time.Duration(p.Years)*day/4 +
+		time.Duration(p.Months)*30*day + time.Duration(p.Months)*day*7/16 +
+		time.Duration(p.Days)*day + p.Duration
+}
+
+// addTo returns the time advanced by n periods.  Adding years and months keeps
+// the day of the month, but not beyond the end of the month, so that a month
+// after January 31st is the last day of February.
+func (p Period) addTo(t time.Time, n int) time.Time {
+	year, month := t.Year()+n*p.Years, t.Month()+time.Month(n*p.Months)
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	day := t.Day()
+	if last := daysIn(first.Month(), first.Year()); day > last {
+		day = last
+	}
+	t = time.Date(first.Year(), first.Month(), day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	return t.AddDate(0, 0, n*p.Days).Add(time.Duration(n) * p.Duration)
+}
+
+// IntervalSchedule represents an ISO 8601 repeating interval, e.g.
+// "R5/2025-01-01T00:00:00Z/PT6H", or a calendar interval, e.g. "@every 1mo".
+// It activates at Start and then after every Period, for a total of Count
+// activations.
+type IntervalSchedule struct {
+	Start  time.Time
+	Period Period
+
+	// Count is the number of activations.  A negative Count repeats forever.
+	Count int
+}
+
+// Next returns the first activation of the interval later than the given time,
+// or the zero time if all activations have passed.
+func (schedule IntervalSchedule) Next(t time.Time) time.Time {
+	// Estimate the number of passed activations and correct the estimate.
+	var n int
+	if elapsed := t.Sub(schedule.Start); elapsed > 0 {
+		n = int(elapsed/schedule.Period.approx()) - 1
+		if n < 0 {
+			n = 0
+		}
+	}
+	for n > 0 && schedule.Period.addTo(schedule.Start, n).After(t) {
+		n--
+	}
+	for !schedule.Period.addTo(schedule.Start, n).After(t) {
+		n++
+	}
+
+	if schedule.Count >= 0 && n >= schedule.Count {
+		return time.Time{}
+	}
+	return schedule.Period.addTo(schedule.Start, n)
+}
+
+// ParseRepeatingInterval returns a new schedule representing the given ISO 8601
+// repeating interval, in the form "R<count>/<start>/<period>".
+// It returns a descriptive error if the interval is not valid.
+//
+// It accepts
+//   - Limited repetitions, e.g. "R5/2025-01-01T00:00:00Z/PT6H"
+//   - Unlimited repetitions, e.g. "R/2025-01-01T00:00:00Z/P1M"
+func ParseRepeatingInterval(spec string) (_ Schedule, err error) {
+	// Convert panics into errors
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("%v", recovered)
+		}
+	}()
+
+	parts := strings.Split(spec, "/")
+	if len(parts) != 3 || !strings.HasPrefix(parts[0], "R") {
+		log.Panicf("Expected R<count>/<start>/<period>: %s", spec)
+	}
+
+	count := -1
+	if parts[0] != "R" {
+		count = int(mustParseInt(parts[0][1:]))
+	}
+	start, err := time.Parse(time.RFC3339, parts[1])
+	if err != nil {
+		log.Panicf("Failed to parse start %s: %s", spec, err)
+	}
+	period := parsePeriod(parts[2])
+	if period.approx() <= 0 {
+		log.Panicf("Period must be positive: %s", spec)
+	}
+
+	return IntervalSchedule{
+		Start:  start,
+		Period: period,
+		Count:  count,
+	}, nil
+}
+
+// parsePeriod returns the period of the given ISO 8601 duration,
+// e.g. "P1Y2M3W4DT5H6M7.5S".  It panics if the duration is not valid.
+func parsePeriod(expr string) Period {
+	if !strings.HasPrefix(expr, "P") || len(expr) == 1 {
+		log.Panicf("Failed to parse period: %s", expr)
+	}
+
+	var (
+		p       Period
+		inTime  bool
+		number  string
+		numbers int
+	)
+	for _, r := range expr[1:] {
+		switch {
+		case r >= '0' && r <= '9' || r == '.':
+			number += string(r)
+			continue
+		case r == 'T' && !inTime && number == "":
+			inTime = true
+			continue
+		case number == "":
+			log.Panicf("Missing number in period: %s", expr)
+		}
+
+		value, err := strconv.ParseFloat(number, 64)
+		if err != nil {
+			log.Panicf("Failed to parse period %s: %s", expr, err)
+		}
+		if !inTime && r != 'S' && strings.Contains(number, ".") {
+			log.Panicf("Fractions are only supported in the time of a period: %s", expr)
+		}
+		switch {
+		case !inTime && r == 'Y':
+			p.Years += int(value)
+		case !inTime && r == 'M':
+			p.Months += int(value)
+		case !inTime && r == 'W':
+			p.Days += 7 * int(value)
+		case !inTime && r == 'D':
+			p.Days += int(value)
+		case inTime && r == 'H':
+			p.Duration += time.Duration(value * float64(time.Hour))
+		case inTime && r == 'M':
+			p.Duration += time.Duration(value * float64(time.Minute))
+		case inTime && r == 'S':
+			p.Duration += time.Duration(value * float64(time.Second))
+		default:
+			log.Panicf("Unexpected designator %c in period: %s", r, expr)
+		}
+		number = ""
+		numbers++
+	}
+	if number != "" || numbers == 0 {
+		log.Panicf("Failed to parse period: %s", expr)
+	}
+	return p
+}
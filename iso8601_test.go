@@ -0,0 +1,94 @@
+package cron
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParsePeriod(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected Period
+	}{
+		{"PT6H", Period{Duration: 6 * time.Hour}},
+		{"PT1.5S", Period{Duration: 1500 * time.Millisecond}},
+		{"P1M", Period{Months: 1}},
+		{"P2W", Period{Days: 14}},
+		{"P1Y2M3W4DT5H6M7S", Period{Years: 1, Months: 2, Days: 25, Duration: 5*time.Hour + 6*time.Minute + 7*time.Second}},
+	}
+
+	for _, c := range tests {
+		actual := parsePeriod(c.expr)
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s => (expected) %v != %v (actual)", c.expr, c.expected, actual)
+		}
+	}
+}
+
+func TestIntervalNext(t *testing.T) {
+	tests := []struct {
+		time, spec string
+		expected   string
+	}{
+		{"2024-12-31T12:00:00Z", "R5/2025-01-01T00:00:00Z/PT6H", "2025-01-01T00:00:00Z"},
+		{"2025-01-01T00:00:00Z", "R5/2025-01-01T00:00:00Z/PT6H", "2025-01-01T06:00:00Z"},
+		{"2025-01-01T23:59:59Z", "R5/2025-01-01T00:00:00Z/PT6H", "2025-01-02T00:00:00Z"},
+		{"2025-01-02T00:00:00Z", "R5/2025-01-01T00:00:00Z/PT6H", ""},
+		{"2025-01-02T00:00:00Z", "R0/2025-01-01T00:00:00Z/PT6H", ""},
+
+		// Unlimited repetitions.
+		{"2030-06-15T01:00:00Z", "R/2025-01-01T00:00:00Z/PT6H", "2030-06-15T06:00:00Z"},
+
+		// Calendar-aware periods.
+		{"2025-01-31T00:00:00Z", "R/2025-01-31T00:00:00Z/P1M", "2025-02-28T00:00:00Z"},
+		{"2025-02-28T00:00:00Z", "R/2025-01-31T00:00:00Z/P1M", "2025-03-31T00:00:00Z"},
+		{"2025-03-31T00:00:00Z", "R/2025-01-31T00:00:00Z/P1M", "2025-04-30T00:00:00Z"},
+		{"2028-01-31T00:00:00Z", "R/2025-01-31T00:00:00Z/P1M", "2028-02-29T00:00:00Z"},
+		{"2025-01-31T00:00:00Z", "R/2025-01-31T00:00:00Z/P1M1D", "2025-03-01T00:00:00Z"},
+		{"2025-01-01T00:00:00Z", "R/2024-02-29T00:00:00Z/P1Y", "2025-02-28T00:00:00Z"},
+		{"2027-05-01T00:00:00Z", "R/2024-02-29T00:00:00Z/P1Y", "2028-02-29T00:00:00Z"},
+		{"2027-05-01T00:00:00Z", "R/2025-01-01T00:00:00Z/P1Y", "2028-01-01T00:00:00Z"},
+		{"2025-01-01T00:00:00Z", "R3/2025-01-01T00:00:00Z/P1DT12H", "2025-01-02T12:00:00Z"},
+		{"2025-01-04T00:00:00Z", "R3/2025-01-01T00:00:00Z/P1DT12H", ""},
+	}
+
+	for _, c := range tests {
+		sched, err := ParseRepeatingInterval(c.spec)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		from, _ := time.Parse(time.RFC3339, c.time)
+		var expected time.Time
+		if c.expected != "" {
+			expected, _ = time.Parse(time.RFC3339, c.expected)
+		}
+		actual := sched.Next(from)
+		if !actual.Equal(expected) {
+			t.Errorf("%s, \"%s\": (expected) %v != %v (actual)", c.time, c.spec, expected, actual)
+		}
+	}
+}
+
+func TestParseRepeatingIntervalErrors(t *testing.T) {
+	invalidSpecs := []string{
+		"",
+		"R5/2025-01-01T00:00:00Z",
+		"5/2025-01-01T00:00:00Z/PT6H",
+		"R-1/2025-01-01T00:00:00Z/PT6H",
+		"R5/2025-01-01/PT6H",
+		"R5/2025-01-01T00:00:00Z/6H",
+		"R5/2025-01-01T00:00:00Z/P",
+		"R5/2025-01-01T00:00:00Z/PT",
+		"R5/2025-01-01T00:00:00Z/P6H",
+		"R5/2025-01-01T00:00:00Z/PT0S",
+		"R5/2025-01-01T00:00:00Z/P1.5D",
+		"R5/2025-01-01T00:00:00Z/PT6",
+	}
+	for _, spec := range invalidSpecs {
+		if _, err := ParseRepeatingInterval(spec); err == nil {
+			t.Error("expected an error parsing: ", spec)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package cron
+
+import (
+	"sync"
+	"time"
+)
+
+// LimitSchedule activates like its schedule, but only for its first N
+// activations after the Start.
+//
+// The activations are counted from the Start, not by the calls to Next, so
+// that calling Next again, e.g. when the Cron is restarted or when another
+// schedule wraps it, does not use them up.  Activations which are missed, e.g.
+// while the Cron is stopped, count as well.
+type LimitSchedule struct {
+	Schedule Schedule
+	N        int
+	Start    time.Time
+
+	once sync.Once
+	last time.Time // the N-th activation after the Start
+}
+
+// Limit returns a Schedule that activates like the given schedule for its
+// first n activations from now on, and never again afterwards.
+func Limit(schedule Schedule, n int) *LimitSchedule {
+	return LimitClock(schedule, n, SystemClock)
+}
+
+// LimitClock returns the schedule of Limit, whose activations are counted from
+// the current time of the clock, e.g. a fake clock in tests.
+func LimitClock(schedule Schedule, n int, clock Clock) *LimitSchedule {
+	return &LimitSchedule{Schedule: schedule, N: n, Start: clock.Now()}
+}
+
+// Next returns the next activation of the schedule, or the zero time if it is
+// after the first N activations.
+func (s *LimitSchedule) Next(t time.Time) time.Time {
+	last := s.Last()
+	if last.IsZero() {
+		return time.Time{}
+	}
+	if t.Before(s.Start) {
+		t = s.Start
+	}
+	next := s.Schedule.Next(t)
+	if next.After(last) {
+		return time.Time{}
+	}
+	return next
+}
+
+// Last returns the last activation of the schedule, or the zero time if there
+// is none.
+func (s *LimitSchedule) Last() time.Time {
+	s.once.Do(func() {
+		for i, t := 0, s.Start; i < s.N; i++ {
+			if t = s.Schedule.Next(t); t.IsZero() {
+				break
+			}
+			s.last = t
+		}
+	})
+	return s.last
+}
+
+// NotAfterSchedule activates like its schedule until the End.
+type NotAfterSchedule struct {
+	Schedule Schedule
+	End      time.Time
+}
+
+// NotAfter returns a Schedule that activates like the given schedule, but not
+// after the given time, e.g. the end of a contract.
+func NotAfter(schedule Schedule, end time.Time) NotAfterSchedule {
+	return NotAfterSchedule{Schedule: schedule, End: end}
+}
+
+// Next returns the next activation of the schedule, or the zero time if it is
+// after the End.
+func (s NotAfterSchedule) Next(t time.Time) time.Time {
+	next := s.Schedule.Next(t)
+	if next.After(s.End) {
+		return time.Time{}
+	}
+	return next
+}
+
+// StartingAtSchedule activates like its schedule from the Start on.
+type StartingAtSchedule struct {
+	Schedule Schedule
+	Start    time.Time
+}
+
+// StartingAt returns a Schedule that activates like the given schedule, but
+// not before the given time.  Unlike the StartTime of a ConstantDelaySchedule,
+// it works for any schedule and does not change the times of the activations.
+func StartingAt(schedule Schedule, start time.Time) StartingAtSchedule {
+	return StartingAtSchedule{Schedule: schedule, Start: start}
+}
+
+// Next returns the next activation of the schedule, which is not before the
+// Start.
+func (s StartingAtSchedule) Next(t time.Time) time.Time {
+	if t.Before(s.Start) {
+		t = s.Start.Add(-1 * time.Nanosecond)
+	}
+	return s.Schedule.Next(t)
+}
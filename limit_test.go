@@ -0,0 +1,128 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLimitNext(t *testing.T) {
+	hourly, _ := Parse("@hourly")
+	start := getTime("Mon Jul 9 14:45 2012")
+	tests := []struct {
+		n        int
+		times    []string
+		expected []string
+	}{
+		{3, []string{"Mon Jul 9 14:45 2012", "Mon Jul 9 15:00 2012", "Mon Jul 9 16:00 2012", "Mon Jul 9 17:00 2012"},
+			[]string{"Mon Jul 9 15:00 2012", "Mon Jul 9 16:00 2012", "Mon Jul 9 17:00 2012", ""}},
+
+		// Repeated calls, e.g. on a restart, do not count again.
+		{2, []string{"Mon Jul 9 14:45 2012", "Mon Jul 9 14:45 2012", "Mon Jul 9 15:00 2012", "Mon Jul 9 14:50 2012", "Mon Jul 9 16:00 2012"},
+			[]string{"Mon Jul 9 15:00 2012", "Mon Jul 9 15:00 2012", "Mon Jul 9 16:00 2012", "Mon Jul 9 15:00 2012", ""}},
+
+		// Times before the start are not counted.
+		{1, []string{"Mon Jul 9 10:00 2012", "Mon Jul 9 15:00 2012"},
+			[]string{"Mon Jul 9 15:00 2012", ""}},
+
+		// Missed activations count.
+		{2, []string{"Mon Jul 9 15:30 2012"}, []string{"Mon Jul 9 16:00 2012"}},
+		{2, []string{"Mon Jul 9 16:30 2012"}, []string{""}},
+
+		{0, []string{"Mon Jul 9 14:45 2012"}, []string{""}},
+	}
+
+	for _, c := range tests {
+		s := &LimitSchedule{Schedule: hourly, N: c.n, Start: start}
+		for i, tm := range c.times {
+			actual := s.Next(getTime(tm))
+			expected := getTime(c.expected[i])
+			if !actual.Equal(expected) {
+				t.Errorf("Limit %d, %s: (expected) %v != %v (actual)", c.n, tm, expected, actual)
+			}
+		}
+	}
+}
+
+func TestLimitComposition(t *testing.T) {
+	hourly, _ := Parse("@hourly")
+	excluded, _ := Parse("0 0 16 * * *")
+	s := &LimitSchedule{Schedule: hourly, N: 3, Start: getTime("Mon Jul 9 14:45 2012")}
+
+	// Except probes the limited schedule, which must not use it up.
+	except := Except(s, excluded)
+	expected := []string{"Mon Jul 9 15:00 2012", "Mon Jul 9 17:00 2012", ""}
+	next := getTime("Mon Jul 9 14:45 2012")
+	for _, e := range expected {
+		next = except.Next(next)
+		if !next.Equal(getTime(e)) {
+			t.Errorf("(expected) %v != %v (actual)", getTime(e), next)
+		}
+	}
+	if !s.Last().Equal(getTime("Mon Jul 9 17:00 2012")) {
+		t.Errorf("(expected) last activation at 17:00 != %v (actual)", s.Last())
+	}
+
+	// The limit is shared by the schedules wrapping it.
+	union := ScheduleUnion(s, s)
+	if actual := union.Next(getTime("Mon Jul 9 16:00 2012")); !actual.Equal(getTime("Mon Jul 9 17:00 2012")) {
+		t.Errorf("(expected) 17:00 != %v (actual)", actual)
+	}
+}
+
+func TestLimitRestart(t *testing.T) {
+	s := Limit(Every(time.Hour), 1)
+	cron := New()
+	cron.Schedule(s, FuncJob(func() {}))
+
+	// Starting and stopping before the activation does not use it up.
+	cron.Start()
+	cron.Stop()
+	cron.Start()
+	defer cron.Stop()
+	if next := cron.Entries()[0].Next; next.IsZero() || !next.Equal(s.Last()) {
+		t.Errorf("(expected) %v != %v (actual)", s.Last(), next)
+	}
+}
+
+func TestNotAfterNext(t *testing.T) {
+	hourly, _ := Parse("@hourly")
+	end := getTime("Mon Jul 9 17:00 2012")
+	tests := []struct {
+		time, expected string
+	}{
+		{"Mon Jul 9 14:45 2012", "Mon Jul 9 15:00 2012"},
+		{"Mon Jul 9 16:00 2012", "Mon Jul 9 17:00 2012"},
+		{"Mon Jul 9 17:00 2012", ""},
+		{"Tue Jul 10 14:45 2012", ""},
+	}
+	for _, c := range tests {
+		actual := NotAfter(hourly, end).Next(getTime(c.time))
+		expected := getTime(c.expected)
+		if !actual.Equal(expected) {
+			t.Errorf("%s: (expected) %v != %v (actual)", c.time, expected, actual)
+		}
+	}
+
+	if actual := NotAfter(At(end.Add(time.Hour)), end).Next(getTime("Mon Jul 9 14:45 2012")); !actual.IsZero() {
+		t.Errorf("(expected) zero time != %v (actual)", actual)
+	}
+}
+
+func TestStartingAtNext(t *testing.T) {
+	hourly, _ := Parse("@hourly")
+	tests := []struct {
+		start, time, expected string
+	}{
+		{"Mon Jul 9 17:00 2012", "Mon Jul 9 14:45 2012", "Mon Jul 9 17:00 2012"},
+		{"Mon Jul 9 17:30 2012", "Mon Jul 9 14:45 2012", "Mon Jul 9 18:00 2012"},
+		{"Mon Jul 9 17:00 2012", "Mon Jul 9 17:00 2012", "Mon Jul 9 18:00 2012"},
+		{"Mon Jul 9 17:00 2012", "Tue Jul 10 14:45 2012", "Tue Jul 10 15:00 2012"},
+	}
+	for _, c := range tests {
+		actual := StartingAt(hourly, getTime(c.start)).Next(getTime(c.time))
+		expected := getTime(c.expected)
+		if !actual.Equal(expected) {
+			t.Errorf("%s, %s: (expected) %v != %v (actual)", c.start, c.time, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,303 @@
+package cron
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Locale is a message catalog used to describe schedules in a language.
+type Locale struct {
+	Weekdays [7]string  // Names of the days of the week, starting on Sunday
+	Months   [12]string // Names of the months, starting in January
+	Ordinals [5]string  // First to fifth, for the n-th weekday of the month
+	And      string     // Joins the last item of a list, e.g. " and "
+	Through  string     // Joins the bounds of a range, e.g. " through "
+	Or       string     // Joins the day of month and the day of week, e.g. " or "
+
+	// Messages maps the message keys to their format strings.  See English
+	// for the keys and their arguments.  Messages which are missing are taken
+	// from English.
+	Messages map[string]string
+}
+
+// msg returns the message with the given key, formatted with the arguments.
+func (l *Locale) msg(key string, args ...interface{}) string {
+	format, ok := l.Messages[key]
+	if !ok {
+		format = English.Messages[key]
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+var (
+	localesMu sync.RWMutex
+	locales   = map[string]*Locale{
+		"en": English,
+		"de": German,
+		"fr": French,
+		"it": Italian,
+		"es": Spanish,
+	}
+)
+
+// RegisterLocale registers the locale for the given name, e.g. "nl" or "de-CH",
+// replacing any locale registered before.  It is safe to call concurrently with
+// DescribeIn.
+func RegisterLocale(name string, locale *Locale) {
+	localesMu.Lock()
+	defer localesMu.Unlock()
+	locales[strings.ToLower(name)] = locale
+}
+
+// lookupLocale returns the locale registered for the name, falling back to the
+// language without the region, and to English.
+func lookupLocale(name string) *Locale {
+	name = strings.ToLower(strings.Replace(name, "_", "-", -1))
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+	if l, ok := locales[name]; ok {
+		return l
+	}
+	if i := strings.Index(name, "-"); i >= 0 {
+		if l, ok := locales[name[:i]]; ok {
+			return l
+		}
+	}
+	return English
+}
+
+// English describes schedules in English.
+var English = &Locale{
+	Weekdays: [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+	Months: [12]string{"January", "February", "March", "April", "May", "June",
+		"July", "August", "September", "October", "November", "December"},
+	Ordinals: [5]string{"first", "second", "third", "fourth", "fifth"},
+	And:      " and ",
+	Through:  " through ",
+	Or:       " or ",
+	Messages: map[string]string{
+		"every":             "every %v",                           // delay
+		"everyJitter":       "every %v with a jitter of up to %v", // delay, jitter
+		"once":              "once at %s",                         // timestamp
+		"reboot":            "once at startup",
+		"window":            "%s, only between %s and %s", // schedule, start, end
+		"custom":            "custom schedule",
+		"at":                "at %s", // times of the day
+		"everySecond":       "every second",
+		"everyMilliseconds": "every %d milliseconds", // step
+		"millisecond":       "at millisecond %s past the second",
+		"milliseconds":      "at milliseconds %s past the second",
+		"everySeconds":      "every %d seconds", // step
+		"second":            "at second %s past the minute",
+		"seconds":           "at seconds %s past the minute",
+		"everyMinute":       "every minute",
+		"everyMinutes":      "every %d minutes", // step
+		"minute":            "at minute %s past the hour",
+		"minutes":           "at minutes %s past the hour",
+		"everyHour":         "every hour",
+		"everyHours":        "every %d hours",    // step
+		"hourRange":         "between %s and %s", // start, end
+		"hour":              "in the hour %s",
+		"hours":             "in the hours %s",
+		"dom":               "on day %s of the month",
+		"doms":              "on days %s of the month",
+		"lastDom":           "on the last day of the month",
+		"lastWeekdayDom":    "on the last weekday of the month",
+		"weekdayDom":        "on the weekday nearest to day %s of the month",
+		"weekdayDoms":       "on the weekday nearest to days %s of the month",
+		"dow":               "on %s",                       // weekdays
+		"lastDow":           "on the last %s of the month", // weekdays
+		"nthDow":            "on the %s %s of the month",   // ordinal, weekdays
+		"months":            "in %s",
+		"years":             "in %s",
+	},
+}
+
+// German describes schedules in German.
+var German = &Locale{
+	Weekdays: [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+	Months: [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni",
+		"Juli", "August", "September", "Oktober", "November", "Dezember"},
+	Ordinals: [5]string{"ersten", "zweiten", "dritten", "vierten", "fünften"},
+	And:      " und ",
+	Through:  " bis ",
+	Or:       " oder ",
+	Messages: map[string]string{
+		"every":             "alle %v",
+		"everyJitter":       "alle %v mit einer zufälligen Verzögerung von bis zu %v",
+		"once":              "einmalig am %s",
+		"reboot":            "einmalig beim Start",
+		"window":            "%s, nur zwischen %s und %s",
+		"custom":            "benutzerdefinierter Zeitplan",
+		"at":                "um %s",
+		"everySecond":       "jede Sekunde",
+		"everyMilliseconds": "alle %d Millisekunden",
+		"millisecond":       "in Millisekunde %s jeder Sekunde",
+		"milliseconds":      "in den Millisekunden %s jeder Sekunde",
+		"everySeconds":      "alle %d Sekunden",
+		"second":            "in Sekunde %s jeder Minute",
+		"seconds":           "in den Sekunden %s jeder Minute",
+		"everyMinute":       "jede Minute",
+		"everyMinutes":      "alle %d Minuten",
+		"minute":            "in Minute %s jeder Stunde",
+		"minutes":           "in den Minuten %s jeder Stunde",
+		"everyHour":         "jede Stunde",
+		"everyHours":        "alle %d Stunden",
+		"hourRange":         "zwischen %s und %s",
+		"hour":              "in der Stunde %s",
+		"hours":             "in den Stunden %s",
+		"dom":               "am Tag %s des Monats",
+		"doms":              "an den Tagen %s des Monats",
+		"lastDom":           "am letzten Tag des Monats",
+		"lastWeekdayDom":    "am letzten Werktag des Monats",
+		"weekdayDom":        "am Werktag, der dem Tag %s des Monats am nächsten liegt",
+		"weekdayDoms":       "am Werktag, der den Tagen %s des Monats am nächsten liegt",
+		"dow":               "am %s",
+		"lastDow":           "am letzten %s des Monats",
+		"nthDow":            "am %s %s des Monats",
+		"months":            "im %s",
+		"years":             "im Jahr %s",
+	},
+}
+
+// French describes schedules in French.
+var French = &Locale{
+	Weekdays: [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+	Months: [12]string{"janvier", "février", "mars", "avril", "mai", "juin",
+		"juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+	Ordinals: [5]string{"premier", "deuxième", "troisième", "quatrième", "cinquième"},
+	And:      " et ",
+	Through:  " à ",
+	Or:       " ou ",
+	Messages: map[string]string{
+		"every":             "toutes les %v",
+		"everyJitter":       "toutes les %v avec un décalage aléatoire jusqu'à %v",
+		"once":              "une fois le %s",
+		"reboot":            "une fois au démarrage",
+		"window":            "%s, uniquement entre %s et %s",
+		"custom":            "planification personnalisée",
+		"at":                "à %s",
+		"everySecond":       "chaque seconde",
+		"everyMilliseconds": "toutes les %d millisecondes",
+		"millisecond":       "à la milliseconde %s de chaque seconde",
+		"milliseconds":      "aux millisecondes %s de chaque seconde",
+		"everySeconds":      "toutes les %d secondes",
+		"second":            "à la seconde %s de chaque minute",
+		"seconds":           "aux secondes %s de chaque minute",
+		"everyMinute":       "chaque minute",
+		"everyMinutes":      "toutes les %d minutes",
+		"minute":            "à la minute %s de chaque heure",
+		"minutes":           "aux minutes %s de chaque heure",
+		"everyHour":         "chaque heure",
+		"everyHours":        "toutes les %d heures",
+		"hourRange":         "entre %s et %s",
+		"hour":              "pendant l'heure %s",
+		"hours":             "pendant les heures %s",
+		"dom":               "le jour %s du mois",
+		"doms":              "les jours %s du mois",
+		"lastDom":           "le dernier jour du mois",
+		"lastWeekdayDom":    "le dernier jour ouvré du mois",
+		"weekdayDom":        "le jour ouvré le plus proche du jour %s du mois",
+		"weekdayDoms":       "le jour ouvré le plus proche des jours %s du mois",
+		"dow":               "le %s",
+		"lastDow":           "le dernier %s du mois",
+		"nthDow":            "le %s %s du mois",
+		"months":            "en %s",
+		"years":             "en %s",
+	},
+}
+
+// Italian describes schedules in Italian.
+var Italian = &Locale{
+	Weekdays: [7]string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+	Months: [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno",
+		"luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+	Ordinals: [5]string{"primo", "secondo", "terzo", "quarto", "quinto"},
+	And:      " e ",
+	Through:  " a ",
+	Or:       " o ",
+	Messages: map[string]string{
+		"every":             "ogni %v",
+		"everyJitter":       "ogni %v con un ritardo casuale fino a %v",
+		"once":              "una volta il %s",
+		"reboot":            "una volta all'avvio",
+		"window":            "%s, solo tra le %s e le %s",
+		"custom":            "pianificazione personalizzata",
+		"at":                "alle %s",
+		"everySecond":       "ogni secondo",
+		"everyMilliseconds": "ogni %d millisecondi",
+		"millisecond":       "al millisecondo %s di ogni secondo",
+		"milliseconds":      "ai millisecondi %s di ogni secondo",
+		"everySeconds":      "ogni %d secondi",
+		"second":            "al secondo %s di ogni minuto",
+		"seconds":           "ai secondi %s di ogni minuto",
+		"everyMinute":       "ogni minuto",
+		"everyMinutes":      "ogni %d minuti",
+		"minute":            "al minuto %s di ogni ora",
+		"minutes":           "ai minuti %s di ogni ora",
+		"everyHour":         "ogni ora",
+		"everyHours":        "ogni %d ore",
+		"hourRange":         "tra le %s e le %s",
+		"hour":              "nell'ora %s",
+		"hours":             "nelle ore %s",
+		"dom":               "il giorno %s del mese",
+		"doms":              "i giorni %s del mese",
+		"lastDom":           "l'ultimo giorno del mese",
+		"lastWeekdayDom":    "l'ultimo giorno feriale del mese",
+		"weekdayDom":        "il giorno feriale più vicino al giorno %s del mese",
+		"weekdayDoms":       "il giorno feriale più vicino ai giorni %s del mese",
+		"dow":               "di %s",
+		"lastDow":           "l'ultimo %s del mese",
+		"nthDow":            "il %s %s del mese",
+		"months":            "in %s",
+		"years":             "nel %s",
+	},
+}
+
+// Spanish describes schedules in Spanish.
+var Spanish = &Locale{
+	Weekdays: [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+	Months: [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio",
+		"julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+	Ordinals: [5]string{"primer", "segundo", "tercer", "cuarto", "quinto"},
+	And:      " y ",
+	Through:  " a ",
+	Or:       " o ",
+	Messages: map[string]string{
+		"every":             "cada %v",
+		"everyJitter":       "cada %v con un retraso aleatorio de hasta %v",
+		"once":              "una vez el %s",
+		"reboot":            "una vez al iniciar",
+		"window":            "%s, solo entre las %s y las %s",
+		"custom":            "programación personalizada",
+		"at":                "a las %s",
+		"everySecond":       "cada segundo",
+		"everyMilliseconds": "cada %d milisegundos",
+		"millisecond":       "en el milisegundo %s de cada segundo",
+		"milliseconds":      "en los milisegundos %s de cada segundo",
+		"everySeconds":      "cada %d segundos",
+		"second":            "en el segundo %s de cada minuto",
+		"seconds":           "en los segundos %s de cada minuto",
+		"everyMinute":       "cada minuto",
+		"everyMinutes":      "cada %d minutos",
+		"minute":            "en el minuto %s de cada hora",
+		"minutes":           "en los minutos %s de cada hora",
+		"everyHour":         "cada hora",
+		"everyHours":        "cada %d horas",
+		"hourRange":         "entre las %s y las %s",
+		"hour":              "en la hora %s",
+		"hours":             "en las horas %s",
+		"dom":               "el día %s del mes",
+		"doms":              "los días %s del mes",
+		"lastDom":           "el último día del mes",
+		"lastWeekdayDom":    "el último día laborable del mes",
+		"weekdayDom":        "el día laborable más cercano al día %s del mes",
+		"weekdayDoms":       "el día laborable más cercano a los días %s del mes",
+		"dow":               "el %s",
+		"lastDow":           "el último %s del mes",
+		"nthDow":            "el %s %s del mes",
+		"months":            "en %s",
+		"years":             "en %s",
+	},
+}
@@ -0,0 +1,75 @@
+package cron
+
+import "testing"
+
+func TestDescribeIn(t *testing.T) {
+	tests := []struct {
+		spec, locale, expected string
+	}{
+		{"0 30 6 * * MON-FRI", "en", "at 06:30 on Monday through Friday"},
+		{"0 30 6 * * MON-FRI", "de", "um 06:30 am Montag bis Freitag"},
+		{"0 30 6 * * MON-FRI", "fr", "à 06:30 le lundi à vendredi"},
+		{"0 30 6 * * MON-FRI", "it", "alle 06:30 di lunedì a venerdì"},
+		{"0 30 6 * * MON-FRI", "es", "a las 06:30 el lunes a viernes"},
+		{"0 0/5 9-17 * * *", "de", "alle 5 Minuten, zwischen 09:00 und 17:59"},
+		{"0 0 0 1,15 * *", "fr", "à 00:00 les jours 1 et 15 du mois"},
+		{"0 0 0 * * 1#2", "es", "a las 00:00 el segundo lunes del mes"},
+		{"0 0 12 1 3 * 2030", "it", "alle 12:00 il giorno 1 del mese in marzo nel 2030"},
+		{"@reboot", "de", "einmalig beim Start"},
+
+		// Stepped stars
+		{"0 */15 * * * *", "de", "alle 15 Minuten"},
+		{"*/10 * * * * *", "fr", "toutes les 10 secondes"},
+		{"0 0 */2 * * *", "it", "ogni 2 ore"},
+		{"0 */15 * * * *", "es", "cada 15 minutos"},
+		{"0 0 0 * */6 *", "de", "um 00:00 im Januar und Juli"},
+
+		// Regions fall back to their language, unknown languages to English.
+		{"0 0 * * * *", "de-CH", "jede Stunde"},
+		{"0 0 * * * *", "fr_CA", "chaque heure"},
+		{"0 0 * * * *", "xx", "every hour"},
+	}
+
+	for _, c := range tests {
+		actual, err := DescribeSpecIn(c.spec, c.locale)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if actual != c.expected {
+			t.Errorf("%s (%s): (expected) %q != %q (actual)", c.spec, c.locale, c.expected, actual)
+		}
+	}
+}
+
+func TestRegisterLocale(t *testing.T) {
+	dutch := &Locale{
+		Weekdays: [7]string{"zondag", "maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag"},
+		And:      " en ",
+		Through:  " tot en met ",
+		Or:       " of ",
+		Messages: map[string]string{
+			"at":  "om %s",
+			"dow": "op %s",
+		},
+	}
+	RegisterLocale("nl", dutch)
+
+	// Missing messages are taken from English.
+	tests := []struct {
+		spec, expected string
+	}{
+		{"0 30 6 * * MON-FRI", "om 06:30 op maandag tot en met vrijdag"},
+		{"0 0 * * * *", "every hour"},
+	}
+	for _, c := range tests {
+		actual, err := DescribeSpecIn(c.spec, "nl-BE")
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if actual != c.expected {
+			t.Errorf("%s: (expected) %q != %q (actual)", c.spec, c.expected, actual)
+		}
+	}
+}
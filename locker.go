@@ -0,0 +1,70 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrLocked is returned by a Locker if the lock is held by another process.
+var ErrLocked = errors.New("Lock is held by another process")
+
+// Locker locks the runs of the entries across the replicas of a horizontally
+// scaled service, e.g. with a Redis or etcd lease, so that only one of them
+// runs each activation.  Its methods are called concurrently.
+type Locker interface {
+	// Lock acquires the lock of the key, or returns ErrLocked if another
+	// process holds it.  It returns the error of the context if it is done
+	// before.
+	Lock(ctx context.Context, key string) error
+
+	// Unlock releases the lock of the key.
+	Unlock(ctx context.Context, key string) error
+}
+
+// WithLocker locks each run of the named entries with the given locker, once
+// the run has been admitted by the limits of its entry and the Cron.  The key
+// of the lock is the name of the entry and the activation of the run in UTC,
+// e.g. "backup@2016-03-01T12:00:00Z", and the lock is held until the next
+// activation of the entry, so that replicas whose clocks are behind, or which
+// wake up late, do not run the activation again.  The locks of the last
+// activations of entries are not released.  Runs whose lock is held by another
+// process are skipped, and runs failing to acquire it fail with its error.
+// Entries without a name are not locked, since their IDs differ between the
+// replicas.
+func WithLocker(locker Locker) Option {
+	return func(c *Cron) {
+		c.locker = locker
+	}
+}
+
+// lock acquires the lock of the run of the entry, if any, and returns the func
+// which releases it at the next activation of the entry, once the run has
+// completed.  It returns ErrSkipped if another process holds the lock.
+func (c *Cron) lock(ctx context.Context, run *Entry) (unlock func(), err error) {
+	if c.locker == nil || run.Name == "" {
+		return func() {}, nil
+	}
+	key := run.Name + "@" + run.Prev.UTC().Format(time.RFC3339Nano)
+	if err := c.locker.Lock(ctx, key); err != nil {
+		if err == ErrLocked {
+			c.logger.Info("skip", "entry", run.ID, "name", run.Name, "scheduled", run.Prev, "reason", "locked")
+			return nil, ErrSkipped
+		}
+		return nil, err
+	}
+	return func() {
+		next := run.Next
+		if next.IsZero() {
+			return
+		}
+		// The lock is released at the next activation even if the Cron has
+		// stopped, since other processes may still be running.
+		go func() {
+			<-c.clock.After(next.Sub(c.clock.Now()))
+			if err := c.locker.Unlock(context.Background(), key); err != nil {
+				c.logger.Error(err, "failed to unlock", "entry", run.ID, "name", run.Name, "key", key)
+			}
+		}()
+	}, nil
+}
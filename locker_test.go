@@ -0,0 +1,135 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeLocker fails to lock the keys of the names with an error, and holds the
+// other keys until they are unlocked.
+type fakeLocker struct {
+	mu       sync.Mutex
+	errors   map[string]error
+	held     map[string]bool
+	locked   map[string]int
+	unlocked map[string]int
+}
+
+func newFakeLocker(errors map[string]error) *fakeLocker {
+	return &fakeLocker{errors: errors, held: map[string]bool{}, locked: map[string]int{}, unlocked: map[string]int{}}
+}
+
+func (l *fakeLocker) Lock(ctx context.Context, key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	name := key[:strings.Index(key, "@")]
+	if err := l.errors[name]; err != nil {
+		return err
+	}
+	if l.held[key] {
+		return ErrLocked
+	}
+	l.held[key] = true
+	l.locked[name]++
+	return nil
+}
+
+func (l *fakeLocker) Unlock(ctx context.Context, key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.held, key)
+	l.unlocked[key[:strings.Index(key, "@")]]++
+	return nil
+}
+
+func TestWithLocker(t *testing.T) {
+	broken := errors.New("broken")
+	locker := newFakeLocker(map[string]error{"held": ErrLocked, "broken": broken})
+	cron := New(WithLocker(locker))
+	ran := make(chan string, 10)
+	for _, name := range []string{"free", "held", "broken"} {
+		name := name
+		cron.AddNamedJob(name, "* * * * * ?", FuncJob(func() { ran <- name }))
+	}
+	cron.AddFunc("* * * * * ?", func() { ran <- "unnamed" })
+	cron.Start()
+	time.Sleep(ONE_SECOND + 50*time.Millisecond)
+	<-cron.Stop().Done()
+
+	runs := map[string]int{}
+	for len(ran) > 0 {
+		runs[<-ran]++
+	}
+	tests := []struct {
+		name                 string
+		ran, skipped, failed bool
+		locked               bool
+		expectedErr          error
+	}{
+		{"free", true, false, false, true, nil},
+		{"held", false, true, false, false, nil},
+		{"broken", false, false, true, false, broken},
+	}
+	for _, c := range tests {
+		s := cron.EntryByName(c.name).Stats
+		if (runs[c.name] > 0) != c.ran || (s.Skips > 0) != c.skipped || (s.Failures > 0) != c.failed || s.LastError != c.expectedErr {
+			t.Errorf("%s: unexpected %d runs, stats %+v", c.name, runs[c.name], s)
+		}
+		locker.mu.Lock()
+		if (locker.locked[c.name] > 0) != c.locked {
+			t.Errorf("%s: unexpected %d locks", c.name, locker.locked[c.name])
+		}
+		locker.mu.Unlock()
+	}
+	if runs["unnamed"] == 0 {
+		t.Error("expected the unnamed entry to run without a lock")
+	}
+}
+
+// Replicas sharing a Locker run each activation once, even if the clock of one
+// is behind, since the locks are held until the next activation.
+func TestWithLockerReplicas(t *testing.T) {
+	start := time.Date(2016, time.March, 1, 12, 0, 30, 0, time.UTC)
+	locker := newFakeLocker(nil)
+	var (
+		mu   sync.Mutex
+		runs = map[time.Time]int{}
+	)
+	clocks := []*fakeClock{newFakeClock(start), newFakeClock(start.Add(-10 * time.Second))}
+	for _, clock := range clocks {
+		cron := New(WithClock(clock), WithLocation(time.UTC), WithLocker(locker),
+			WithErrorHandler(func(run RunInfo) {}),
+			WithBeforeRun(func(run RunInfo) {
+				mu.Lock()
+				runs[run.Scheduled]++
+				mu.Unlock()
+			}))
+		cron.AddNamedJob("singleton", "0 * * * * *", FuncJob(func() {}))
+		cron.Start()
+		defer cron.Stop()
+		clock.waitForTimers(t, 1)
+	}
+
+	for minute := 1; minute <= 3; minute++ {
+		// The first replica runs the activation, and holds its lock until the
+		// next one, while the second one runs 10 seconds behind.
+		clocks[0].Advance(time.Minute)
+		clocks[0].waitForTimers(t, 2)
+		clocks[1].Advance(time.Minute)
+		clocks[1].waitForTimers(t, 1)
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for minute := 1; minute <= 3; minute++ {
+		activation := time.Date(2016, time.March, 1, 12, minute, 0, 0, time.UTC)
+		if runs[activation] != 1 {
+			t.Errorf("%v: (expected) 1 run != %d (actual)", activation, runs[activation])
+		}
+	}
+}
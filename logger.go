@@ -0,0 +1,79 @@
+package cron
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+)
+
+// Logger logs the decisions of a Cron, such as its wake-ups, skipped runs,
+// recovered panics and the progress of stopping.  The messages are followed by
+// alternating keys and values, e.g. "entry", 1.
+type Logger interface {
+	// Info logs a routine decision.
+	Info(msg string, keysAndValues ...interface{})
+
+	// Error logs an error.
+	Error(err error, msg string, keysAndValues ...interface{})
+}
+
+// DefaultLogger is the Logger of a Cron by default, which logs the errors to
+// the standard error, but not the routine decisions.
+var DefaultLogger Logger = PrintfLogger(log.New(os.Stderr, "cron: ", log.LstdFlags))
+
+// DiscardLogger is a Logger, which discards all messages.
+var DiscardLogger Logger = discardLogger{}
+
+// PrintfLogger returns a Logger, which logs the errors with the given logger,
+// e.g. a *log.Logger.
+func PrintfLogger(l interface {
+	Printf(string, ...interface{})
+}) Logger {
+	return printfLogger{l, false}
+}
+
+// VerbosePrintfLogger returns a Logger, which logs the routine decisions as
+// well as the errors with the given logger.
+func VerbosePrintfLogger(l interface {
+	Printf(string, ...interface{})
+}) Logger {
+	return printfLogger{l, true}
+}
+
+type printfLogger struct {
+	logger interface {
+		Printf(string, ...interface{})
+	}
+	verbose bool
+}
+
+func (l printfLogger) Info(msg string, keysAndValues ...interface{}) {
+	if l.verbose {
+		l.logger.Printf("%s", formatLog(msg, keysAndValues))
+	}
+}
+
+func (l printfLogger) Error(err error, msg string, keysAndValues ...interface{}) {
+	l.logger.Printf("%s", formatLog(msg, append(keysAndValues, "error", err)))
+}
+
+// formatLog formats the message followed by the keys and values, e.g.
+// "skip, entry=1, name=backup".
+func formatLog(msg string, keysAndValues []interface{}) string {
+	var b bytes.Buffer
+	b.WriteString(msg)
+	for i := 0; i < len(keysAndValues); i += 2 {
+		if i+1 < len(keysAndValues) {
+			fmt.Fprintf(&b, ", %v=%v", keysAndValues[i], keysAndValues[i+1])
+		} else {
+			fmt.Fprintf(&b, ", %v", keysAndValues[i])
+		}
+	}
+	return b.String()
+}
+
+type discardLogger struct{}
+
+func (discardLogger) Info(msg string, keysAndValues ...interface{})             {}
+func (discardLogger) Error(err error, msg string, keysAndValues ...interface{}) {}
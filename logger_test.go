@@ -0,0 +1,104 @@
+package cron
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPrintfLogger(t *testing.T) {
+	err := errors.New("broken")
+	tests := []struct {
+		verbose  bool
+		log      func(Logger)
+		expected string
+	}{
+		{false, func(l Logger) { l.Info("skip", "entry", 1) }, ""},
+		{true, func(l Logger) { l.Info("skip", "entry", 1, "name", "backup") }, "skip, entry=1, name=backup\n"},
+		{true, func(l Logger) { l.Info("start") }, "start\n"},
+		{true, func(l Logger) { l.Info("odd", "entry") }, "odd, entry\n"},
+		{false, func(l Logger) { l.Error(err, "panic", "entry", 2) }, "panic, entry=2, error=broken\n"},
+	}
+	for _, c := range tests {
+		var b bytes.Buffer
+		logger := PrintfLogger(log.New(&b, "", 0))
+		if c.verbose {
+			logger = VerbosePrintfLogger(log.New(&b, "", 0))
+		}
+		c.log(logger)
+		if b.String() != c.expected {
+			t.Errorf("(expected) %q != %q (actual)", c.expected, b.String())
+		}
+	}
+}
+
+// recordingLogger records the messages logged.
+type recordingLogger struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (l *recordingLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.record("info: " + formatLog(msg, keysAndValues))
+}
+
+func (l *recordingLogger) Error(err error, msg string, keysAndValues ...interface{}) {
+	l.record("error: " + formatLog(msg, append(keysAndValues, "error", err)))
+}
+
+func (l *recordingLogger) record(message string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.messages = append(l.messages, message)
+}
+
+// count returns the number of messages starting with the prefix.
+func (l *recordingLogger) count(prefix string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	n := 0
+	for _, message := range l.messages {
+		if strings.HasPrefix(message, prefix) {
+			n++
+		}
+	}
+	return n
+}
+
+// The decisions of the Cron are logged with its Logger.
+func TestWithLogger(t *testing.T) {
+	logger := &recordingLogger{}
+	cron := New(WithLogger(logger))
+	if _, err := cron.AddFunc("invalid", func() {}); err == nil {
+		t.Fatal("expected an error")
+	}
+	id, _ := cron.AddNamedJob("broken", "* * * * * ?", FuncJob(func() { panic("broken") }))
+	paused, _ := cron.AddFunc("* * * * * ?", func() {})
+	cron.Pause(paused)
+	cron.Start()
+	time.Sleep(ONE_SECOND + 100*time.Millisecond)
+	cron.StopWithTimeout(time.Second)
+
+	for _, c := range []struct {
+		prefix   string
+		expected int
+	}{
+		{"error: failed to parse spec, spec=invalid, error=", 1},
+		{fmt.Sprintf("error: panic, entry=%d, name=broken, stack=", id), 1},
+		{fmt.Sprintf("info: skip, entry=%d, name=, scheduled=", paused), 1},
+		{"info: start", 1},
+		{"info: wake", 1},
+		{"info: stop", 1},
+		{"info: waiting for jobs", 1},
+		{"info: jobs completed", 1},
+	} {
+		if actual := logger.count(c.prefix); actual < c.expected {
+			t.Errorf("%s: (expected) %d != %d (actual) in %q", c.prefix, c.expected, actual, logger.messages)
+		}
+	}
+}
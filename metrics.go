@@ -0,0 +1,64 @@
+package cron
+
+import "time"
+
+// Metrics records the metrics of a Cron, e.g. as the counters, histograms and
+// gauges of a Prometheus collector registered on a prometheus.Registerer:
+//
+//	runs, failures, skips  counters by entry name
+//	duration, lateness     histograms by entry name
+//	entries, in-flight     gauges of the scheduler
+//
+// The package provides no such collector, to keep it free of dependencies, so
+// callers implement Metrics with their Prometheus client.  Its methods are
+// called concurrently by the Cron, and must not call it.
+type Metrics interface {
+	// ObserveRun records a run of the job of an entry, whose Err is ErrSkipped
+	// if it has been skipped, e.g. because the entry is paused.  The lateness
+	// is the time the run started after its activation, and zero for skipped
+	// runs.
+	ObserveRun(run RunInfo, lateness time.Duration)
+
+	// SetEntries records the number of entries of the Cron.
+	SetEntries(n int)
+
+	// SetInFlight records the number of runs which have started, but not
+	// completed yet.
+	SetInFlight(n int)
+}
+
+// WithMetrics records the metrics of the Cron with the given metrics.
+func WithMetrics(metrics Metrics) Option {
+	return func(c *Cron) {
+		c.metrics = metrics
+	}
+}
+
+// observeRun records the completed or skipped run.
+func (c *Cron) observeRun(run RunInfo) {
+	if c.metrics == nil {
+		return
+	}
+	var lateness time.Duration
+	if run.Err != ErrSkipped && run.Start.After(run.Scheduled) {
+		lateness = run.Start.Sub(run.Scheduled)
+	}
+	c.metrics.ObserveRun(run, lateness)
+}
+
+// entriesChanged records the number of entries, after adding or removing one.
+func (c *Cron) entriesChanged() {
+	c.mu.Lock()
+	c.debug.Entries = len(c.entries)
+	c.mu.Unlock()
+	if c.metrics != nil {
+		c.metrics.SetEntries(len(c.entries))
+	}
+}
+
+// inFlightChanged records the number of runs in flight, with c.mu held.
+func (c *Cron) inFlightChanged() {
+	if c.metrics != nil {
+		c.metrics.SetInFlight(c.debug.InFlight)
+	}
+}
@@ -0,0 +1,88 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingMetrics records the metrics by entry name.
+type recordingMetrics struct {
+	mu                    sync.Mutex
+	runs, failures, skips map[string]int
+	entries, inFlight     int
+	maxInFlight           int
+}
+
+func newRecordingMetrics() *recordingMetrics {
+	return &recordingMetrics{runs: map[string]int{}, failures: map[string]int{}, skips: map[string]int{}}
+}
+
+func (m *recordingMetrics) ObserveRun(run RunInfo, lateness time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	switch {
+	case run.Err == ErrSkipped:
+		m.skips[run.Entry.Name]++
+	case run.Err != nil:
+		m.failures[run.Entry.Name]++
+		fallthrough
+	default:
+		m.runs[run.Entry.Name]++
+	}
+}
+
+func (m *recordingMetrics) SetEntries(n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries = n
+}
+
+func (m *recordingMetrics) SetInFlight(n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.inFlight = n
+	if n > m.maxInFlight {
+		m.maxInFlight = n
+	}
+}
+
+func TestWithMetrics(t *testing.T) {
+	metrics := newRecordingMetrics()
+	cron := New(WithMetrics(metrics))
+	cron.AddNamedJob("ok", "* * * * * ?", FuncJob(func() { time.Sleep(10 * time.Millisecond) }))
+	cron.AddNamedJob("failing", "* * * * * ?", ErrorFuncJob(func(ctx context.Context) error { return errors.New("broken") }))
+	paused, _ := cron.AddNamedJob("paused", "* * * * * ?", FuncJob(func() {}))
+	removed, _ := cron.AddNamedJob("removed", "* * * * * ?", FuncJob(func() {}))
+	cron.Pause(paused)
+	cron.Start()
+	cron.Remove(removed)
+	time.Sleep(ONE_SECOND + 50*time.Millisecond)
+	cron.StopWithTimeout(time.Second)
+
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+	// The jobs activate once or twice within the second.
+	for _, c := range []struct {
+		name                  string
+		runs, failures, skips bool
+	}{
+		{"ok", true, false, false},
+		{"failing", true, true, false},
+		{"paused", false, false, true},
+		{"removed", false, false, false},
+	} {
+		runs, failures, skips := metrics.runs[c.name], metrics.failures[c.name], metrics.skips[c.name]
+		if (runs > 0) != c.runs || (failures > 0) != c.failures || (skips > 0) != c.skips || c.failures && failures != runs {
+			t.Errorf("%s: unexpected %d runs, %d failures, %d skips", c.name, runs, failures, skips)
+		}
+	}
+	if metrics.entries != 3 {
+		t.Errorf("(expected) 3 entries != %d (actual)", metrics.entries)
+	}
+	if metrics.inFlight != 0 || metrics.maxInFlight < 1 {
+		t.Errorf("(expected) 0 runs in flight, after at least 1 != %d, after %d (actual)", metrics.inFlight, metrics.maxInFlight)
+	}
+}
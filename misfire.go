@@ -0,0 +1,87 @@
+package cron
+
+import "time"
+
+// MisfirePolicy selects how the activations of an entry are handled, which
+// have been missed because the process was down or asleep past them.
+type MisfirePolicy int
+
+const (
+	// MisfireRunAll runs the job for each missed activation, back to back.
+	MisfireRunAll MisfirePolicy = iota
+
+	// MisfireRunOnce runs the job once for all missed activations, at the
+	// latest one of them.
+	MisfireRunOnce
+
+	// MisfireSkip skips the missed activations.
+	MisfireSkip
+
+	// MisfireCoalesce runs the job once for all missed activations, like
+	// MisfireRunOnce, passing them to the job by the context of the run, see
+	// Activations.  The activations suppressed while the entry or the Cron is
+	// paused are coalesced into one run as well, when it is resumed.
+	MisfireCoalesce
+)
+
+// misfireThreshold is the time after which an activation has been missed by
+// the scheduler waking up, rather than being just late.
+const misfireThreshold = time.Second
+
+// SetMisfirePolicy sets the policy of the missed activations of the entry with
+// the given ID, rather than MisfireRunAll.  An activation has been missed if
+// the scheduler wakes up more than a second after it, or if it is between the
+// last fire of WithLastFire and the start of the Cron.  It returns an error if
+// there is no such entry.
+func (c *Cron) SetMisfirePolicy(id EntryID, policy MisfirePolicy) error {
+	return c.updateEntry(id, func(e *Entry, running bool) {
+		e.misfire = policy
+	})
+}
+
+// WithLastFire takes the last activation fired for an entry from the given
+// function when the Cron starts or the entry is added while it runs, e.g. as
+// persisted by WithBeforeRun, rather than from the Store of WithStore, so that
+// the activations missed while the process was down are handled by the misfire
+// policy of the entry.  A zero time activates the entry as usual.
+func WithLastFire(last func(e *Entry) time.Time) Option {
+	return func(c *Cron) {
+		c.lastFire = last
+	}
+}
+
+// activate sets the next activation of the entry when the scheduler starts or
+// the entry is added, which is the first one after its last fire if that has
+// been missed.
+func (c *Cron) activate(e *Entry, now time.Time) {
+	e.Next = e.firstActivation(now)
+	if last := c.loadLastFire(e); !last.IsZero() {
+		if next := e.next(last); !next.IsZero() && next.Before(e.Next) {
+			e.Next = next
+		}
+	}
+}
+
+// catchUp handles the activation of the entry by its misfire policy, when the
+// scheduler woke up at now, and moves the next activation after now.  It
+// returns the activations to run at once, the latest one last, or none if all
+// have been skipped.
+func (c *Cron) catchUp(e *Entry, activation, now time.Time) []time.Time {
+	if e.misfire == MisfireRunAll || now.Sub(activation) <= misfireThreshold {
+		return []time.Time{activation}
+	}
+	coalesced := []time.Time{activation}
+	for !e.Next.IsZero() && !e.Next.After(now) {
+		if e.misfire != MisfireCoalesce {
+			c.skipped(e, activation, "misfire")
+			coalesced = coalesced[:0]
+		}
+		activation, e.Next = e.Next, e.next(e.Next)
+		coalesced = append(coalesced, activation)
+	}
+	if e.misfire == MisfireSkip && now.Sub(activation) > misfireThreshold {
+		c.skipped(e, activation, "misfire")
+		return nil
+	}
+	return coalesced
+}
@@ -0,0 +1,59 @@
+package cron
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestSetMisfirePolicy(t *testing.T) {
+	start := time.Date(2016, time.March, 1, 12, 0, 30, 0, time.UTC)
+	at := func(hour int) time.Time { return time.Date(2016, time.March, 1, hour, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		policy   MisfirePolicy
+		lastFire time.Time
+		asleep   time.Duration
+		expected []time.Time
+	}{
+		// The process is asleep from 12:00:30 to 15:30.
+		{MisfireRunAll, time.Time{}, 3*time.Hour + 29*time.Minute + 30*time.Second, []time.Time{at(13), at(14), at(15)}},
+		{MisfireRunOnce, time.Time{}, 3*time.Hour + 29*time.Minute + 30*time.Second, []time.Time{at(15)}},
+		{MisfireSkip, time.Time{}, 3*time.Hour + 29*time.Minute + 30*time.Second, nil},
+
+		// The process has been down since the fire at 9:00.
+		{MisfireRunAll, at(9), 0, []time.Time{at(10), at(11), at(12)}},
+		{MisfireRunOnce, at(9), 0, []time.Time{at(12)}},
+		{MisfireSkip, at(9), 0, nil},
+
+		// Late activations within a second are not missed.
+		{MisfireSkip, time.Time{}, 59*time.Minute + 30*time.Second + 500*time.Millisecond, []time.Time{at(13)}},
+	}
+	for _, c := range tests {
+		clock := newFakeClock(start)
+		ran := make(chan time.Time, 10)
+		cron := New(WithClock(clock), WithLocation(time.UTC),
+			WithBeforeRun(func(run RunInfo) { ran <- run.Scheduled }),
+			WithLastFire(func(e *Entry) time.Time { return c.lastFire }))
+		id, _ := cron.AddFunc("0 0 * * * *", func() {})
+		cron.SetMisfirePolicy(id, c.policy)
+		cron.Start()
+		clock.waitForTimers(t, 1)
+		clock.Advance(c.asleep)
+
+		var actual []time.Time
+		for waiting := true; waiting; {
+			select {
+			case t := <-ran:
+				actual = append(actual, t)
+			case <-time.After(50 * time.Millisecond):
+				waiting = false
+			}
+		}
+		cron.Stop()
+		sort.Sort(byInstant(actual))
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%d, last fire %v: (expected) %v != %v (actual)", c.policy, c.lastFire, c.expected, actual)
+		}
+	}
+}
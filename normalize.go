@@ -0,0 +1,84 @@
+package cron
+
+import (
+	"fmt"
+	"time"
+)
+
+// Normalize returns the canonical form of the given spec, which is parsed with
+// Parse, so that equivalent specs are equal as strings.  Names are replaced by
+// numbers, lists and ranges are ordered and collapsed, and descriptors are
+// expanded, e.g. "0 0 9 * * mon-fri,SAT" and "0 0 9 ? * 1-6" both become
+// "0 0 9 * * 1-6", and "@daily" becomes "0 0 0 * * *".
+// It returns a descriptive error if the spec is not valid.
+func Normalize(spec string) (string, error) {
+	schedule, err := Parse(spec)
+	if err != nil {
+		return "", err
+	}
+	return normalize(schedule)
+}
+
+// normalize returns the canonical form of the schedule.
+func normalize(schedule Schedule) (string, error) {
+	switch s := schedule.(type) {
+	case *SpecSchedule:
+		text, err := canonical(s).MarshalText()
+		return string(text), err
+	case ConstantDelaySchedule:
+		text, err := s.MarshalText()
+		return string(text), err
+	case DailySchedule:
+		text, err := s.MarshalText()
+		return string(text), err
+	case RebootSchedule:
+		return "@reboot", nil
+	case OnceSchedule:
+		return "@at " + s.At.Format(time.RFC3339), nil
+	case WindowSchedule:
+		spec, err := normalize(s.Schedule)
+		if err != nil {
+			return "", err
+		}
+		return spec + " @between " + clock(s.Start) + "-" + clock(s.End), nil
+	}
+	return "", fmt.Errorf("Schedule of type %T can not be normalized", schedule)
+}
+
+// canonical returns a copy of the schedule with the star bits set consistently
+// on the fields where they do not change the activations.
+func canonical(s *SpecSchedule) *SpecSchedule {
+	c := *s
+	c.Second = canonicalStar(c.Second, seconds)
+	c.Minute = canonicalStar(c.Minute, minutes)
+	c.Hour = canonicalStar(c.Hour, hours)
+	c.Month = canonicalStar(c.Month, months)
+
+	// A day field containing every day is equivalent to a star, as long as the
+	// other day field has one.  If neither has one, either day field matches
+	// on every day.
+	domAll := isAll(c.Dom, dom)
+	dowAll := isAll(c.Dow, dow)
+	switch {
+	case c.dayAnd:
+	case c.Dom&starBit == 0 && c.Dow&starBit == 0 && (domAll || dowAll):
+		c.Dom, c.Dow = all(dom), all(dow)
+		c.lastDom, c.lastWeekdayDom, c.weekdayDom, c.lastDow, c.nthDow = false, false, 0, 0, 0
+	case domAll && c.Dow&starBit > 0:
+		c.Dom |= starBit
+	case dowAll && c.Dom&starBit > 0:
+		c.Dow |= starBit
+	}
+	return &c
+}
+
+// canonicalStar returns the bits of a field, which does not depend on the star,
+// with the star set if the field contains every value, or every n-th value
+// starting at the minimum.  Two values are kept as a list, e.g. "0,30".
+func canonicalStar(bits uint64, r bounds) uint64 {
+	values := bitValues(bits, r)
+	if len(values) == int(r.max-r.min+1) || len(values) > 2 && step(values, r) > 0 {
+		return bits | starBit
+	}
+	return bits &^ starBit
+}
@@ -0,0 +1,53 @@
+package cron
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		specs    []string
+		expected string
+	}{
+		{[]string{"0 0 9 * * mon-fri,SAT", "0 0 9 ? * 1-6", "0 0 9 * * 6,1,2,3-5"}, "0 0 9 * * 1-6"},
+		{[]string{"0 0/15 * * * *", "0 */15 * * * *", "0 0,15,30,45 * * * *"}, "0 */15 * * * *"},
+		{[]string{"0-59 * * * * *", "* * * * * ?"}, "* * * * * *"},
+		{[]string{"*.0/500 * * * * *", "*.500,0 * * * * *", "0-59.0,500 * * * * *"}, "*.0/500 * * * * *"},
+		{[]string{"@daily", "@midnight", "0 0 0 * * *", "0 0 0 1-31 * *"}, "0 0 0 * * *"},
+		{[]string{"0 0 0 1-31 * 1-5", "0 0 0 * * 0-6"}, "0 0 0 * * *"},
+		{[]string{"0 0 0 1 Jan,jul *", "0 0 0 1 7,1 * "}, "0 0 0 1 1,7 *"},
+		{[]string{"0 5,10-12,11 * * * *"}, "0 5,10-12 * * * *"},
+		{[]string{"0 0 0 1 * Mon"}, "0 0 0 1 * 1"},
+		{[]string{"0 0 0 1 1 * 2030,2031,2032", "0 0 0 1 1 * 2030-2032"}, "0 0 0 1 1 * 2030-2032"},
+		{[]string{"TZ=UTC 0 0 6 * * *", "CRON_TZ=UTC 0 0 6 * * ?"}, "CRON_TZ=UTC 0 0 6 * * *"},
+		{[]string{"@every 90m", "@every 1h30m"}, "@every 1h30m0s"},
+		{[]string{"@every 24h at 03:00", "@every 1440m at 3:00"}, "@every 24h0m0s at 03:00"},
+		{[]string{"@weekday 09:30", "0 30 9 * * MON-FRI"}, "0 30 9 * * 1-5"},
+		{[]string{"@weekend", "@weekend 00:00", "0 0 0 * * SAT,SUN"}, "0 0 0 * * 0,6"},
+		{[]string{"@reboot"}, "@reboot"},
+		{[]string{"@at 2030-01-01T00:00:00Z"}, "@at 2030-01-01T00:00:00Z"},
+		{[]string{"0 0/5 * * * * @between 08:00-18:00"}, "0 */5 * * * * @between 08:00-18:00"},
+	}
+
+	for _, c := range tests {
+		for _, spec := range c.specs {
+			actual, err := Normalize(spec)
+			if err != nil {
+				t.Error(err)
+				continue
+			}
+			if actual != c.expected {
+				t.Errorf("%s: (expected) %q != %q (actual)", spec, c.expected, actual)
+			}
+		}
+	}
+
+	// Specs which differ are not normalized to the same spec.
+	a, _ := Normalize("0 0 0 1 * ?")
+	b, _ := Normalize("0 0 0 1 * 1-6")
+	if a == b {
+		t.Errorf("expected different specs, got %q for both", a)
+	}
+
+	if _, err := Normalize("0 0 25 * * *"); err == nil {
+		t.Error("expected an error normalizing an invalid spec")
+	}
+}
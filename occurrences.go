@@ -0,0 +1,44 @@
+package cron
+
+import "time"
+
+// Occurrences returns the next n activations of the schedule after the given
+// time, in order, e.g. to preview the upcoming runs of a job.  Fewer
+// activations are returned if the schedule does not activate that often.
+func Occurrences(schedule Schedule, from time.Time, n int) []time.Time {
+	var times []time.Time
+	for t := from; len(times) < n; {
+		next := schedule.Next(t)
+		// A schedule which does not advance would activate forever.
+		if next.IsZero() || !next.After(t) {
+			break
+		}
+		times = append(times, next)
+		t = next
+	}
+	return times
+}
+
+// OccurrencesBetween returns the activations of the schedule within
+// [start, end), in order, e.g. to plan a backfill of missed runs.
+func OccurrencesBetween(schedule Schedule, start, end time.Time) []time.Time {
+	var times []time.Time
+	EachOccurrence(schedule, start, end, func(t time.Time) bool {
+		times = append(times, t)
+		return true
+	})
+	return times
+}
+
+// EachOccurrence calls f with each activation of the schedule within
+// [start, end), in order, until f returns false.  Unlike OccurrencesBetween,
+// it does not hold all activations of a long period in memory.
+func EachOccurrence(schedule Schedule, start, end time.Time, f func(time.Time) bool) {
+	for t := start.Add(-time.Nanosecond); ; {
+		next := schedule.Next(t)
+		if next.IsZero() || !next.After(t) || !next.Before(end) || !f(next) {
+			return
+		}
+		t = next
+	}
+}
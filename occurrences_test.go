@@ -0,0 +1,95 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOccurrences(t *testing.T) {
+	tests := []struct {
+		spec     string
+		from     string
+		n        int
+		expected []string
+	}{
+		{"0 30 9 * * MON-FRI", "Fri Jul 6 10:00 2012", 3, []string{"Mon Jul 9 09:30 2012", "Tue Jul 10 09:30 2012", "Wed Jul 11 09:30 2012"}},
+		{"0 0/20 * * * *", "Mon Jul 9 23:30 2012", 2, []string{"Mon Jul 9 23:40 2012", "Tue Jul 10 00:00 2012"}},
+		{"0 0 0 1 1 * 2030-2031", "Mon Jul 9 23:30 2012", 5, []string{"Tue Jan 1 00:00 2030", "Wed Jan 1 00:00 2031"}},
+		{"0 0 0 30 Feb ?", "Mon Jul 9 23:30 2012", 3, nil},
+		{"@hourly", "Mon Jul 9 23:30 2012", 0, nil},
+	}
+	for _, c := range tests {
+		sched, err := Parse(c.spec)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		actual := Occurrences(sched, getTime(c.from), c.n)
+		if len(actual) != len(c.expected) {
+			t.Errorf("%s: (expected) %d occurrences != %d (actual): %v", c.spec, len(c.expected), len(actual), actual)
+			continue
+		}
+		for i, expected := range c.expected {
+			if !actual[i].Equal(getTime(expected)) {
+				t.Errorf("%s, %d: (expected) %v != %v (actual)", c.spec, i, getTime(expected), actual[i])
+			}
+		}
+	}
+
+	// A schedule which does not advance stops the occurrences.
+	at := getTime("Mon Jul 9 23:30 2012")
+	if actual := Occurrences(stuckSchedule{at}, at.Add(-time.Hour), 3); len(actual) != 1 {
+		t.Errorf("(expected) one occurrence != %v (actual)", actual)
+	}
+}
+
+func TestOccurrencesBetween(t *testing.T) {
+	tests := []struct {
+		spec       string
+		start, end string
+		expected   []string
+	}{
+		{"0 0 */6 * * *", "Mon Jul 9 00:00 2012", "Tue Jul 10 00:00 2012", []string{"Mon Jul 9 00:00 2012", "Mon Jul 9 06:00 2012", "Mon Jul 9 12:00 2012", "Mon Jul 9 18:00 2012"}},
+		{"0 0 */6 * * *", "Mon Jul 9 00:00:01 2012", "Mon Jul 9 12:00:01 2012", []string{"Mon Jul 9 06:00 2012", "Mon Jul 9 12:00 2012"}},
+		{"0 30 9 * * MON-FRI", "Sat Jul 7 00:00 2012", "Mon Jul 9 00:00 2012", nil},
+		{"0 0 0 1 1 * 2030", "Mon Jul 9 00:00 2012", "Tue Jan 1 00:00 2030", nil},
+		{"0 0 0 1 1 * 2030", "Mon Jul 9 00:00 2012", "Tue Jan 1 00:00:01 2030", []string{"Tue Jan 1 00:00 2030"}},
+	}
+	for _, c := range tests {
+		sched, err := Parse(c.spec)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		actual := OccurrencesBetween(sched, getTime(c.start), getTime(c.end))
+		if len(actual) != len(c.expected) {
+			t.Errorf("%s: (expected) %d occurrences != %d (actual): %v", c.spec, len(c.expected), len(actual), actual)
+			continue
+		}
+		for i, expected := range c.expected {
+			if !actual[i].Equal(getTime(expected)) {
+				t.Errorf("%s, %d: (expected) %v != %v (actual)", c.spec, i, getTime(expected), actual[i])
+			}
+		}
+	}
+
+	// Returning false stops the iteration.
+	sched, _ := Parse("* * * * * *")
+	var count int
+	EachOccurrence(sched, getTime("Mon Jul 9 00:00 2012"), getTime("Tue Jul 10 00:00 2012"), func(time.Time) bool {
+		count++
+		return count < 3
+	})
+	if count != 3 {
+		t.Errorf("(expected) 3 calls != %d (actual)", count)
+	}
+}
+
+// stuckSchedule activates at the given time, even after it.
+type stuckSchedule struct {
+	at time.Time
+}
+
+func (s stuckSchedule) Next(t time.Time) time.Time {
+	return s.at
+}
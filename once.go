@@ -0,0 +1,34 @@
+package cron
+
+import "time"
+
+// OnceSchedule represents a schedule which activates exactly once, at the given
+// time, e.g. "@at 2025-07-01T03:00:00Z".
+type OnceSchedule struct {
+	At time.Time
+}
+
+// At returns a Schedule that activates once at the given time and never again.
+func At(t time.Time) OnceSchedule {
+	return OnceSchedule{At: t}
+}
+
+// Next returns the time of the activation if it is later than the given time.
+// Otherwise the schedule is over and the zero time is returned.
+func (schedule OnceSchedule) Next(t time.Time) time.Time {
+	if schedule.At.After(t) {
+		return schedule.At
+	}
+	return time.Time{}
+}
+
+// RebootSchedule represents a schedule which activates once, immediately after
+// the Cron has been started, e.g. "@reboot".  An entry added to a running Cron
+// activates immediately.
+type RebootSchedule struct{}
+
+// Next returns the zero time, since the only activation of the schedule is
+// triggered by the start of the Cron.
+func (schedule RebootSchedule) Next(t time.Time) time.Time {
+	return time.Time{}
+}
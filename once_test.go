@@ -0,0 +1,59 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOnceNext(t *testing.T) {
+	tests := []struct {
+		time, at string
+		expected string
+	}{
+		{"Mon Jul 9 14:45 2012", "Mon Jul 9 15:00 2012", "Mon Jul 9 15:00 2012"},
+		{"Mon Jul 9 14:59:59 2012", "Mon Jul 9 15:00 2012", "Mon Jul 9 15:00 2012"},
+		{"Mon Jul 9 14:45 2012", "Tue Jan 1 00:00:00 2013", "Tue Jan 1 00:00:00 2013"},
+
+		// Past activations
+		{"Mon Jul 9 15:00 2012", "Mon Jul 9 15:00 2012", ""},
+		{"Mon Jul 9 15:00:01 2012", "Mon Jul 9 15:00 2012", ""},
+	}
+
+	for _, c := range tests {
+		actual := At(getTime(c.at)).Next(getTime(c.time))
+		expected := getTime(c.expected)
+		if !actual.Equal(expected) {
+			t.Errorf("%s, \"%s\": (expected) %v != %v (actual)", c.time, c.at, expected, actual)
+		}
+	}
+}
+
+func TestParseAt(t *testing.T) {
+	sched, err := Parse("@at 2025-07-01T03:00:00Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := time.Date(2025, time.July, 1, 3, 0, 0, 0, time.UTC)
+	if actual := sched.(OnceSchedule).At; !actual.Equal(expected) {
+		t.Errorf("(expected) %v != %v (actual)", expected, actual)
+	}
+
+	for _, spec := range []string{"@at", "@at tomorrow", "@at 2025-07-01"} {
+		if _, err := Parse(spec); err == nil {
+			t.Error("expected an error parsing: ", spec)
+		}
+	}
+}
+
+func TestParseReboot(t *testing.T) {
+	sched, err := Parse("@reboot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := sched.(RebootSchedule); !ok {
+		t.Fatalf("(expected) RebootSchedule != %T (actual)", sched)
+	}
+	if next := sched.Next(time.Now()); !next.IsZero() {
+		t.Errorf("(expected) zero time != %v (actual)", next)
+	}
+}
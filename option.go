@@ -0,0 +1,116 @@
+package cron
+
+import (
+	"math"
+	"time"
+)
+
+// Option configures a Cron created by New.
+type Option func(*Cron)
+
+// EntryOption configures an entry added to a Cron, e.g. by AddJob.
+type EntryOption func(*Entry)
+
+// WithLocation evaluates the schedules of the entries in the given location,
+// rather than the local time zone.  Entries added with AddJobIn and specs with
+// a time zone keep their own.
+func WithLocation(loc *time.Location) Option {
+	return func(c *Cron) {
+		c.location = loc
+	}
+}
+
+// WithChain wraps the jobs of all entries with the wrappers, where the first
+// one is the outermost, e.g. to log or lock them in one place.
+func WithChain(wrappers ...JobWrapper) Option {
+	return func(c *Cron) {
+		c.chain = NewChain(wrappers...)
+	}
+}
+
+// WithBeforeRun calls the hook before each run of a job starts, once it has
+// been admitted by the limits of its entry and the Cron.
+func WithBeforeRun(hook RunHook) Option {
+	return func(c *Cron) {
+		c.beforeRun = hook
+	}
+}
+
+// WithAfterRun calls the hook after each run of a job has completed, including
+// failed and skipped ones, before the error handlers.
+func WithAfterRun(hook RunHook) Option {
+	return func(c *Cron) {
+		c.afterRun = hook
+	}
+}
+
+// WithClock takes the time from the given clock, e.g. to control it in tests.
+// A Parser given by WithParser takes it as well, for the start of "@every"
+// schedules.
+func WithClock(clock Clock) Option {
+	return func(c *Cron) {
+		c.clock = clock
+	}
+}
+
+// WithLogger logs the decisions of the Cron with the given logger, rather than
+// the DefaultLogger, e.g. VerbosePrintfLogger to log the routine ones too.
+func WithLogger(logger Logger) Option {
+	return func(c *Cron) {
+		c.logger = logger
+	}
+}
+
+// WithMaxConcurrency runs the jobs on a pool of n goroutines, rather than on a
+// goroutine per run, e.g. to bound the connections to a downstream service.
+// Runs beyond the n concurrent ones wait for one to complete, in the order of
+// their priorities and activations.
+func WithMaxConcurrency(n int) Option {
+	return func(c *Cron) {
+		c.maxConcurrency = n
+	}
+}
+
+// WithRateLimit bounds the starts of runs across all entries to the given rate
+// per second, allowing bursts of up to burst starts, e.g. to protect a shared
+// downstream service at the top of the hour.  Runs beyond the rate wait for
+// their turn, and fail with the error of their context if it is done before.
+// Rates which are not positive, or infinite, disable the limit, and bursts of
+// less than one start are rounded up to one.
+func WithRateLimit(perSecond float64, burst int) Option {
+	return func(c *Cron) {
+		if !(perSecond > 0) || math.IsInf(perSecond, 1) {
+			c.limiter = nil
+			return
+		}
+		if burst < 1 {
+			burst = 1
+		}
+		c.limiter = &rateLimiter{rate: perSecond, burst: float64(burst)}
+	}
+}
+
+// WithPanicHandler handles the panics of the jobs with the given handler,
+// rather than logging them with the Logger.  A job which panicked counts as
+// failed.
+func WithPanicHandler(handler PanicHandler) Option {
+	return func(c *Cron) {
+		c.panicHandler = handler
+	}
+}
+
+// WithErrorHandler handles the failed runs of all jobs with the given handler,
+// e.g. to report their errors in one place rather than in every job.
+func WithErrorHandler(handler ErrorHandler) Option {
+	return func(c *Cron) {
+		c.errorHandler = handler
+	}
+}
+
+// WithParser parses the specs given to AddFunc, AddJob and the like with the
+// given parser, e.g. to accept seconds.
+func WithParser(p ScheduleParser) Option {
+	return func(c *Cron) {
+		c.parser = p
+	}
+}
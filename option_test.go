@@ -0,0 +1,28 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOptions(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cron := New(WithLocation(tokyo), WithParser(NewParser(Minute|Hour|Dom|Month|Dow)))
+	if _, err := cron.AddFunc("0 9 * * *", func() {}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cron.AddFunc("0 0 9 * * *", func() {}); err == nil {
+		t.Error("expected the parser to reject six fields")
+	}
+	cron.Start()
+	defer cron.Stop()
+
+	next := cron.Entries()[0].Next
+	if next.Location() != tokyo || next.Hour() != 9 || next.Minute() != 0 {
+		t.Errorf("(expected) 09:00 in Tokyo != %v (actual)", next)
+	}
+}
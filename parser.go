@@ -2,11 +2,292 @@ package cron
 
 import (
 	"fmt"
+	"hash/fnv"
 	"log"
 	"math"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
+)
+
+// ParseOption configures the fields accepted by a Parser.
+type ParseOption int
+
+const (
+	Second          ParseOption = 1 << iota // Seconds field, default 0
+	SecondOptional                          // Optional seconds field, default 0
+	Minute                                  // Minutes field, default 0
+	Hour                                    // Hours field, default 0
+	Dom                                     // Day of month field, default *
+	Month                                   // Month field, default *
+	Dow                                     // Day of week field, default *
+	DowOptional                             // Optional day of week field, default *
+	Year                                    // Year field, default *
+	YearOptional                            // Optional trailing year field, default *
+	Descriptor                              // Allow descriptors such as @monthly, @every 5m
+	QuartzDow                               // Number the days of the week from 1 (SUN) to 7 (SAT)
+	DomDowExclusive                         // Require "?" in exactly one of the day of month and day of week fields
+	Strict                                  // Reject specs which can never be activated, e.g. "0 0 0 30 2 *"
+	DomDowAnd                               // Require both the day of month and the day of week to match, instead of either
+)
+
+// AWS configures a Parser for the cron expressions of Amazon EventBridge:
+// minute, hour, day of month, month, day of week and year.
+const AWS = Minute | Hour | Dom | Month | Dow | Year | QuartzDow | DomDowExclusive
+
+// places lists the fields in the order they appear in a spec.
+var places = []ParseOption{
+	Second,
+	Minute,
+	Hour,
+	Dom,
+	Month,
+	Dow,
+	Year,
+}
+
+// defaults lists the values of the fields which are not part of a spec.
+var defaults = []string{
+	"0",
+	"0",
+	"0",
+	"*",
+	"*",
+	"*",
+	"*",
+}
+
+// Parser parses cron specs into schedules, accepting the fields it has been
+// configured with.
+type Parser struct {
+	options ParseOption
+
+	// hashKey is the key from which the values of the hash tokens ("H") are
+	// derived.
+	hashKey string
+
+	// descriptors maps the names of custom descriptors to their schedules.
+	descriptors map[string]Schedule
+
+	// monthNames and dowNames map additional names of the months and days of
+	// the week to their values, counting the days from Sunday as 0.
+	monthNames, dowNames map[string]uint
+
+	// gap and overlap are the policies of the parsed schedules for the start
+	// and the end of daylight saving time.
+	gap     GapPolicy
+	overlap OverlapPolicy
+
+	// limits bounds the accepted specs.
+	limits Limits
+
+	// clock is the source of the current time, from which "@every" schedules
+	// start, or nil for the SystemClock.
+	clock Clock
+}
+
+// Limits bounds the specs accepted by a parser, e.g. if they are supplied by
+// untrusted users.  Limits which are zero do not apply.
+type Limits struct {
+	MaxLength int // Maximum length of a spec in bytes
+	MaxItems  int // Maximum number of comma separated items of a field
+	MaxStep   int // Maximum step of a range, e.g. 15 in "*/15"
+}
+
+// DefaultLimits are limits for specs supplied by untrusted users, which are
+// met by any spec written by hand.
+var DefaultLimits = Limits{
+	MaxLength: 256,
+	MaxItems:  60,
+	MaxStep:   999,
+}
+
+// NewParser returns a Parser accepting the fields given by the options.  At
+// most one of SecondOptional and DowOptional may be given.  A YearOptional
+// field is given either in addition to all other fields, or as the last field
+// of a spec if it can only be a year (1970 or later).
+//
+// Examples
+//
+//	// Standard parser without descriptors
+//	specParser := NewParser(Minute | Hour | Dom | Month | Dow)
+//	sched, err := specParser.Parse("0 0 15 */3 *")
+//
+//	// Same as above, just excludes time fields
+//	subsParser := NewParser(Dom | Month | Dow)
+//	sched, err := subsParser.Parse("15 */3 *")
+//
+//	// Same as above, just makes Dow optional
+//	subsParser := NewParser(Dom | Month | DowOptional)
+//	sched, err := subsParser.Parse("15 */3")
+func NewParser(options ParseOption) Parser {
+	return Parser{options: options}
+}
+
+// WithHashKey returns a copy of the parser, which derives the values of hash
+// tokens ("H") from the given key.  Jobs with the same spec but different keys
+// are spread over the allowed range, while the schedule of a single job stays
+// the same across restarts.
+func (p Parser) WithHashKey(key string) Parser {
+	p.hashKey = key
+	return p
+}
+
+// WithDescriptor returns a copy of the parser, which resolves the given custom
+// descriptor to the schedule, e.g.
+//
+//	p := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow).
+//		WithDescriptor("@quarterly", quarterly)
+//
+// The name is prefixed with "@" if it does not start with it already.  Custom
+// descriptors take precedence over the predefined ones, and are accepted even
+// if the parser has not been configured with the Descriptor option.
+func (p Parser) WithDescriptor(name string, schedule Schedule) Parser {
+	if !strings.HasPrefix(name, "@") {
+		name = "@" + name
+	}
+	descriptors := make(map[string]Schedule, len(p.descriptors)+1)
+	for n, s := range p.descriptors {
+		descriptors[n] = s
+	}
+	descriptors[name] = schedule
+	p.descriptors = descriptors
+	return p
+}
+
+// WithNames returns a copy of the parser, which accepts the names of the months
+// and days of the week of the given locales, in any case, e.g.
+//
+//	p := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow).
+//		WithNames(cron.German, cron.French)
+//	sched, err := p.Parse("30 6 * janvier Montag-Freitag")
+//
+// A custom name table may be given as a Locale with only the Weekdays and
+// Months.  The English abbreviations and names are always accepted and take
+// precedence.
+func (p Parser) WithNames(locales ...*Locale) Parser {
+	monthNames := make(map[string]uint, len(p.monthNames))
+	for n, v := range p.monthNames {
+		monthNames[n] = v
+	}
+	dowNames := make(map[string]uint, len(p.dowNames))
+	for n, v := range p.dowNames {
+		dowNames[n] = v
+	}
+	for _, l := range locales {
+		addNames(monthNames, l.Months[:], 1)
+		addNames(dowNames, l.Weekdays[:], 0)
+	}
+	p.monthNames, p.dowNames = monthNames, dowNames
+	return p
+}
+
+// WithGapPolicy returns a copy of the parser, whose schedules follow the policy
+// at wall clock times which are skipped at the start of daylight saving time,
+// e.g. to run a job scheduled at 02:30 at 03:00 instead of skipping it.
+func (p Parser) WithGapPolicy(policy GapPolicy) Parser {
+	p.gap = policy
+	return p
+}
+
+// WithClock returns a copy of the parser, whose "@every" schedules start from
+// the current time of the clock, e.g. a fake clock in tests.
+func (p Parser) WithClock(clock Clock) Parser {
+	p.clock = clock
+	return p
+}
+
+// now returns the current time of the clock of the parser.
+func (p Parser) now() time.Time {
+	if p.clock == nil {
+		return time.Now()
+	}
+	return p.clock.Now()
+}
+
+// WithLimits returns a copy of the parser, which rejects specs exceeding the
+// limits with a *ParseError whose Limit is set, e.g.
+//
+//	p := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow).
+//		WithLimits(cron.DefaultLimits)
+func (p Parser) WithLimits(limits Limits) Parser {
+	p.limits = limits
+	return p
+}
+
+// checkLimits panics with a *LimitError if the field exceeds the limits on the
+// number of items or the steps.
+func (p Parser) checkLimits(field string) {
+	items := strings.Split(field, ",")
+	if max := p.limits.MaxItems; max > 0 && len(items) > max {
+		panicLimit("MaxItems", len(items), max)
+	}
+	if p.limits.MaxStep <= 0 {
+		return
+	}
+	for _, item := range items {
+		i := strings.LastIndex(item, "/")
+		if i < 0 {
+			continue
+		}
+		// Invalid steps are reported by the parsing of the field.
+		if step, err := strconv.Atoi(item[i+1:]); err == nil && step > p.limits.MaxStep {
+			panicLimit("MaxStep", step, p.limits.MaxStep)
+		}
+	}
+}
+
+// WithOverlapPolicy returns a copy of the parser, whose schedules follow the
+// policy at wall clock times which are repeated at the end of daylight saving
+// time, e.g. to run a billing job scheduled at 01:30 only once.
+func (p Parser) WithOverlapPolicy(policy OverlapPolicy) Parser {
+	p.overlap = policy
+	return p
+}
+
+// addNames adds the lower case names to the map, numbered from the first value.
+// Names which are in the map already are kept.
+func addNames(m map[string]uint, names []string, first uint) {
+	for i, name := range names {
+		name = strings.ToLower(name)
+		if _, ok := m[name]; !ok && name != "" {
+			m[name] = first + uint(i)
+		}
+	}
+}
+
+// withNames returns the bounds accepting the additional names as well, whose
+// values are offset by the given amount.
+func withNames(r bounds, names map[string]uint, offset uint) bounds {
+	if len(names) == 0 {
+		return r
+	}
+	merged := make(map[string]uint, len(r.names)+len(names))
+	for n, v := range r.names {
+		merged[n] = v
+	}
+	for n, v := range names {
+		if _, ok := merged[n]; !ok {
+			merged[n] = v + offset
+		}
+	}
+	r.names = merged
+	return r
+}
+
+func init() {
+	// The full English names are accepted as well as the abbreviations.
+	addNames(months.names, English.Months[:], 1)
+	addNames(dow.names, English.Weekdays[:], 0)
+	addNames(quartzDow.names, English.Weekdays[:], 1)
+}
+
+var (
+	defaultParser  = NewParser(Second | Minute | Hour | Dom | Month | DowOptional | YearOptional | Descriptor)
+	standardParser = NewParser(SecondOptional | Minute | Hour | Dom | Month | Dow | YearOptional | Descriptor)
+	quartzParser   = NewParser(Second | Minute | Hour | Dom | Month | Dow | YearOptional | QuartzDow | Descriptor)
+	awsParser      = NewParser(AWS)
 )
 
 // Parse returns a new crontab schedule representing the given spec.
@@ -14,43 +295,644 @@ import (
 //
 // It accepts
 //   - Full crontab specs, e.g. "* * * * * ?"
+//   - Crontab specs with a year, e.g. "0 0 0 1 1 * 2030"
+//   - Descriptors, e.g. "@midnight", "@every 1h30m"
+//
+// The spec may be prefixed with "CRON_TZ=<location>" or "TZ=<location>", e.g.
+// "CRON_TZ=Europe/Zurich 0 0 6 * * *", to evaluate the schedule in the given
+// location instead of the location of the time passed to Next.  This applies
+// to all Parse functions.
+func Parse(spec string) (Schedule, error) {
+	return defaultParser.Parse(spec)
+}
+
+// ParseStandard returns a new crontab schedule representing the given spec in
+// the standard crontab layout (minute, hour, day of month, month, day of week).
+// An additional leading seconds field may be given, so that both 5 and 6 field
+// expressions are accepted.
+// It returns a descriptive error if the spec is not valid.
+//
+// It accepts
+//   - Standard crontab specs, e.g. "*/5 * * * *"
+//   - Crontab specs with seconds, e.g. "*/10 * * * * *"
+//   - Crontab specs with a year, e.g. "0 0 1 1 * 2030", "0 0 0 1 1 * 2030"
+//   - Descriptors, e.g. "@midnight", "@every 1h30m"
+func ParseStandard(spec string) (Schedule, error) {
+	return standardParser.Parse(spec)
+}
+
+// ParseWithHashKey returns a new crontab schedule representing the given spec,
+// like Parse.  The values of hash tokens ("H") in the spec are derived from the
+// given key, e.g. the name of the job, so that jobs with the same spec but
+// different keys are spread over the allowed range, while the schedule of a
+// single job stays the same across restarts.
+func ParseWithHashKey(spec, key string) (Schedule, error) {
+	return defaultParser.WithHashKey(key).Parse(spec)
+}
+
+// ParseQuartz returns a new crontab schedule representing the given spec in the
+// layout used by the Quartz scheduler: second, minute, hour, day of month, month,
+// day of week and an optional year.  Days of the week are numbered from 1 (SUN)
+// to 7 (SAT).
+// It returns a descriptive error if the spec is not valid.
+//
+// It accepts
+//   - Quartz cron expressions, e.g. "0 15 10 ? * MON-FRI", "0 0 12 1 1 ? 2030"
 //   - Descriptors, e.g. "@midnight", "@every 1h30m"
-func Parse(spec string) (_ Schedule, err error) {
+func ParseQuartz(spec string) (Schedule, error) {
+	return quartzParser.Parse(spec)
+}
+
+// ParseAWS returns a new schedule representing the given schedule expression of
+// an Amazon EventBridge rule.  Cron expressions have the fields minute, hour,
+// day of month, month, day of week and year, where exactly one of the day of
+// month and the day of week must be "?".  Days of the week are numbered from
+// 1 (SUN) to 7 (SAT).
+// It returns a descriptive error if the expression is not valid.
+//
+// It accepts
+//   - Cron expressions, e.g. "cron(0 12 * * ? *)", "0 18 ? * MON-FRI *"
+//   - Rate expressions, e.g. "rate(5 minutes)", "rate(1 day)"
+func ParseAWS(expr string) (_ Schedule, err error) {
+	orig := expr
+	expr = strings.TrimSpace(expr)
+	offset := strings.Index(orig, expr)
+
+	// Report errors with the offsets in the given expression.
+	parse := func(spec string, index int) (Schedule, error) {
+		schedule, err := awsParser.Parse(spec)
+		if e, ok := err.(*ParseError); ok {
+			e.Spec = orig
+			e.Offset += offset + index
+		}
+		return schedule, err
+	}
+
+	switch {
+	case strings.HasPrefix(expr, "cron(") && strings.HasSuffix(expr, ")"):
+		return parse(expr[len("cron("):len(expr)-1], len("cron("))
+	case strings.HasPrefix(expr, "rate(") && strings.HasSuffix(expr, ")"):
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = fmt.Errorf("%v", recovered)
+			}
+		}()
+		return parseRate(expr[len("rate(") : len(expr)-1]), nil
+	}
+	return parse(expr, 0)
+}
+
+// rateUnits maps the units of rate expressions to their durations.
+var rateUnits = map[string]time.Duration{
+	"minute":  time.Minute,
+	"minutes": time.Minute,
+	"hour":    time.Hour,
+	"hours":   time.Hour,
+	"day":     24 * time.Hour,
+	"days":    24 * time.Hour,
+}
+
+// parseRate returns the schedule of a rate expression "value unit", e.g.
+// "5 minutes".
+func parseRate(rate string) ConstantDelaySchedule {
+	parts := strings.Fields(rate)
+	if len(parts) != 2 {
+		log.Panicf("Expected rate(value unit): %s", rate)
+	}
+	value := mustParseInt(parts[0])
+	unit, ok := rateUnits[parts[1]]
+	if !ok {
+		log.Panicf("Unknown unit %s: %s", parts[1], rate)
+	}
+	if value == 0 {
+		log.Panicf("Rate must be positive: %s", rate)
+	}
+	return Every(time.Duration(value) * unit)
+}
+
+// ValidateSpec checks the syntax and the ranges of the given spec, as accepted
+// by Parse, e.g. to lint configurations before they are deployed.  It parses
+// the spec and discards the resulting schedule; no Cron is needed, and the
+// check always terminates.
+// It returns a *ParseError if the spec is not valid.
+func ValidateSpec(spec string) error {
+	return defaultParser.Validate(spec)
+}
+
+// Validate checks the syntax and the ranges of the given spec, using the fields
+// the parser has been configured with, by parsing it and discarding the
+// resulting schedule.
+// It returns a *ParseError if the spec is not valid.
+func (p Parser) Validate(spec string) error {
+	_, err := p.Parse(spec)
+	return err
+}
+
+// Parse returns a new crontab schedule representing the given spec, using the
+// fields the parser has been configured with.
+// It returns a *ParseError if the spec is not valid.
+func (p Parser) Parse(spec string) (_ Schedule, err error) {
 	// Convert panics into errors
+	orig := spec
 	defer func() {
 		if recovered := recover(); recovered != nil {
-			err = fmt.Errorf("%v", recovered)
+			err = newParseError(orig, recovered)
 		}
 	}()
 
-	if spec[0] == '@' {
-		return parseDescriptor(spec), nil
+	if max := p.limits.MaxLength; max > 0 && len(spec) > max {
+		panicLimit("MaxLength", len(spec), max)
 	}
 
-	// Split on whitespace.  We require 5 or 6 fields.
-	// (second) (minute) (hour) (day of month) (month) (day of week, optional)
-	fields := strings.Fields(spec)
-	if len(fields) != 5 && len(fields) != 6 {
-		log.Panicf("Expected 5 or 6 fields, found %d: %s", len(fields), spec)
+	// Extract the location from a leading "CRON_TZ=" or "TZ=" option.
+	var loc *time.Location
+	if strings.HasPrefix(spec, "CRON_TZ=") || strings.HasPrefix(spec, "TZ=") {
+		var name string
+		if i := strings.IndexAny(spec, " \t"); i >= 0 {
+			name, spec = spec[:i], strings.TrimSpace(spec[i:])
+		} else {
+			name, spec = spec, ""
+		}
+		name = name[strings.Index(name, "=")+1:]
+		if loc, err = time.LoadLocation(name); err != nil {
+			log.Panicf("Provided bad location %s: %v", name, err)
+		}
 	}
 
-	// If a sixth field is not provided (DayOfWeek), then it is equivalent to star.
-	if len(fields) == 5 {
-		fields = append(fields, "*")
+	// Extract a trailing "@between" window and apply it to the schedule.
+	if i := strings.Index(spec, " @between"); i >= 0 {
+		window := parseWindow(spec[i+1:])
+		schedule, err := p.Parse(strings.TrimSpace(spec[:i]))
+		if err != nil {
+			// Locate the error within the whole spec.
+			if e, ok := err.(*ParseError); ok {
+				e.Spec = orig
+				e.Offset += strings.Index(orig, strings.TrimSpace(spec[:i]))
+			}
+			return nil, err
+		}
+		window.Schedule = schedule
+		window.Location = loc
+		if s, ok := schedule.(*SpecSchedule); ok && loc != nil {
+			s.Location = loc
+		}
+		return window, nil
+	}
+
+	if len(spec) == 0 {
+		log.Panicf("Empty spec string")
 	}
 
-	schedule := &SpecSchedule{
-		Second: getField(fields[0], seconds),
-		Minute: getField(fields[1], minutes),
-		Hour:   getField(fields[2], hours),
-		Dom:    getField(fields[3], dom),
-		Month:  getField(fields[4], months),
-		Dow:    getField(fields[5], dow),
+	if schedule, ok := p.descriptors[spec]; ok {
+		// The registered schedule is shared, so set the location on a copy.
+		if s, ok := schedule.(*SpecSchedule); ok && loc != nil {
+			c := *s
+			c.Location = loc
+			return &c, nil
+		}
+		return schedule, nil
+	}
+
+	if spec[0] == '@' {
+		if p.options&Descriptor == 0 {
+			log.Panicf("Descriptors are not accepted: %s", spec)
+		}
+		schedule := p.parseDescriptor(spec)
+		if s, ok := schedule.(*SpecSchedule); ok {
+			s.Location = loc
+			s.Gap, s.Overlap = p.gap, p.overlap
+		}
+		if s, ok := schedule.(ConstantDelaySchedule); ok && s.Aligned && !s.Keyed && loc != nil {
+			s.StartTime = alignedStart(loc)
+			schedule = s
+		}
+		if s, ok := schedule.(DailySchedule); ok {
+			s.Location = loc
+			schedule = s
+		}
+		if s, ok := schedule.(IntervalSchedule); ok && loc != nil {
+			s.Start = s.Start.In(loc)
+			schedule = s
+		}
+		return schedule, nil
+	}
+
+	// Split on whitespace.
+	// (second) (minute) (hour) (day of month) (month) (day of week) (year)
+	fields, positions := p.normalizeFields(strings.Fields(spec), spec)
+	if p.options&DomDowExclusive > 0 && (fields[3] == "?") == (fields[5] == "?") {
+		log.Panicf("Expected \"?\" in exactly one of day of month and day of week: %s", spec)
+	}
+	fieldBounds := []bounds{seconds, minutes, hours, dom, months, dow, years}
+	if p.options&QuartzDow > 0 {
+		fieldBounds[5] = quartzDow
+	}
+	fieldBounds[4] = withNames(fieldBounds[4], p.monthNames, 0)
+	fieldBounds[5] = withNames(fieldBounds[5], p.dowNames, fieldBounds[5].min)
+
+	schedule := &SpecSchedule{Location: loc, Gap: p.gap, Overlap: p.overlap}
+	setters := []func(field string){
+		func(field string) { parseSeconds(field, schedule) },
+		func(field string) { schedule.Minute = getField(field, minutes) },
+		func(field string) { schedule.Hour = getField(field, hours) },
+		func(field string) { parseDom(field, schedule) },
+		func(field string) { schedule.Month = getField(field, fieldBounds[4]) },
+		func(field string) { parseDow(field, fieldBounds[5], schedule) },
+		func(field string) { schedule.Year = getYears(field) },
+	}
+	offsets, base := fieldOffsets(spec), strings.Index(orig, spec)
+	for i, set := range setters {
+		if positions[i] < 0 {
+			set(fields[i])
+			continue
+		}
+		p.parseField(fields[i], i, fieldBounds[i], set, positions[i], base+offsets[positions[i]])
+	}
+	schedule.dayAnd = p.options&DomDowAnd > 0
+	if p.options&QuartzDow > 0 {
+		schedule.Dow = quartzShift(schedule.Dow)
+		schedule.lastDow = quartzShift(schedule.lastDow)
+		schedule.nthDow = quartzShift(schedule.nthDow)
+	}
+	if p.options&Strict > 0 && !schedule.activates() {
+		log.Panicf("Spec can never be activated: %s", spec)
 	}
 
 	return schedule, nil
 }
 
+// parseField expands the hash tokens of the field at the given index and sets
+// it on the schedule.  A panic is converted into a *ParseError locating the
+// field at its position and offset in the spec.
+func (p Parser) parseField(field string, index int, r bounds, set func(string), position, offset int) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			panic(fieldError(recovered, field, index, r, position, offset))
+		}
+	}()
+	p.checkLimits(field)
+	if index < len(places)-1 {
+		// The hash token does not apply to the milliseconds of the seconds.
+		var millis string
+		if i := strings.Index(field, "."); index == 0 && i >= 0 {
+			field, millis = field[:i], field[i:]
+		}
+		field = expandRandom(p.expandHash(field, index, r), index, r) + millis
+	}
+	set(field)
+}
+
+// fieldOffsets returns the byte offsets of the whitespace separated fields of
+// the spec.
+func fieldOffsets(spec string) []int {
+	var (
+		offsets []int
+		inField bool
+	)
+	for i, r := range spec {
+		space := unicode.IsSpace(r)
+		if !space && !inField {
+			offsets = append(offsets, i)
+		}
+		inField = !space
+	}
+	return offsets
+}
+
+// normalizeFields returns the fields expanded to the full layout of second,
+// minute, hour, day of month, month, day of week and year.  Fields which are
+// not part of the options, or optional fields which have been omitted, are
+// filled in with their defaults.  It also returns the positions of the fields
+// in the spec, which are -1 for the defaults.
+func (p Parser) normalizeFields(fields []string, spec string) ([]string, []int) {
+	// Validate optionals & add their field to options
+	options := p.options
+	optionals := 0
+	if options&SecondOptional > 0 {
+		options |= Second
+		optionals++
+	}
+	if options&DowOptional > 0 {
+		options |= Dow
+		optionals++
+	}
+	if optionals > 1 {
+		log.Panicf("Multiple optionals may not be configured")
+	}
+	if options&YearOptional > 0 {
+		options |= Year
+	}
+
+	// Figure out how many fields we need, not counting the year.
+	max := 0
+	for _, place := range places[:len(places)-1] {
+		if options&place > 0 {
+			max++
+		}
+	}
+	min := max - optionals
+
+	// Validate number of fields
+	minCount, maxCount := min, max
+	if options&Year > 0 {
+		maxCount++
+		if options&YearOptional == 0 {
+			minCount++
+		}
+	}
+	if count := len(fields); count < minCount || count > maxCount {
+		if minCount == maxCount {
+			log.Panicf("Expected %d fields, found %d: %s", minCount, count, spec)
+		}
+		log.Panicf("Expected %d to %d fields, found %d: %s", minCount, maxCount, count, spec)
+	}
+
+	// The year is given either in addition to all other fields, or as the last
+	// field of a shorter spec if it can only be a year.
+	positions := make([]int, len(fields))
+	for i := range positions {
+		positions[i] = i
+	}
+	year, yearPosition := defaults[len(defaults)-1], -1
+	if options&Year > 0 {
+		last := len(fields) - 1
+		if options&YearOptional == 0 || len(fields) == maxCount || len(fields) > min && isYears(fields[last]) {
+			year, fields = fields[last], fields[:last]
+			yearPosition, positions = positions[last], positions[:last]
+		}
+	}
+
+	// Populate the optional field if not provided
+	if min < max && len(fields) == min {
+		switch {
+		case options&DowOptional > 0:
+			fields = append(fields, defaults[5])
+			positions = append(positions, -1)
+		case options&SecondOptional > 0:
+			fields = append([]string{defaults[0]}, fields...)
+			positions = append([]int{-1}, positions...)
+		}
+	}
+
+	// Populate all fields not part of the options with their defaults
+	n := 0
+	expandedFields := make([]string, len(places))
+	expandedPositions := make([]int, len(places))
+	copy(expandedFields, defaults)
+	for i, place := range places[:len(places)-1] {
+		expandedPositions[i] = -1
+		if options&place > 0 {
+			expandedFields[i] = fields[n]
+			expandedPositions[i] = positions[n]
+			n++
+		}
+	}
+	expandedFields[len(places)-1] = year
+	expandedPositions[len(places)-1] = yearPosition
+	return expandedFields, expandedPositions
+}
+
+// isYears returns true if the field starts with a year, which is beyond the
+// range of all other fields.
+func isYears(field string) bool {
+	end := strings.IndexFunc(field, func(r rune) bool { return r < '0' || r > '9' })
+	if end < 0 {
+		end = len(field)
+	}
+	year, err := strconv.Atoi(field[:end])
+	return err == nil && year >= int(years.min)
+}
+
+// expandHash returns the field with its hash tokens replaced by the values they
+// stand for.  The values are derived from the hash key of the parser and the
+// position of the field.  A hash token is one of:
+//   - "H" for a single value within the bounds
+//   - "H/n" for every n-th value, starting at a value below n
+//   - "H(a-b)" or "H(a-b)/n" for the same within the range a-b
+//
+// In the day of month field, "H" is limited to the days 1-28, which exist in
+// every month.
+func (p Parser) expandHash(field string, index int, r bounds) string {
+	if !strings.Contains(field, "H") {
+		return field
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(p.hashKey))
+	h.Write([]byte{byte(index)})
+	sum := uint(h.Sum32())
+
+	ranges := strings.Split(field, ",")
+	for i, expr := range ranges {
+		if !strings.HasPrefix(expr, "H") {
+			continue
+		}
+
+		min, max, rest := r.min, r.max, expr[1:]
+		if index == 3 {
+			max = 28
+		}
+		if strings.HasPrefix(rest, "(") {
+			end := strings.Index(rest, ")")
+			if end < 0 {
+				log.Panicf("Missing closing parenthesis: %s", expr)
+			}
+			min, max, _, _ = parseRange(rest[1:end], r)
+			rest = rest[end+1:]
+		}
+
+		switch {
+		case rest == "":
+			ranges[i] = strconv.Itoa(int(min + sum%(max-min+1)))
+		case strings.HasPrefix(rest, "/"):
+			step := mustParseInt(rest[1:])
+			if step == 0 {
+				log.Panicf("Step of zero not allowed: %s", expr)
+			}
+			offset := sum % step
+			if min+offset > max {
+				offset %= max - min + 1
+			}
+			ranges[i] = fmt.Sprintf("%d-%d/%d", min+offset, max, step)
+		default:
+			log.Panicf("Unexpected characters after hash token: %s", expr)
+		}
+	}
+	return strings.Join(ranges, ",")
+}
+
+// expandRandom replaces the random tokens ("~") of the field by a value picked
+// at random within the range of the field, or within the given bounds, e.g.
+// "0~29".  An omitted bound is the minimum or maximum of the field.  As for the
+// hash token, the day of month is picked within 1-28 unless bounded.
+func expandRandom(field string, index int, r bounds) string {
+	if !strings.Contains(field, "~") {
+		return field
+	}
+
+	ranges := strings.Split(field, ",")
+	for i, expr := range ranges {
+		lowAndHigh := strings.Split(expr, "~")
+		switch {
+		case len(lowAndHigh) == 1:
+			continue
+		case len(lowAndHigh) > 2:
+			log.Panicf("Too many tildes: %s", expr)
+		case strings.ContainsAny(expr, "-/"):
+			log.Panicf("Unexpected range or step with random token: %s", expr)
+		}
+
+		low, high := lowAndHigh[0], lowAndHigh[1]
+		if low == "" {
+			low = strconv.Itoa(int(r.min))
+		}
+		if high == "" {
+			high = strconv.Itoa(int(r.max))
+			if index == 3 {
+				high = "28"
+			}
+		}
+		min, max, _, _ := parseRange(low+"-"+high, r)
+		ranges[i] = strconv.Itoa(int(min) + int(randomInt63n(int64(max-min+1))))
+	}
+	return strings.Join(ranges, ",")
+}
+
+// parseDaily returns the schedule of the descriptor "@every <duration> at HH:MM",
+// whose duration is a multiple of 24 hours.
+func parseDaily(duration, clock, spec string) DailySchedule {
+	d, err := parseDuration(strings.TrimSpace(duration))
+	if err != nil {
+		log.Panicf("Failed to parse duration %s: %s", spec, err)
+	}
+	if d <= 0 || d%(24*time.Hour) != 0 {
+		log.Panicf("Expected a multiple of 24h before the time of the day: %s", spec)
+	}
+	return EveryDaysAt(int(d/(24*time.Hour)), parseTimeOfDay(strings.TrimSpace(clock), spec))
+}
+
+// parseCalendarPeriod returns the period of a calendar interval, which starts
+// with a number of years "y" and months "mo", e.g. "1mo" or "1y6mo", optionally
+// followed by a duration.  It returns false if the interval has neither years
+// nor months.
+func parseCalendarPeriod(value, spec string) (p Period, ok bool) {
+	rest := value
+	for _, unit := range []string{"y", "mo"} {
+		i := strings.Index(rest, unit)
+		if i < 0 {
+			continue
+		}
+		if i == 0 || strings.Trim(rest[:i], "0123456789") != "" {
+			log.Panicf("Failed to parse calendar interval %s", spec)
+		}
+		if unit == "y" {
+			p.Years = int(mustParseInt(rest[:i]))
+		} else {
+			p.Months = int(mustParseInt(rest[:i]))
+		}
+		rest, ok = rest[i+len(unit):], true
+	}
+	if !ok {
+		return p, false
+	}
+	if rest != "" {
+		d, err := parseDuration(rest)
+		if err != nil {
+			log.Panicf("Failed to parse calendar interval %s: %s", spec, err)
+		}
+		p.Duration = d
+	}
+	if p.approx() <= 0 {
+		log.Panicf("Calendar interval must be positive: %s", spec)
+	}
+	return p, true
+}
+
+// durationUnits are the units accepted by parseDuration in addition to the ones
+// of time.ParseDuration, largest first.
+var durationUnits = []struct {
+	name string
+	unit time.Duration
+}{
+	{"w", 7 * 24 * time.Hour},
+	{"d", 24 * time.Hour},
+}
+
+// parseDuration parses a duration like time.ParseDuration, which may start with
+// a number of weeks "w" and days "d" of 24 hours, e.g. "1d", "2d12h" or "1w".
+func parseDuration(s string) (time.Duration, error) {
+	var (
+		d    time.Duration
+		rest = s
+		neg  = strings.HasPrefix(s, "-")
+	)
+	if neg || strings.HasPrefix(s, "+") {
+		rest = s[1:]
+	}
+	found := false
+	for _, u := range durationUnits {
+		i := strings.Index(rest, u.name)
+		if i < 0 {
+			continue
+		}
+		value := rest[:i]
+		if value == "" || strings.Trim(value, "0123456789.") != "" {
+			return 0, fmt.Errorf("Invalid duration %q", s)
+		}
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return 0, fmt.Errorf("Invalid duration %q", s)
+		}
+		d += time.Duration(n * float64(u.unit))
+		rest, found = rest[i+len(u.name):], true
+	}
+	if !found {
+		return time.ParseDuration(s)
+	}
+	if rest != "" {
+		// The sign applies to the whole duration.
+		if strings.HasPrefix(rest, "-") || strings.HasPrefix(rest, "+") {
+			return 0, fmt.Errorf("Invalid duration %q", s)
+		}
+		smaller, err := time.ParseDuration(rest)
+		if err != nil {
+			return 0, fmt.Errorf("Invalid duration %q", s)
+		}
+		d += smaller
+	}
+	if neg {
+		d = -d
+	}
+	return d, nil
+}
+
+// parseWindow returns the window given by the expression
+// "@between HH:MM-HH:MM", without a schedule.
+func parseWindow(expr string) WindowSchedule {
+	const between = "@between "
+	if !strings.HasPrefix(expr, between) {
+		log.Panicf("Expected @between HH:MM-HH:MM: %s", expr)
+	}
+	startAndEnd := strings.Split(strings.TrimSpace(expr[len(between):]), "-")
+	if len(startAndEnd) != 2 {
+		log.Panicf("Expected @between HH:MM-HH:MM: %s", expr)
+	}
+	return WindowSchedule{
+		Start: parseTimeOfDay(startAndEnd[0], expr),
+		End:   parseTimeOfDay(startAndEnd[1], expr),
+	}
+}
+
+// parseTimeOfDay returns the time elapsed since midnight for the given value
+// in the format HH:MM, which is part of the given expression.
+func parseTimeOfDay(value, expr string) time.Duration {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		log.Panicf("Failed to parse time of day %s: %s", expr, err)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+}
+
 // getField returns an Int with the bits set representing all of the times that
 // the field represents.  A "field" is a comma-separated list of "ranges".
 func getField(field string, r bounds) uint64 {
@@ -63,22 +945,170 @@ func getField(field string, r bounds) uint64 {
 	return bits
 }
 
+// parseDom sets the day of month of the schedule from the given field.  Besides
+// the regular ranges, the field may contain the special tokens:
+//   - "L" for the last day of the month
+//   - "LW" for the last weekday (Monday to Friday) of the month
+//   - "nW" for the weekday nearest to day n, e.g. "15W"
+func parseDom(field string, s *SpecSchedule) {
+	ranges := strings.FieldsFunc(field, func(r rune) bool { return r == ',' })
+	for _, expr := range ranges {
+		switch {
+		case strings.EqualFold(expr, "L"):
+			s.lastDom = true
+		case strings.EqualFold(expr, "LW"):
+			s.lastWeekdayDom = true
+		case len(expr) > 1 && strings.HasSuffix(strings.ToUpper(expr), "W"):
+			day := mustParseInt(expr[:len(expr)-1])
+			if day < dom.min || day > dom.max {
+				log.Panicf("Day of month (%d) not within %d-%d: %s", day, dom.min, dom.max, expr)
+			}
+			s.weekdayDom |= 1 << day
+		default:
+			s.Dom |= getRange(expr, dom)
+		}
+	}
+}
+
+// parseDow sets the day of week of the schedule from the given field, using
+// the given bounds for the weekday numbers.  Besides the regular ranges, the
+// field may contain the special tokens:
+//   - "nL" for the last weekday n of the month, e.g. "5L" for the last Friday
+//   - "n#k" for the k-th weekday n of the month, e.g. "2#3" for the third Tuesday
+func parseDow(field string, r bounds, s *SpecSchedule) {
+	ranges := strings.FieldsFunc(field, func(r rune) bool { return r == ',' })
+	for _, expr := range ranges {
+		switch {
+		case len(expr) > 1 && strings.HasSuffix(strings.ToUpper(expr), "L"):
+			s.lastDow |= 1 << getWeekday(expr[:len(expr)-1], r, expr)
+		case strings.Contains(expr, "#"):
+			dayAndNth := strings.Split(expr, "#")
+			if len(dayAndNth) != 2 {
+				log.Panicf("Too many hashes: %s", expr)
+			}
+			day := getWeekday(dayAndNth[0], r, expr)
+			nth := mustParseInt(dayAndNth[1])
+			if nth < 1 || nth > 5 {
+				log.Panicf("Occurrence of weekday (%d) not within 1-5: %s", nth, expr)
+			}
+			s.nthDow |= 1 << (8*(nth-1) + day)
+		default:
+			s.Dow |= getRange(expr, r)
+		}
+	}
+}
+
+// getWeekday returns the single (possibly-named) weekday contained in value,
+// which is part of the given expression.
+func getWeekday(value string, r bounds, expr string) uint {
+	day := parseIntOrName(value, r.names)
+	if day < r.min || day > r.max {
+		log.Panicf("Day of week (%d) not within %d-%d: %s", day, r.min, r.max, expr)
+	}
+	return day
+}
+
+// quartzShift shifts day of week bits numbered from 1 (Sunday) to 7 (Saturday),
+// as done by the Quartz scheduler, to the regular numbering from 0 (Sunday) to
+// 6 (Saturday).
+func quartzShift(bits uint64) uint64 {
+	return (bits&^starBit)>>1 | bits&starBit
+}
+
+// parseSeconds sets the seconds of the schedule from the field, which may be
+// followed by "." and the milliseconds within each of the seconds, e.g.
+// "*.0/500" for every 500 milliseconds, or "0.250" for a quarter second past
+// the minute.
+func parseSeconds(field string, s *SpecSchedule) {
+	if i := strings.Index(field, "."); i >= 0 {
+		s.millis = getMillis(field[i+1:])
+		field = field[:i]
+	}
+	s.Second = getField(field, seconds)
+}
+
+// getMillis returns the milliseconds of the list of ranges, in ascending
+// order.  The start of the second alone is returned as nil.
+func getMillis(field string) []int {
+	var (
+		set    = make(map[int]bool)
+		ranges = strings.FieldsFunc(field, func(r rune) bool { return r == ',' })
+	)
+	for _, expr := range ranges {
+		start, end, step, _ := parseRange(expr, milliseconds)
+		for ms := start; ms <= end; ms += step {
+			set[int(ms)] = true
+		}
+	}
+
+	var list []int
+	for ms := int(milliseconds.min); ms <= int(milliseconds.max); ms++ {
+		if set[ms] {
+			list = append(list, ms)
+		}
+	}
+	if len(list) == 0 {
+		log.Panicf("Empty milliseconds: %s", field)
+	}
+	if len(list) == 1 && list[0] == 0 {
+		return nil
+	}
+	return list
+}
+
+// getYears returns the sorted list of years represented by the field, or nil if
+// the field matches every year.
+func getYears(field string) []int {
+	var (
+		set    = make(map[int]bool)
+		ranges = strings.FieldsFunc(field, func(r rune) bool { return r == ',' })
+	)
+	for _, expr := range ranges {
+		start, end, step, star := parseRange(expr, years)
+		if star && step == 1 {
+			return nil
+		}
+		for y := start; y <= end; y += step {
+			set[int(y)] = true
+		}
+	}
+
+	var list []int
+	for y := int(years.min); y <= int(years.max); y++ {
+		if set[y] {
+			list = append(list, y)
+		}
+	}
+	return list
+}
+
 // getRange returns the bits indicated by the given expression:
-//   number | number "-" number [ "/" number ]
+//
+//	number | number "-" number [ "/" number ]
 func getRange(expr string, r bounds) uint64 {
+	start, end, step, star := parseRange(expr, r)
 
+	var extra_star uint64
+	if star {
+		extra_star = starBit
+	}
+	return getBits(start, end, step) | extra_star
+}
+
+// parseRange returns the start, end and step of the given expression, and
+// whether it has been given as a star.  It panics if the range is not within
+// the bounds, or if the step is zero.
+func parseRange(expr string, r bounds) (start, end, step uint, star bool) {
 	var (
-		start, end, step uint
-		rangeAndStep     = strings.Split(expr, "/")
-		lowAndHigh       = strings.Split(rangeAndStep[0], "-")
-		singleDigit      = len(lowAndHigh) == 1
+		rangeAndStep = strings.Split(expr, "/")
+		lowAndHigh   = strings.Split(rangeAndStep[0], "-")
+		singleDigit  = len(lowAndHigh) == 1
 	)
 
-	var extra_star uint64
 	if lowAndHigh[0] == "*" || lowAndHigh[0] == "?" {
 		start = r.min
 		end = r.max
-		extra_star = starBit
+		star = true
 	} else {
 		start = parseIntOrName(lowAndHigh[0], r.names)
 		switch len(lowAndHigh) {
@@ -96,6 +1126,9 @@ func getRange(expr string, r bounds) uint64 {
 		step = 1
 	case 2:
 		step = mustParseInt(rangeAndStep[1])
+		if step == 0 {
+			log.Panicf("Step of zero not allowed: %s", expr)
+		}
 
 		// Special handling: "N/step" means "N-max/step".
 		if singleDigit {
@@ -106,16 +1139,16 @@ func getRange(expr string, r bounds) uint64 {
 	}
 
 	if start < r.min {
-		log.Panicf("Beginning of range (%d) below minimum (%d): %s", start, r.min, expr)
+		panicRange(expr, start, fmt.Sprintf("Beginning of range (%d) below minimum (%d): %s", start, r.min, expr))
 	}
 	if end > r.max {
-		log.Panicf("End of range (%d) above maximum (%d): %s", end, r.max, expr)
+		panicRange(expr, end, fmt.Sprintf("End of range (%d) above maximum (%d): %s", end, r.max, expr))
 	}
 	if start > end {
 		log.Panicf("Beginning of range (%d) beyond end of range (%d): %s", start, end, expr)
 	}
 
-	return getBits(start, end, step) | extra_star
+	return start, end, step, star
 }
 
 // parseIntOrName returns the (possibly-named) integer contained in expr.
@@ -164,7 +1197,7 @@ func all(r bounds) uint64 {
 
 // parseDescriptor returns a pre-defined schedule for the expression, or panics
 // if none matches.
-func parseDescriptor(spec string) Schedule {
+func (p Parser) parseDescriptor(spec string) Schedule {
 	switch spec {
 	case "@yearly", "@annually":
 		return &SpecSchedule{
@@ -206,6 +1239,9 @@ func parseDescriptor(spec string) Schedule {
 			Dow:    all(dow),
 		}
 
+	case "@reboot":
+		return RebootSchedule{}
+
 	case "@hourly":
 		return &SpecSchedule{
 			Second: 1 << seconds.min,
@@ -217,27 +1253,92 @@ func parseDescriptor(spec string) Schedule {
 		}
 	}
 
+	if fields := strings.Fields(spec); fields[0] == "@weekday" || fields[0] == "@weekend" {
+		if len(fields) > 2 {
+			log.Panicf("Expected %s [HH:MM]: %s", fields[0], spec)
+		}
+		var clock time.Duration
+		if len(fields) == 2 {
+			clock = parseTimeOfDay(fields[1], spec)
+		}
+		days := getBits(1, 5, 1)
+		if fields[0] == "@weekend" {
+			days = 1<<0 | 1<<6
+		}
+		return &SpecSchedule{
+			Second: 1 << seconds.min,
+			Minute: 1 << uint(clock%time.Hour/time.Minute),
+			Hour:   1 << uint(clock/time.Hour),
+			Dom:    all(dom),
+			Month:  all(months),
+			Dow:    days,
+		}
+	}
+
 	const every = "@every "
 	if strings.HasPrefix(spec, every) {
+		if i := strings.Index(spec, " at "); i >= 0 {
+			return parseDaily(spec[len(every):i], spec[i+len(" at "):], spec)
+		}
+		if period, ok := parseCalendarPeriod(strings.TrimSpace(spec[len(every):]), spec); ok {
+			now := p.now()
+			return IntervalSchedule{
+				Start:  now.Add(-time.Duration(now.Nanosecond())),
+				Period: period,
+				Count:  -1,
+			}
+		}
 		everyparts := strings.Split(spec[len(every):], ",")
-		duration, err := time.ParseDuration(everyparts[0])
+		durationAndJitter := strings.Split(everyparts[0], "~")
+		if len(durationAndJitter) > 2 {
+			log.Panicf("Too many jitters: %s", spec)
+		}
+		duration, err := parseDuration(durationAndJitter[0])
 		if err != nil {
 			log.Panicf("Failed to parse duration %s: %s", spec, err)
 		}
+
+		var schedule ConstantDelaySchedule
 		if len(everyparts) == 2 {
 			initial := strings.Trim(everyparts[1], " ")
-			const rand = "@rand"
+			const (
+				rand    = "@rand"
+				aligned = "@aligned"
+			)
 			if initial == rand {
-				return EveryWithRandInitial(duration)
+				schedule = everyWithRandInitial(duration, p.now())
+			} else if initial == aligned {
+				schedule = EveryAligned(duration, time.Local)
+			} else if initial == "H" {
+				schedule = EveryWithKey(duration, p.hashKey)
 			} else {
-				initialDuration, err := time.ParseDuration(initial)
+				initialDuration, err := parseDuration(initial)
 				if err != nil {
 					log.Panicf("Failed to parse duration %s: %s", spec, err)
 				}
-				return EveryWithInitial(duration, initialDuration)
+				schedule = everyWithInitial(duration, initialDuration, p.now())
+			}
+		} else {
+			schedule = Every(duration)
+		}
+
+		if len(durationAndJitter) == 2 {
+			jitter, err := parseDuration(durationAndJitter[1])
+			if err != nil {
+				log.Panicf("Failed to parse jitter %s: %s", spec, err)
 			}
+			schedule.Jitter = EveryWithJitter(duration, jitter).Jitter
+		}
+		return schedule
+	}
+
+	const at = "@at "
+	if strings.HasPrefix(spec, at) {
+		t, err := time.Parse(time.RFC3339, strings.TrimSpace(spec[len(at):]))
+		if err != nil {
+			log.Panicf("Failed to parse time %s: %s", spec, err)
 		}
-		return Every(duration)
+		return At(t)
 	}
 
 	log.Panicf("Unrecognized descriptor: %s", spec)
@@ -1,6 +1,7 @@
 package cron
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 	"time"
@@ -101,8 +102,14 @@ func TestSpecSchedule(t *testing.T) {
 		expr     string
 		expected Schedule
 	}{
-		{"* 5 * * * *", &SpecSchedule{all(seconds), 1 << 5, all(hours), all(dom), all(months), all(dow)}},
-		{"@every 5m", ConstantDelaySchedule{time.Duration(5) * time.Minute, time.Unix(0, 0)}},
+		{"* 5 * * * *", &SpecSchedule{Second: all(seconds), Minute: 1 << 5, Hour: all(hours), Dom: all(dom), Month: all(months), Dow: all(dow)}},
+		{"0 0 0 1 1 * 2030", &SpecSchedule{Second: 1 << 0, Minute: 1 << 0, Hour: 1 << 0, Dom: 1 << 1, Month: 1 << 1, Dow: all(dow), Year: []int{2030}}},
+		{"0 0 0 1 1 2030,2040", &SpecSchedule{Second: 1 << 0, Minute: 1 << 0, Hour: 1 << 0, Dom: 1 << 1, Month: 1 << 1, Dow: all(dow), Year: []int{2030, 2040}}},
+		{"@every 5m", ConstantDelaySchedule{Delay: time.Duration(5) * time.Minute, StartTime: time.Unix(0, 0)}},
+		{"@every 5m~30s", ConstantDelaySchedule{Delay: time.Duration(5) * time.Minute, StartTime: time.Unix(0, 0), Jitter: 30 * time.Second}},
+		{"@every 1h~1m30.5s", ConstantDelaySchedule{Delay: time.Hour, StartTime: time.Unix(0, 0), Jitter: 90 * time.Second}},
+		{"@every 1d", ConstantDelaySchedule{Delay: 24 * time.Hour, StartTime: time.Unix(0, 0)}},
+		{"@every 1w~1d", ConstantDelaySchedule{Delay: 7 * 24 * time.Hour, StartTime: time.Unix(0, 0), Jitter: 24 * time.Hour}},
 	}
 
 	for _, c := range entries {
@@ -115,3 +122,639 @@ func TestSpecSchedule(t *testing.T) {
 		}
 	}
 }
+
+func TestParseCalendarInterval(t *testing.T) {
+	tests := []struct {
+		spec     string
+		expected Period
+	}{
+		{"@every 1mo", Period{Months: 1}},
+		{"@every 3mo", Period{Months: 3}},
+		{"@every 1y", Period{Years: 1}},
+		{"@every 1y6mo", Period{Years: 1, Months: 6}},
+		{"@every 1mo12h", Period{Months: 1, Duration: 12 * time.Hour}},
+	}
+	for _, c := range tests {
+		before := time.Now().Add(-time.Second)
+		sched, err := Parse(c.spec)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		actual, ok := sched.(IntervalSchedule)
+		if !ok || actual.Period != c.expected || actual.Count != -1 {
+			t.Errorf("%s: (expected) %+v != %+v (actual)", c.spec, c.expected, sched)
+			continue
+		}
+
+		// The interval starts now, and activates one period later.
+		if actual.Start.Before(before) || actual.Start.After(time.Now()) {
+			t.Errorf("%s: (expected) start now != %v (actual)", c.spec, actual.Start)
+		}
+		if next := actual.Next(time.Now()); !next.Equal(c.expected.addTo(actual.Start, 1)) {
+			t.Errorf("%s: (expected) %v != %v (actual)", c.spec, c.expected.addTo(actual.Start, 1), next)
+		}
+	}
+
+	// The months keep the day of the month of the start, but not beyond the
+	// end of the month.
+	sched, _ := Parse("@every 1mo")
+	monthly := sched.(IntervalSchedule)
+	monthly.Start = getTime("Tue Jan 31 09:00 2012")
+	next := monthly.Start
+	for _, expected := range []string{"Wed Feb 29 09:00 2012", "Sat Mar 31 09:00 2012", "Mon Apr 30 09:00 2012"} {
+		if next = monthly.Next(next); !next.Equal(getTime(expected)) {
+			t.Errorf("(expected) %v != %v (actual)", getTime(expected), next)
+		}
+	}
+
+	for _, spec := range []string{"@every 0mo", "@every xmo", "@every -1mo", "@every 1mo~1d", "@every 1mo,0s", "@every 1mox"} {
+		if _, err := Parse(spec); err == nil {
+			t.Errorf("%s: expected an error", spec)
+		}
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{"90m", 90 * time.Minute},
+		{"1d", 24 * time.Hour},
+		{"2d12h", 60 * time.Hour},
+		{"1.5d", 36 * time.Hour},
+		{"1w", 7 * 24 * time.Hour},
+		{"1w2d3h4m", 9*24*time.Hour + 3*time.Hour + 4*time.Minute},
+		{"-1d12h", -36 * time.Hour},
+	}
+	for _, c := range tests {
+		actual, err := parseDuration(c.value)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if actual != c.expected {
+			t.Errorf("%s: (expected) %v != %v (actual)", c.value, c.expected, actual)
+		}
+	}
+
+	for _, value := range []string{"", "d", "1x", "1dd", "12h1d", "1d-1h", "x1d", "1.2.3d"} {
+		if _, err := parseDuration(value); err == nil {
+			t.Errorf("%s: expected an error", value)
+		}
+	}
+}
+
+func TestParseStandard(t *testing.T) {
+	entries := []struct {
+		expr     string
+		expected Schedule
+	}{
+		{"5 * * * *", &SpecSchedule{Second: 1 << seconds.min, Minute: 1 << 5, Hour: all(hours), Dom: all(dom), Month: all(months), Dow: all(dow)}},
+		{"*/10 * * * * *", &SpecSchedule{Second: getBits(0, 59, 10) | starBit, Minute: all(minutes), Hour: all(hours), Dom: all(dom), Month: all(months), Dow: all(dow)}},
+		{"0 9 * * mon-fri", &SpecSchedule{Second: 1 << seconds.min, Minute: 1 << 0, Hour: 1 << 9, Dom: all(dom), Month: all(months), Dow: getBits(1, 5, 1)}},
+		{"0 0 1 1 * 2030", &SpecSchedule{Second: 1 << seconds.min, Minute: 1 << 0, Hour: 1 << 0, Dom: 1 << 1, Month: 1 << 1, Dow: all(dow), Year: []int{2030}}},
+		{"0 0 0 1 1 * 2030-2031", &SpecSchedule{Second: 1 << seconds.min, Minute: 1 << 0, Hour: 1 << 0, Dom: 1 << 1, Month: 1 << 1, Dow: all(dow), Year: []int{2030, 2031}}},
+		{"@every 5m", ConstantDelaySchedule{Delay: time.Duration(5) * time.Minute, StartTime: time.Unix(0, 0)}},
+	}
+
+	for _, c := range entries {
+		actual, err := ParseStandard(c.expr)
+		if err != nil {
+			t.Error(err)
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s => (expected) %b != %b (actual)", c.expr, c.expected, actual)
+		}
+	}
+
+	invalidSpecs := []string{
+		"* * * *",
+		"60 * * * *",
+		"0 0 * * * * * *",
+		"0 0 1 1 2030",
+	}
+	for _, spec := range invalidSpecs {
+		if _, err := ParseStandard(spec); err == nil {
+			t.Error("expected an error parsing: ", spec)
+		}
+	}
+}
+
+func TestParseQuartz(t *testing.T) {
+	entries := []struct {
+		expr     string
+		expected Schedule
+	}{
+		{"0 15 10 ? * MON-FRI", &SpecSchedule{Second: 1 << 0, Minute: 1 << 15, Hour: 1 << 10, Dom: all(dom), Month: all(months), Dow: getBits(1, 5, 1)}},
+		{"0 15 10 ? * 2-6", &SpecSchedule{Second: 1 << 0, Minute: 1 << 15, Hour: 1 << 10, Dom: all(dom), Month: all(months), Dow: getBits(1, 5, 1)}},
+		{"0 0 12 1 * ?", &SpecSchedule{Second: 1 << 0, Minute: 1 << 0, Hour: 1 << 12, Dom: 1 << 1, Month: all(months), Dow: all(dow)}},
+		{"0 0 12 ? * 1,7", &SpecSchedule{Second: 1 << 0, Minute: 1 << 0, Hour: 1 << 12, Dom: all(dom), Month: all(months), Dow: 1<<0 | 1<<6}},
+		{"0 0 12 1 1 ? 2030", &SpecSchedule{Second: 1 << 0, Minute: 1 << 0, Hour: 1 << 12, Dom: 1 << 1, Month: 1 << 1, Dow: all(dow), Year: []int{2030}}},
+		{"0 0 12 1 1 ? 2030-2040/5,2032", &SpecSchedule{Second: 1 << 0, Minute: 1 << 0, Hour: 1 << 12, Dom: 1 << 1, Month: 1 << 1, Dow: all(dow), Year: []int{2030, 2032, 2035, 2040}}},
+		{"0 0 12 1 1 ? *", &SpecSchedule{Second: 1 << 0, Minute: 1 << 0, Hour: 1 << 12, Dom: 1 << 1, Month: 1 << 1, Dow: all(dow)}},
+	}
+
+	for _, c := range entries {
+		actual, err := ParseQuartz(c.expr)
+		if err != nil {
+			t.Error(err)
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s => (expected) %v != %v (actual)", c.expr, c.expected, actual)
+		}
+	}
+
+	invalidSpecs := []string{
+		"0 15 10 ? *",
+		"0 15 10 ? * 0",
+		"0 15 10 ? * 8",
+		"0 0 12 1 1 ? 1969",
+		"0 0 12 1 1 ? 2030 *",
+	}
+	for _, spec := range invalidSpecs {
+		if _, err := ParseQuartz(spec); err == nil {
+			t.Error("expected an error parsing: ", spec)
+		}
+	}
+}
+
+func TestParseAWS(t *testing.T) {
+	entries := []struct {
+		expr     string
+		expected Schedule
+	}{
+		{"cron(0 12 * * ? *)", &SpecSchedule{Second: 1 << 0, Minute: 1 << 0, Hour: 1 << 12, Dom: all(dom), Month: all(months), Dow: all(dow)}},
+		{"0 18 ? * MON-FRI *", &SpecSchedule{Second: 1 << 0, Minute: 1 << 0, Hour: 1 << 18, Dom: all(dom), Month: all(months), Dow: getBits(1, 5, 1)}},
+		{"cron(15 10 ? * 6L 2030)", &SpecSchedule{Second: 1 << 0, Minute: 1 << 15, Hour: 1 << 10, Dom: all(dom), Month: all(months), Dow: 0, Year: []int{2030}, lastDow: 1 << 5}},
+		{"0 8 1W * ? *", &SpecSchedule{Second: 1 << 0, Minute: 1 << 0, Hour: 1 << 8, Dom: 0, Month: all(months), Dow: all(dow), weekdayDom: 1 << 1}},
+		{"0 18 LW * ? *", &SpecSchedule{Second: 1 << 0, Minute: 1 << 0, Hour: 1 << 18, Dom: 0, Month: all(months), Dow: all(dow), lastWeekdayDom: true}},
+		{"rate(5 minutes)", Every(5 * time.Minute)},
+		{"rate(1 hour)", Every(time.Hour)},
+		{"rate(2 days)", Every(48 * time.Hour)},
+	}
+
+	for _, c := range entries {
+		actual, err := ParseAWS(c.expr)
+		if err != nil {
+			t.Error(err)
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s => (expected) %v != %v (actual)", c.expr, c.expected, actual)
+		}
+	}
+
+	invalidSpecs := []string{
+		"0 12 * * ?",
+		"0 0 12 * * ? *",
+		"0 12 * * * *",
+		"0 12 ? * ? *",
+		"0 12 1 * MON *",
+		"cron(0 12 * * ? *",
+		"rate(5)",
+		"rate(0 minutes)",
+		"rate(5 weeks)",
+		"@daily",
+	}
+	for _, spec := range invalidSpecs {
+		if _, err := ParseAWS(spec); err == nil {
+			t.Error("expected an error parsing: ", spec)
+		}
+	}
+
+	// The offsets of errors are within the given expression.
+	offsets := []struct {
+		expr   string
+		token  string
+		offset int
+	}{
+		{"cron(0 25 ? * MON *)", "25", 7},
+		{"  cron(0 25 ? * MON *)", "25", 9},
+		{" 0 25 ? * MON *", "25", 3},
+	}
+	for _, c := range offsets {
+		_, err := ParseAWS(c.expr)
+		e, ok := err.(*ParseError)
+		if !ok {
+			t.Errorf("%s: expected a *ParseError, got %v", c.expr, err)
+			continue
+		}
+		if e.Spec != c.expr || e.Token != c.token || e.Offset != c.offset || c.expr[e.Offset:e.Offset+len(e.Token)] != c.token {
+			t.Errorf("%s: (expected) %q at %d != %q at %d in %q (actual)", c.expr, c.token, c.offset, e.Token, e.Offset, e.Spec)
+		}
+	}
+}
+
+func TestParseStrict(t *testing.T) {
+	p := NewParser(Minute | Hour | Dom | Month | Dow | YearOptional | Strict)
+	validSpecs := []string{
+		"0 0 29 2 *",
+		"0 0 31 1-2 *",
+		"0 0 30 2 Mon",
+		"0 0 * 2 1#5",
+		"0 0 29 2 * 2028",
+		"0 0 L 2 * 2030",
+		"0 0 1W 4 *",
+	}
+	for _, spec := range validSpecs {
+		if _, err := p.Parse(spec); err != nil {
+			t.Errorf("%s: unexpected error %v", spec, err)
+		}
+	}
+
+	invalidSpecs := []string{
+		"0 0 31 2 *",
+		"0 0 30,31 2 *",
+		"0 0 31 4,6,9,11 *",
+		"0 0 29 2 * 2030",
+		"0 0 * 2 1#5 2030-2031",
+		"0 0 31W 4 *",
+	}
+	for _, spec := range invalidSpecs {
+		if _, err := p.Parse(spec); err == nil {
+			t.Error("expected an error parsing: ", spec)
+		}
+		// Without the option, the spec is accepted.
+		if _, err := standardParser.Parse(spec); err != nil {
+			t.Errorf("%s: unexpected error %v", spec, err)
+		}
+	}
+}
+
+func TestParseDomDowAnd(t *testing.T) {
+	and := NewParser(Minute | Hour | Dom | Month | Dow | DomDowAnd)
+	runs := []struct {
+		parser     Parser
+		time, spec string
+		expected   string
+	}{
+		{standardParser, "Sat Jul 14 00:00 2012", "0 0 13 * FRI", "Fri Jul 20 00:00 2012"},
+		{and, "Sat Jul 14 00:00 2012", "0 0 13 * FRI", "Fri Sep 13 00:00 2013"},
+		{and, "Mon Jul 9 00:00 2012", "0 0 13 * FRI", "Fri Jul 13 00:00 2012"},
+		{and, "Sat Jul 14 00:00 2012", "0 0 L * 1-5", "Tue Jul 31 00:00 2012"},
+		{and, "Tue Jul 31 00:00 2012", "0 0 L * 1-5", "Fri Aug 31 00:00 2012"},
+
+		// A star in either field matches every day, as without the option.
+		{and, "Sat Jul 14 00:00 2012", "0 0 * * FRI", "Fri Jul 20 00:00 2012"},
+		{and, "Sat Jul 14 00:00 2012", "0 0 13 * *", "Mon Aug 13 00:00 2012"},
+	}
+	for _, c := range runs {
+		sched, err := c.parser.Parse(c.spec)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		actual := sched.Next(getTime(c.time))
+		if expected := getTime(c.expected); !actual.Equal(expected) {
+			t.Errorf("%s, \"%s\": (expected) %v != %v (actual)", c.time, c.spec, expected, actual)
+		}
+	}
+
+	// Strict takes the option into account.
+	if _, err := NewParser(Minute | Hour | Dom | Month | Dow | DomDowAnd | Strict).Parse("0 0 31 2 MON"); err == nil {
+		t.Error("expected an error parsing a spec which can never be activated")
+	}
+}
+
+func TestValidateSpec(t *testing.T) {
+	validSpecs := []string{
+		"0 30 6 * * MON-FRI",
+		"0 0 0 1 1 * 2030",
+		"CRON_TZ=Europe/Zurich 0 0 6 * * *",
+		"@daily",
+		"@every 5m",
+		"@weekday 09:30",
+		"0 0/5 * * * * @between 08:00-18:00",
+	}
+	for _, spec := range validSpecs {
+		if err := ValidateSpec(spec); err != nil {
+			t.Errorf("%s: unexpected error %v", spec, err)
+		}
+	}
+
+	invalidSpecs := []string{
+		"",
+		"0 0 25 * * *",
+		"0 0 0 * * XYZ",
+		"TZ=Nowhere/Bad 0 0 6 * * *",
+		"@every",
+		"@weekday 25:00",
+		"@weekend 09:30 10:30",
+		"*/0 * * * * *",
+		"0 0 0 1 1 * 2030/0",
+	}
+	for _, spec := range invalidSpecs {
+		err := ValidateSpec(spec)
+		if _, ok := err.(*ParseError); !ok {
+			t.Errorf("%s: expected a *ParseError, got %v", spec, err)
+		}
+	}
+
+	// Validate uses the fields of the parser.
+	if err := NewParser(Minute | Hour | Dom | Month | Dow).Validate("0 30 6 * * MON-FRI"); err == nil {
+		t.Error("expected an error validating 6 fields with a 5 field parser")
+	}
+}
+
+func TestParseWithHashKey(t *testing.T) {
+	specs := []string{
+		"H H H * * *",
+		"H H/15 H(8-17) * * *",
+		"0 H(0-29)/10 * H * H",
+		"0 0 0 H Jan,Jul *",
+	}
+	keys := []string{"", "job1", "job2", "backup", "report"}
+
+	for _, spec := range specs {
+		for _, key := range keys {
+			first, err := ParseWithHashKey(spec, key)
+			if err != nil {
+				t.Error(err)
+				continue
+			}
+
+			// The same key must always result in the same schedule.
+			second, _ := ParseWithHashKey(spec, key)
+			if !reflect.DeepEqual(first, second) {
+				t.Errorf("%s, %q: (first) %v != %v (second)", spec, key, first, second)
+			}
+
+			// The hashed values must be within the allowed ranges.
+			actual := first.(*SpecSchedule)
+			if actual.Hour&^(getBits(0, 23, 1)|starBit) != 0 {
+				t.Errorf("%s, %q: hour out of range: %b", spec, key, actual.Hour)
+			}
+			if actual.Dom&starBit == 0 && actual.Dom&^getBits(1, 28, 1) != 0 {
+				t.Errorf("%s, %q: day of month out of range: %b", spec, key, actual.Dom)
+			}
+		}
+	}
+
+	// Hash ranges and steps.
+	for _, key := range keys {
+		sched, err := ParseWithHashKey("0 H/15 H(8-17) * * *", key)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		actual := sched.(*SpecSchedule)
+		if actual.Hour&^getBits(8, 17, 1) != 0 || actual.Hour == 0 {
+			t.Errorf("%q: hour not within 8-17: %b", key, actual.Hour)
+		}
+		offset := uint(0)
+		for actual.Minute&(1<<offset) == 0 {
+			offset++
+		}
+		if offset >= 15 || actual.Minute != getBits(offset, 59, 15) {
+			t.Errorf("%q: (expected) every 15 minutes != %b (actual)", key, actual.Minute)
+		}
+	}
+
+	// Different keys spread a spec over the range.
+	minutesUsed := make(map[uint64]bool)
+	for i := 0; i < 20; i++ {
+		sched, _ := ParseWithHashKey("0 H * * * *", fmt.Sprintf("job%d", i))
+		minutesUsed[sched.(*SpecSchedule).Minute] = true
+	}
+	if len(minutesUsed) < 2 {
+		t.Errorf("expected hashed minutes to differ between keys, got %v", minutesUsed)
+	}
+
+	invalidSpecs := []string{
+		"H(0-60) * * * * *",
+		"H(5-1) * * * * *",
+		"H(0-5 * * * * *",
+		"H/0 * * * * *",
+		"Hx * * * * *",
+	}
+	for _, spec := range invalidSpecs {
+		if _, err := ParseWithHashKey(spec, "job"); err == nil {
+			t.Error("expected an error parsing: ", spec)
+		}
+	}
+}
+
+func TestParseRandom(t *testing.T) {
+	tests := []struct {
+		spec     string
+		min, max uint
+		field    func(*SpecSchedule) uint64
+	}{
+		{"0 0~29 * * * *", 0, 29, func(s *SpecSchedule) uint64 { return s.Minute }},
+		{"0 ~ * * * *", 0, 59, func(s *SpecSchedule) uint64 { return s.Minute }},
+		{"0 0 ~5 * * *", 0, 5, func(s *SpecSchedule) uint64 { return s.Hour }},
+		{"0 0 20~ * * *", 20, 23, func(s *SpecSchedule) uint64 { return s.Hour }},
+		{"0 0 0 ~ * *", 1, 28, func(s *SpecSchedule) uint64 { return s.Dom }},
+		{"0 0 0 * * mon~fri", 1, 5, func(s *SpecSchedule) uint64 { return s.Dow }},
+	}
+	for _, c := range tests {
+		used := make(map[uint64]bool)
+		for i := 0; i < 50; i++ {
+			sched, err := Parse(c.spec)
+			if err != nil {
+				t.Error(err)
+				break
+			}
+			bits := c.field(sched.(*SpecSchedule))
+			if bits&(bits-1) != 0 || bits&^getBits(c.min, c.max, 1) != 0 {
+				t.Errorf("%s: (expected) one value within %d-%d != %b (actual)", c.spec, c.min, c.max, bits)
+			}
+			used[bits] = true
+		}
+		if len(used) < 2 {
+			t.Errorf("%s: expected random values to differ between entries, got %v", c.spec, used)
+		}
+	}
+
+	// The random value is combined with other items of the list.
+	sched, err := Parse("0 0,30~59 * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if minute := sched.(*SpecSchedule).Minute; minute&1 == 0 || minute&^(1|getBits(30, 59, 1)) != 0 {
+		t.Errorf("(expected) 0 and one minute within 30-59 != %b (actual)", minute)
+	}
+
+	invalidSpecs := []string{
+		"0 0~60 * * * *",
+		"0 30~10 * * * *",
+		"0 0~5~9 * * * *",
+		"0 0~29/5 * * * *",
+		"0 1-2~9 * * * *",
+		"0 x~9 * * * *",
+	}
+	for _, spec := range invalidSpecs {
+		if _, err := Parse(spec); err == nil {
+			t.Error("expected an error parsing: ", spec)
+		}
+	}
+}
+
+func TestParseNames(t *testing.T) {
+	p := NewParser(Minute|Hour|Dom|Month|Dow).WithNames(German, French)
+	tests := []struct {
+		parser   Parser
+		spec     string
+		expected *SpecSchedule
+	}{
+		{standardParser, "0 0 * January-March Thursday", &SpecSchedule{Second: 1 << 0, Minute: 1 << 0, Hour: 1 << 0, Dom: all(dom), Month: getBits(1, 3, 1), Dow: 1 << 4}},
+		{quartzParser, "0 0 0 ? * sunday", &SpecSchedule{Second: 1 << 0, Minute: 1 << 0, Hour: 1 << 0, Dom: all(dom), Month: all(months), Dow: 1 << 0}},
+		{p, "30 6 * janvier Montag-Freitag", &SpecSchedule{Second: 1 << 0, Minute: 1 << 30, Hour: 1 << 6, Dom: all(dom), Month: 1 << 1, Dow: getBits(1, 5, 1)}},
+		{p, "0 0 * MÄRZ,mars,Mar SONNTAG", &SpecSchedule{Second: 1 << 0, Minute: 1 << 0, Hour: 1 << 0, Dom: all(dom), Month: 1 << 3, Dow: 1 << 0}},
+		{p, "0 0 * * FreitagL", &SpecSchedule{Second: 1 << 0, Minute: 1 << 0, Hour: 1 << 0, Dom: all(dom), Month: all(months), lastDow: 1 << 5}},
+		{NewParser(Second | Minute | Hour | Dom | Month | Dow | QuartzDow).WithNames(Spanish), "0 0 0 ? * lunes", &SpecSchedule{Second: 1 << 0, Minute: 1 << 0, Hour: 1 << 0, Dom: all(dom), Month: all(months), Dow: 1 << 1}},
+	}
+	for _, c := range tests {
+		actual, err := c.parser.Parse(c.spec)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s: (expected) %v != %v (actual)", c.spec, c.expected, actual)
+		}
+	}
+
+	// The names of a locale are not accepted without it.
+	for _, spec := range []string{"0 0 * janvier *", "0 0 * * Montag"} {
+		if _, err := standardParser.Parse(spec); err == nil {
+			t.Error("expected an error parsing: ", spec)
+		}
+	}
+}
+
+func TestParseLocation(t *testing.T) {
+	entries := []struct {
+		expr     string
+		expected string
+	}{
+		{"CRON_TZ=UTC 0 0 6 * * *", "UTC"},
+		{"TZ=America/New_York 0 0 6 * * *", "America/New_York"},
+		{"CRON_TZ=America/New_York  @daily", "America/New_York"},
+		{"0 0 6 * * *", ""},
+	}
+
+	for _, c := range entries {
+		actual, err := Parse(c.expr)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		var name string
+		if loc := actual.(*SpecSchedule).Location; loc != nil {
+			name = loc.String()
+		}
+		if name != c.expected {
+			t.Errorf("%s => (expected) %q != %q (actual)", c.expr, c.expected, name)
+		}
+	}
+
+	invalidSpecs := []string{
+		"CRON_TZ=Nowhere/Bad 0 0 6 * * *",
+		"TZ=UTC",
+		"",
+	}
+	for _, spec := range invalidSpecs {
+		if _, err := Parse(spec); err == nil {
+			t.Error("expected an error parsing: ", spec)
+		}
+	}
+}
+
+func TestNewParser(t *testing.T) {
+	entries := []struct {
+		options  ParseOption
+		expr     string
+		expected Schedule
+	}{
+		{Minute | Hour | Dom | Month | Dow, "0 0 15 */3 *", &SpecSchedule{Second: 1 << 0, Minute: 1 << 0, Hour: 1 << 0, Dom: 1 << 15, Month: getBits(1, 12, 3) | starBit, Dow: all(dow)}},
+		{Dom | Month | Dow, "15 */3 *", &SpecSchedule{Second: 1 << 0, Minute: 1 << 0, Hour: 1 << 0, Dom: 1 << 15, Month: getBits(1, 12, 3) | starBit, Dow: all(dow)}},
+		{Dom | Month | DowOptional, "15 */3", &SpecSchedule{Second: 1 << 0, Minute: 1 << 0, Hour: 1 << 0, Dom: 1 << 15, Month: getBits(1, 12, 3) | starBit, Dow: all(dow)}},
+		{Dom | Month | DowOptional, "15 */3 mon", &SpecSchedule{Second: 1 << 0, Minute: 1 << 0, Hour: 1 << 0, Dom: 1 << 15, Month: getBits(1, 12, 3) | starBit, Dow: 1 << 1}},
+		{SecondOptional | Minute | Hour, "30 6", &SpecSchedule{Second: 1 << 0, Minute: 1 << 30, Hour: 1 << 6, Dom: all(dom), Month: all(months), Dow: all(dow)}},
+		{SecondOptional | Minute | Hour, "15 30 6", &SpecSchedule{Second: 1 << 15, Minute: 1 << 30, Hour: 1 << 6, Dom: all(dom), Month: all(months), Dow: all(dow)}},
+		{Minute | Hour | Dom | Month | Year, "0 0 1 1 2030", &SpecSchedule{Second: 1 << 0, Minute: 1 << 0, Hour: 1 << 0, Dom: 1 << 1, Month: 1 << 1, Dow: all(dow), Year: []int{2030}}},
+		{Minute | Hour | Dom | Month | YearOptional, "0 0 1 1", &SpecSchedule{Second: 1 << 0, Minute: 1 << 0, Hour: 1 << 0, Dom: 1 << 1, Month: 1 << 1, Dow: all(dow)}},
+		{Minute | Hour | QuartzDow | Dow, "0 0 2", &SpecSchedule{Second: 1 << 0, Minute: 1 << 0, Hour: 1 << 0, Dom: all(dom), Month: all(months), Dow: 1 << 1}},
+		{Minute | Descriptor, "@every 5m", ConstantDelaySchedule{Delay: time.Duration(5) * time.Minute, StartTime: time.Unix(0, 0)}},
+	}
+
+	for _, c := range entries {
+		actual, err := NewParser(c.options).Parse(c.expr)
+		if err != nil {
+			t.Error(err)
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s => (expected) %v != %v (actual)", c.expr, c.expected, actual)
+		}
+	}
+
+	invalid := []struct {
+		options ParseOption
+		expr    string
+	}{
+		{Minute | Hour | Dom | Month | Dow, "0 0 15 */3"},
+		{Minute | Hour | Dom | Month | Dow, "0 0 0 15 */3 *"},
+		{Dom | Month | DowOptional, "15"},
+		{SecondOptional | Minute | Hour | DowOptional, "0 0 0"},
+		{Minute | Hour | Dom | Month | Year, "0 0 1 1"},
+		{Minute | Hour | Dom | Month | Dow, "@daily"},
+	}
+	for _, c := range invalid {
+		if _, err := NewParser(c.options).Parse(c.expr); err == nil {
+			t.Error("expected an error parsing: ", c.expr)
+		}
+	}
+}
+
+func TestParserWithDescriptor(t *testing.T) {
+	businessHours := &SpecSchedule{Second: 1 << 0, Minute: 1 << 0, Hour: getBits(8, 17, 1), Dom: all(dom), Month: all(months), Dow: getBits(1, 5, 1)}
+	quarterly := &SpecSchedule{Second: 1 << 0, Minute: 1 << 0, Hour: 1 << 0, Dom: 1 << 1, Month: getBits(1, 12, 3), Dow: all(dow)}
+	hourly := Every(time.Hour)
+
+	p := NewParser(Minute|Hour|Dom|Month|Dow).
+		WithDescriptor("@business-hours", businessHours).
+		WithDescriptor("quarterly", quarterly)
+	override := defaultParser.WithDescriptor("@hourly", hourly)
+
+	entries := []struct {
+		parser   Parser
+		expr     string
+		expected Schedule
+	}{
+		{p, "@business-hours", businessHours},
+		{p, "@quarterly", quarterly},
+		{override, "@hourly", hourly},
+		{override, "@daily", &SpecSchedule{Second: 1 << 0, Minute: 1 << 0, Hour: 1 << 0, Dom: all(dom), Month: all(months), Dow: all(dow)}},
+	}
+
+	for _, c := range entries {
+		actual, err := c.parser.Parse(c.expr)
+		if err != nil {
+			t.Error(err)
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s => (expected) %v != %v (actual)", c.expr, c.expected, actual)
+		}
+	}
+
+	// The location is set on a copy of the registered schedule.
+	actual, err := p.Parse("TZ=UTC @quarterly")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual.(*SpecSchedule).Location != time.UTC || quarterly.Location != nil {
+		t.Errorf("(expected) location on copy only: %v, %v", actual.(*SpecSchedule).Location, quarterly.Location)
+	}
+
+	// Registering on a copy does not change the original parser.
+	if _, err := p.WithDescriptor("@other", hourly).Parse("@other"); err != nil {
+		t.Error(err)
+	}
+	for _, spec := range []string{"@other", "@daily"} {
+		if _, err := p.Parse(spec); err == nil {
+			t.Error("expected an error parsing: ", spec)
+		}
+	}
+}
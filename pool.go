@@ -0,0 +1,92 @@
+package cron
+
+import (
+	"container/heap"
+	"sync"
+)
+
+// pool runs funcs on a fixed number of goroutines, queueing the funcs which
+// are submitted while all of them are busy.  Queued funcs of a higher priority
+// run first, and ones of the same priority in the order of submission.
+type pool struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	queue  poolQueue
+	seq    int
+	closed bool
+}
+
+// newPool returns a pool of the given number of goroutines.
+func newPool(size int) *pool {
+	p := &pool{}
+	p.cond = sync.NewCond(&p.mu)
+	for i := 0; i < size; i++ {
+		go p.work()
+	}
+	return p
+}
+
+// submit queues the func of the given priority to be run by the next idle
+// goroutine.
+func (p *pool) submit(priority int, f func()) {
+	p.mu.Lock()
+	p.seq++
+	heap.Push(&p.queue, poolItem{priority: priority, seq: p.seq, f: f})
+	p.mu.Unlock()
+	p.cond.Signal()
+}
+
+// close stops the goroutines, once they have run the queued funcs.
+func (p *pool) close() {
+	p.mu.Lock()
+	p.closed = true
+	p.mu.Unlock()
+	p.cond.Broadcast()
+}
+
+// work runs the queued funcs, until the pool is closed and the queue is empty.
+func (p *pool) work() {
+	for {
+		p.mu.Lock()
+		for len(p.queue) == 0 && !p.closed {
+			p.cond.Wait()
+		}
+		if len(p.queue) == 0 {
+			p.mu.Unlock()
+			return
+		}
+		item := heap.Pop(&p.queue).(poolItem)
+		p.mu.Unlock()
+		item.f()
+	}
+}
+
+// poolItem is a queued func of a pool.
+type poolItem struct {
+	priority int
+	seq      int
+	f        func()
+}
+
+// poolQueue is a heap of queued funcs, by descending priority and ascending
+// sequence number.
+type poolQueue []poolItem
+
+func (q poolQueue) Len() int      { return len(q) }
+func (q poolQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q poolQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q *poolQueue) Push(x interface{}) { *q = append(*q, x.(poolItem)) }
+
+func (q *poolQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	old[len(old)-1] = poolItem{}
+	*q = old[:len(old)-1]
+	return item
+}
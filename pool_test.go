@@ -0,0 +1,101 @@
+package cron
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPool(t *testing.T) {
+	var (
+		mu               sync.Mutex
+		running, maximum int
+		wg               sync.WaitGroup
+	)
+	p := newPool(3)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		p.submit(0, func() {
+			defer wg.Done()
+			mu.Lock()
+			if running++; running > maximum {
+				maximum = running
+			}
+			mu.Unlock()
+			time.Sleep(time.Millisecond)
+			mu.Lock()
+			running--
+			mu.Unlock()
+		})
+	}
+	p.close()
+	wg.Wait()
+	if maximum > 3 {
+		t.Errorf("(expected) at most 3 concurrent funcs != %d (actual)", maximum)
+	}
+}
+
+// Queued funcs run by priority, and in order within a priority.
+func TestPoolPriority(t *testing.T) {
+	var order []int
+	release := make(chan struct{})
+	p := newPool(1)
+	p.submit(0, func() { <-release })
+	time.Sleep(5 * time.Millisecond)
+
+	var wg sync.WaitGroup
+	for i, priority := range []int{0, 1, 0, 2, 1} {
+		i := i
+		wg.Add(1)
+		p.submit(priority, func() {
+			defer wg.Done()
+			order = append(order, i)
+		})
+	}
+	close(release)
+	wg.Wait()
+	p.close()
+
+	expected := []int{3, 1, 4, 0, 2}
+	for i := range expected {
+		if order[i] != expected[i] {
+			t.Fatalf("(expected) %v != %v (actual)", expected, order)
+		}
+	}
+}
+
+// A Cron with a maximum concurrency queues the runs beyond it.
+func TestWithMaxConcurrency(t *testing.T) {
+	var (
+		mu               sync.Mutex
+		running, maximum int
+	)
+	cron := New(WithMaxConcurrency(2))
+	for i := 0; i < 5; i++ {
+		cron.AddFunc("@reboot", func() {
+			mu.Lock()
+			if running++; running > maximum {
+				maximum = running
+			}
+			mu.Unlock()
+			time.Sleep(10 * time.Millisecond)
+			mu.Lock()
+			running--
+			mu.Unlock()
+		})
+	}
+	cron.Start()
+	time.Sleep(5 * time.Millisecond)
+	<-cron.Stop().Done()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if maximum != 2 {
+		t.Errorf("(expected) 2 concurrent runs != %d (actual)", maximum)
+	}
+	for _, e := range cron.Entries() {
+		if e.Stats.Runs != 1 {
+			t.Errorf("(expected) 1 run != %d (actual)", e.Stats.Runs)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package cron
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket, which bounds the rate of the starts of runs.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // Tokens added per second
+	burst  float64 // Capacity of the bucket
+	tokens float64
+	last   time.Time // Time the tokens have been counted at
+}
+
+// wait waits for a token, by the time of the clock.  It returns the error of
+// the context if it is done before.
+func (l *rateLimiter) wait(ctx context.Context, clock Clock) error {
+	for {
+		l.mu.Lock()
+		now := clock.Now()
+		if l.last.IsZero() {
+			l.tokens = l.burst
+		} else if elapsed := now.Sub(l.last); elapsed > 0 {
+			l.tokens += elapsed.Seconds() * l.rate
+			if l.tokens > l.burst {
+				l.tokens = l.burst
+			}
+		}
+		l.last = now
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+		delay := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		l.mu.Unlock()
+
+		select {
+		case <-clock.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
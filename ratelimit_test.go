@@ -0,0 +1,104 @@
+package cron
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	start := time.Date(2016, time.March, 1, 12, 0, 0, 0, time.UTC)
+	clock := newFakeClock(start)
+	l := &rateLimiter{rate: 2, burst: 3}
+
+	// The burst is admitted right away.
+	for i := 0; i < 3; i++ {
+		if err := l.wait(context.Background(), clock); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Further starts wait for a token, every half second.
+	admitted := make(chan time.Time)
+	go func() {
+		for i := 0; i < 2; i++ {
+			l.wait(context.Background(), clock)
+			admitted <- clock.Now()
+		}
+	}()
+	for _, expected := range []time.Duration{500 * time.Millisecond, time.Second} {
+		clock.waitForTimers(t, 1)
+		clock.Advance(500 * time.Millisecond)
+		if now := <-admitted; !now.Equal(start.Add(expected)) {
+			t.Errorf("(expected) admitted at %v != %v (actual)", start.Add(expected), now)
+		}
+	}
+
+	// Waiting fails when the context is done.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := l.wait(ctx, clock); err != context.Canceled {
+		t.Errorf("(expected) %v != %v (actual)", context.Canceled, err)
+	}
+}
+
+func TestWithRateLimitBounds(t *testing.T) {
+	tests := []struct {
+		perSecond float64
+		burst     int
+		limited   bool
+		expected  float64 // burst of the limiter
+	}{
+		{2, 3, true, 3},
+		{2, 0, true, 1},
+		{2, -5, true, 1},
+		{0, 3, false, 0},
+		{-1, 3, false, 0},
+		{math.NaN(), 3, false, 0},
+		{math.Inf(1), 3, false, 0},
+	}
+	for _, c := range tests {
+		cron := New(WithRateLimit(c.perSecond, c.burst))
+		if (cron.limiter != nil) != c.limited || c.limited && cron.limiter.burst != c.expected {
+			t.Errorf("%v, %d: unexpected limiter %+v", c.perSecond, c.burst, cron.limiter)
+		}
+	}
+
+	// A burst rounded up to one admits one start right away, and the next one
+	// after the interval.
+	start := time.Date(2016, time.March, 1, 12, 0, 0, 0, time.UTC)
+	clock := newFakeClock(start)
+	cron := New(WithRateLimit(1, 0))
+	if err := cron.limiter.wait(context.Background(), clock); err != nil {
+		t.Fatal(err)
+	}
+	admitted := make(chan time.Time)
+	go func() {
+		cron.limiter.wait(context.Background(), clock)
+		admitted <- clock.Now()
+	}()
+	clock.waitForTimers(t, 1)
+	clock.Advance(time.Second)
+	if now := <-admitted; !now.Equal(start.Add(time.Second)) {
+		t.Errorf("(expected) admitted at %v != %v (actual)", start.Add(time.Second), now)
+	}
+}
+
+// A Cron with a rate limit starts its runs at the rate.
+func TestWithRateLimit(t *testing.T) {
+	ran := make(chan struct{}, 5)
+	cron := New(WithRateLimit(20, 1))
+	for i := 0; i < 5; i++ {
+		cron.AddFunc("@reboot", func() { ran <- struct{}{} })
+	}
+	begin := time.Now()
+	cron.Start()
+	defer cron.Stop()
+	for i := 0; i < 5; i++ {
+		<-ran
+	}
+	if elapsed := time.Since(begin); elapsed < 200*time.Millisecond {
+		t.Errorf("expected 5 starts at 20 per second to take 200ms, took %v", elapsed)
+	}
+}
@@ -0,0 +1,48 @@
+package cron
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"runtime/debug"
+)
+
+// PanicError is the error of a job which panicked, recovered by the Cron.
+type PanicError struct {
+	Value interface{} // Value passed to panic
+	Stack []byte      // Stack trace of the goroutine of the job
+}
+
+// Error returns the description of the error.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("Job panicked: %v", e.Value)
+}
+
+// PanicHandler handles a panic of the job of the entry, which has been
+// recovered.  The entry is a copy, without its statistics.
+type PanicHandler func(e *Entry, err *PanicError)
+
+// LogPanic is a PanicHandler, which logs the panic with its stack trace by the
+// log package.
+func LogPanic(e *Entry, err *PanicError) {
+	log.Printf("cron: job of entry %d %q panicked: %v\n%s", e.ID, e.Name, err.Value, err.Stack)
+}
+
+// runEntry runs the job of a run of an entry, with the context if it is a
+// ContextJob or an ErrorJob.  It returns the error of an ErrorJob, or a
+// *PanicError if the job panicked, which is passed to the PanicHandler or else
+// logged.
+func (c *Cron) runEntry(ctx context.Context, run *Entry) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			perr := &PanicError{Value: r, Stack: debug.Stack()}
+			if c.panicHandler != nil {
+				c.panicHandler(run, perr)
+			} else {
+				c.logger.Error(perr, "panic", "entry", run.ID, "name", run.Name, "stack", string(perr.Stack))
+			}
+			err = perr
+		}
+	}()
+	return runJob(ctx, run.Job)
+}
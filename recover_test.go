@@ -0,0 +1,51 @@
+package cron
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+// Panics of the jobs are recovered, passed to the handler, and counted as
+// failures.
+func TestPanicHandler(t *testing.T) {
+	handled := make(chan *PanicError, 1)
+	var entry *Entry
+
+	cron := New(WithPanicHandler(func(e *Entry, err *PanicError) {
+		entry = e
+		handled <- err
+	}))
+	id, _ := cron.AddNamedJob("broken", "* * * * * ?", FuncJob(func() { panic("broken") }))
+	cron.Start()
+	defer cron.Stop()
+
+	select {
+	case err := <-handled:
+		if err.Value != "broken" || !bytes.Contains(err.Stack, []byte("TestPanicHandler")) {
+			t.Errorf("unexpected panic %v\n%s", err.Value, err.Stack)
+		}
+		if entry.ID != id || entry.Name != "broken" || entry.Prev.IsZero() {
+			t.Errorf("unexpected entry %+v", entry)
+		}
+	case <-time.After(ONE_SECOND):
+		t.Fatal("expected the panic to be handled")
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	if s := cron.EntryByName("broken").Stats; s.Runs != 1 || s.Failures != 1 {
+		t.Errorf("(expected) 1 failed run != %+v (actual)", s)
+	}
+
+	// Simulated jobs are recovered as well.
+	cron = New(WithPanicHandler(func(e *Entry, err *PanicError) {}))
+	cron.AddFunc("@hourly", func() { panic("broken") })
+	from := time.Date(2016, time.March, 1, 0, 0, 0, 0, time.UTC)
+	activations, _ := cron.Simulate(from, from.Add(time.Hour))
+	if len(activations) != 1 {
+		t.Fatalf("(expected) 1 activation != %v (actual)", activations)
+	}
+	if _, ok := activations[0].Err.(*PanicError); !ok {
+		t.Errorf("(expected) a *PanicError != %v (actual)", activations[0].Err)
+	}
+}
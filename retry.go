@@ -0,0 +1,66 @@
+package cron
+
+import (
+	"context"
+	"time"
+)
+
+// RetryPolicy determines how often and when the failed runs of a job are
+// retried, before the next activation of its entry.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts of a run, including the
+	// first one.
+	MaxAttempts int
+
+	// Backoff is the delay before the first retry.  Each further delay is the
+	// previous one times the Multiplier, so a Multiplier of 0 or 1 retries at
+	// a fixed delay, and one of 2 backs off exponentially.
+	Backoff    time.Duration
+	Multiplier float64
+
+	// Retryable returns true if a run which failed with the error is retried.
+	// A nil Retryable retries all errors, including panics.
+	Retryable func(err error) bool
+}
+
+// SetRetryPolicy retries the failed runs of the entry with the given ID by the
+// policy.  A retry which would start at or after the next activation of the
+// entry is not attempted.  The run counts as one in the Stats of the entry,
+// with the error of its last attempt.  It returns an error if there is no such
+// entry.
+func (c *Cron) SetRetryPolicy(id EntryID, policy RetryPolicy) error {
+	return c.updateEntry(id, func(e *Entry, running bool) {
+		e.retry = &policy
+	})
+}
+
+// retry runs the job of a run of an entry like runEntry, retrying it by the
+// policy of the entry until it succeeds, and returns the error of the last
+// attempt.
+func (c *Cron) retry(ctx context.Context, run *Entry) error {
+	err := c.runEntry(ctx, run)
+	policy := run.retry
+	if policy == nil {
+		return err
+	}
+
+	delay := policy.Backoff
+	for attempt := 1; err != nil && err != ErrSkipped && attempt < policy.MaxAttempts; attempt++ {
+		if policy.Retryable != nil && !policy.Retryable(err) {
+			return err
+		}
+		if !run.Next.IsZero() && !c.clock.Now().Add(delay).Before(run.Next) {
+			return err
+		}
+		select {
+		case <-c.clock.After(delay):
+		case <-ctx.Done():
+			return err
+		}
+		err = c.runEntry(ctx, run)
+		if policy.Multiplier > 0 {
+			delay = time.Duration(float64(delay) * policy.Multiplier)
+		}
+	}
+	return err
+}
@@ -0,0 +1,86 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	var (
+		failed    = errors.New("failed")
+		permanent = errors.New("permanent")
+	)
+	tests := []struct {
+		policy   RetryPolicy
+		errs     []error // errors of the attempts, nil afterwards
+		attempts int
+		err      error
+		delays   []time.Duration
+	}{
+		// Fixed backoff until the job succeeds.
+		{RetryPolicy{MaxAttempts: 5, Backoff: time.Minute}, []error{failed, failed}, 3, nil, []time.Duration{time.Minute, time.Minute}},
+
+		// Exponential backoff up to the maximum number of attempts.
+		{RetryPolicy{MaxAttempts: 3, Backoff: time.Minute, Multiplier: 2}, []error{failed, failed, failed, failed}, 3, failed, []time.Duration{time.Minute, 2 * time.Minute}},
+
+		// Errors which are not retryable.
+		{RetryPolicy{MaxAttempts: 3, Backoff: time.Minute, Retryable: func(err error) bool { return err != permanent }}, []error{failed, permanent}, 2, permanent, []time.Duration{time.Minute}},
+
+		// Retries stop before the next activation, an hour after the first.
+		{RetryPolicy{MaxAttempts: 10, Backoff: 20 * time.Minute}, []error{failed, failed, failed, failed}, 3, failed, []time.Duration{20 * time.Minute, 20 * time.Minute}},
+	}
+	for i, c := range tests {
+		start := time.Date(2016, time.March, 1, 12, 0, 0, 0, time.UTC)
+		clock := newFakeClock(start)
+		attempts := 0
+		var delays []time.Duration
+		job := ErrorFuncJob(func(ctx context.Context) error {
+			attempts++
+			if attempts > 1 {
+				delays = append(delays, clock.Now().Sub(start))
+				start = clock.Now()
+			}
+			if attempts <= len(c.errs) {
+				return c.errs[attempts-1]
+			}
+			return nil
+		})
+
+		cron := New(WithClock(clock))
+		policy := c.policy
+		run := &Entry{Job: job, Next: start.Add(time.Hour), retry: &policy}
+		result := make(chan error)
+		go func() { result <- cron.retry(context.Background(), run) }()
+
+		var err error
+	wait:
+		for {
+			select {
+			case err = <-result:
+				break wait
+			case <-time.After(time.Millisecond):
+				clock.Advance(time.Minute)
+			}
+		}
+
+		if attempts != c.attempts || err != c.err {
+			t.Errorf("%d: (expected) %d attempts, %v != %d, %v (actual)", i, c.attempts, c.err, attempts, err)
+		}
+		if len(delays) != len(c.delays) {
+			t.Errorf("%d: (expected) delays %v != %v (actual)", i, c.delays, delays)
+			continue
+		}
+		for j := range delays {
+			if delays[j] != c.delays[j] {
+				t.Errorf("%d: (expected) delays %v != %v (actual)", i, c.delays, delays)
+			}
+		}
+	}
+
+	// Entries which are unknown can not be retried.
+	if err := New().SetRetryPolicy(1, RetryPolicy{}); err == nil {
+		t.Error("expected an error for an unknown ID")
+	}
+}
@@ -0,0 +1,456 @@
+package cron
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is the FREQ of a recurrence rule.
+type Frequency int
+
+const (
+	Minutely Frequency = iota
+	Hourly
+	Daily
+	Weekly
+	Monthly
+	Yearly
+)
+
+// frequencies maps the names of the frequencies in a recurrence rule.
+var frequencies = map[string]Frequency{
+	"MINUTELY": Minutely,
+	"HOURLY":   Hourly,
+	"DAILY":    Daily,
+	"WEEKLY":   Weekly,
+	"MONTHLY":  Monthly,
+	"YEARLY":   Yearly,
+}
+
+// weekdays maps the names of the weekdays in a recurrence rule.
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// RRuleDay is a weekday of the BYDAY part of a recurrence rule, e.g. "MO" or
+// "-1FR".  A non-zero N selects the N-th occurrence of the weekday within the
+// month (or year), counting from the end if negative.
+type RRuleDay struct {
+	Weekday time.Weekday
+	N       int
+}
+
+// RRuleSchedule represents an iCalendar (RFC 5545) recurrence rule, e.g.
+// "FREQ=MONTHLY;BYDAY=-1FR;COUNT=12".  The activations start at Start (the
+// DTSTART of the rule) and have its time of day.
+type RRuleSchedule struct {
+	Start    time.Time
+	Freq     Frequency
+	Interval int
+
+	// Count limits the number of activations, counted from Start.  Until is the
+	// last possible activation time.  Zero values do not limit the rule.
+	Count int
+	Until time.Time
+
+	ByDay      []RRuleDay
+	ByMonthDay []int
+	ByMonth    []time.Month
+	WeekStart  time.Weekday
+}
+
+// ParseRRule returns a new schedule representing the given recurrence rule,
+// starting at the given time.  The rule may be prefixed with "RRULE:".
+// It returns a descriptive error if the rule is not valid.
+//
+// It accepts the rule parts FREQ (MINUTELY to YEARLY), INTERVAL, COUNT, UNTIL,
+// BYDAY, BYMONTHDAY, BYMONTH and WKST, e.g.
+//   - "FREQ=WEEKLY;BYDAY=MO,WE,FR"
+//   - "FREQ=MONTHLY;BYDAY=-1FR;UNTIL=20251231T235959Z"
+//   - "FREQ=YEARLY;BYMONTH=1;BYMONTHDAY=1;COUNT=5"
+func ParseRRule(rule string, start time.Time) (_ Schedule, err error) {
+	// Convert panics into errors
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("%v", recovered)
+		}
+	}()
+
+	schedule := &RRuleSchedule{
+		Start:     start.Truncate(time.Second),
+		Freq:      -1,
+		Interval:  1,
+		WeekStart: time.Monday,
+	}
+
+	for _, part := range strings.Split(strings.TrimPrefix(rule, "RRULE:"), ";") {
+		nameAndValue := strings.Split(part, "=")
+		if len(nameAndValue) != 2 {
+			log.Panicf("Expected NAME=VALUE: %s", part)
+		}
+		name, value := strings.ToUpper(nameAndValue[0]), strings.ToUpper(nameAndValue[1])
+		switch name {
+		case "FREQ":
+			freq, ok := frequencies[value]
+			if !ok {
+				log.Panicf("Unsupported frequency: %s", part)
+			}
+			schedule.Freq = freq
+		case "INTERVAL":
+			schedule.Interval = int(mustParseInt(value))
+			if schedule.Interval == 0 {
+				log.Panicf("Interval must be positive: %s", part)
+			}
+		case "COUNT":
+			schedule.Count = int(mustParseInt(value))
+			if schedule.Count == 0 {
+				log.Panicf("Count must be positive: %s", part)
+			}
+		case "UNTIL":
+			schedule.Until = parseUntil(value, start.Location())
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				schedule.ByDay = append(schedule.ByDay, parseRRuleDay(day))
+			}
+		case "BYMONTHDAY":
+			for _, day := range strings.Split(value, ",") {
+				n := parseSignedInt(day)
+				if n == 0 || n < -31 || n > 31 {
+					log.Panicf("Day of month (%d) not within 1-31 or -31 to -1: %s", n, part)
+				}
+				schedule.ByMonthDay = append(schedule.ByMonthDay, n)
+			}
+		case "BYMONTH":
+			for _, month := range strings.Split(value, ",") {
+				n := mustParseInt(month)
+				if n < months.min || n > months.max {
+					log.Panicf("Month (%d) not within 1-12: %s", n, part)
+				}
+				schedule.ByMonth = append(schedule.ByMonth, time.Month(n))
+			}
+		case "WKST":
+			day, ok := weekdays[value]
+			if !ok {
+				log.Panicf("Unknown weekday: %s", part)
+			}
+			schedule.WeekStart = day
+		default:
+			log.Panicf("Unsupported rule part: %s", part)
+		}
+	}
+
+	if schedule.Freq < 0 {
+		log.Panicf("Missing FREQ: %s", rule)
+	}
+	if schedule.Count > 0 && !schedule.Until.IsZero() {
+		log.Panicf("COUNT and UNTIL may not be given both: %s", rule)
+	}
+	return schedule, nil
+}
+
+// parseUntil returns the time of the given UNTIL value, which is a UTC time
+// (20251231T235959Z), a local time (20251231T235959) or a date (20251231)
+// which includes the whole day.
+func parseUntil(value string, loc *time.Location) time.Time {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t
+	}
+	if t, err := time.ParseInLocation("20060102T150405", value, loc); err == nil {
+		return t
+	}
+	t, err := time.ParseInLocation("20060102", value, loc)
+	if err != nil {
+		log.Panicf("Failed to parse UNTIL: %s", value)
+	}
+	return t.AddDate(0, 0, 1).Add(-time.Second)
+}
+
+// parseRRuleDay returns the weekday of the BYDAY value, e.g. "MO" or "-1FR".
+func parseRRuleDay(value string) RRuleDay {
+	if len(value) < 2 {
+		log.Panicf("Unknown weekday: %s", value)
+	}
+	day, ok := weekdays[value[len(value)-2:]]
+	if !ok {
+		log.Panicf("Unknown weekday: %s", value)
+	}
+	var n int
+	if len(value) > 2 {
+		n = parseSignedInt(value[:len(value)-2])
+		if n == 0 || n < -53 || n > 53 {
+			log.Panicf("Occurrence of weekday (%d) not within 1-53 or -53 to -1: %s", n, value)
+		}
+	}
+	return RRuleDay{Weekday: day, N: n}
+}
+
+// parseSignedInt parses the given expression as an int with an optional sign,
+// or panics.
+func parseSignedInt(expr string) int {
+	num, err := strconv.Atoi(expr)
+	if err != nil {
+		log.Panicf("Failed to parse int from %s: %s", expr, err)
+	}
+	return num
+}
+
+// Next returns the first activation of the rule later than the given time, or
+// the zero time if the rule has ended.  If no activation is found within five
+// years, return the zero time.
+func (s *RRuleSchedule) Next(t time.Time) time.Time {
+	// Without a count, the periods before the given time can be skipped.
+	period := 0
+	if s.Count == 0 {
+		period = s.periodsBefore(t)
+	}
+
+	limit := t.AddDate(5, 0, 0)
+	count := 0
+	for ; ; period++ {
+		start := s.periodStart(period)
+		if start.After(limit) || !s.Until.IsZero() && start.After(s.Until) {
+			return time.Time{}
+		}
+
+		for _, candidate := range s.candidates(start) {
+			if candidate.Before(s.Start) {
+				continue
+			}
+			if !s.Until.IsZero() && candidate.After(s.Until) {
+				return time.Time{}
+			}
+			count++
+			if s.Count > 0 && count > s.Count {
+				return time.Time{}
+			}
+			if candidate.After(t) {
+				return candidate
+			}
+		}
+	}
+}
+
+// periodsBefore returns the number of whole periods from the start of the rule
+// which end before the given time.
+func (s *RRuleSchedule) periodsBefore(t time.Time) int {
+	if !t.After(s.Start) {
+		return 0
+	}
+
+	var n int
+	switch s.Freq {
+	case Minutely:
+		n = int(t.Sub(s.Start) / time.Minute)
+	case Hourly:
+		n = int(t.Sub(s.Start) / time.Hour)
+	case Daily:
+		n = int(t.Sub(s.Start) / (24 * time.Hour))
+	case Weekly:
+		n = int(t.Sub(s.Start) / (7 * 24 * time.Hour))
+	case Monthly:
+		n = (t.Year()-s.Start.Year())*12 + int(t.Month()-s.Start.Month())
+	case Yearly:
+		n = t.Year() - s.Start.Year()
+	}
+
+	// Keep a period of margin for daylight savings time transitions.
+	n = n/s.Interval - 1
+	if n < 0 {
+		return 0
+	}
+	return n
+}
+
+// periodStart returns the beginning of the given period of the rule.
+func (s *RRuleSchedule) periodStart(period int) time.Time {
+	var (
+		st = s.Start
+		n  = period * s.Interval
+	)
+	switch s.Freq {
+	case Minutely:
+		return st.Add(time.Duration(n) * time.Minute)
+	case Hourly:
+		return st.Add(time.Duration(n) * time.Hour)
+	case Daily:
+		return time.Date(st.Year(), st.Month(), st.Day()+n, 0, 0, 0, 0, st.Location())
+	case Weekly:
+		offset := (int(st.Weekday()) - int(s.WeekStart) + 7) % 7
+		return time.Date(st.Year(), st.Month(), st.Day()-offset+7*n, 0, 0, 0, 0, st.Location())
+	case Monthly:
+		return time.Date(st.Year(), st.Month()+time.Month(n), 1, 0, 0, 0, 0, st.Location())
+	}
+	return time.Date(st.Year()+n, time.January, 1, 0, 0, 0, 0, st.Location())
+}
+
+// candidates returns the sorted activation times within the period starting
+// at the given time, before limiting them by Start, Until and Count.
+func (s *RRuleSchedule) candidates(start time.Time) []time.Time {
+	var days []time.Time
+	switch s.Freq {
+	case Minutely, Hourly:
+		if s.matches(start) {
+			return []time.Time{start}
+		}
+		return nil
+	case Daily:
+		days = []time.Time{start}
+	case Weekly:
+		for i := 0; i < 7; i++ {
+			day := start.AddDate(0, 0, i)
+			if len(s.ByDay) > 0 || day.Weekday() == s.Start.Weekday() {
+				days = append(days, day)
+			}
+		}
+	case Monthly:
+		days = s.monthDays(start)
+	case Yearly:
+		if len(s.ByMonth) == 0 && (len(s.ByDay) > 0 || len(s.ByMonthDay) > 0) {
+			days = s.yearDays(start)
+			break
+		}
+		for m := time.January; m <= time.December; m++ {
+			if len(s.ByMonth) > 0 && !s.monthMatches(m) || len(s.ByMonth) == 0 && m != s.Start.Month() {
+				continue
+			}
+			days = append(days, s.monthDays(time.Date(start.Year(), m, 1, 0, 0, 0, 0, start.Location()))...)
+		}
+	}
+
+	var candidates []time.Time
+	for _, day := range days {
+		if !s.matches(day) {
+			continue
+		}
+		candidates = append(candidates, time.Date(day.Year(), day.Month(), day.Day(),
+			s.Start.Hour(), s.Start.Minute(), s.Start.Second(), 0, day.Location()))
+	}
+	sort.Sort(byInstant(candidates))
+	return candidates
+}
+
+// monthDays returns the days of the month starting at the given time, which
+// are selected by BYMONTHDAY and BYDAY, or the day of Start if neither is given.
+func (s *RRuleSchedule) monthDays(start time.Time) []time.Time {
+	last := daysIn(start.Month(), start.Year())
+	if len(s.ByMonthDay) == 0 && len(s.ByDay) == 0 {
+		if s.Start.Day() > last {
+			return nil
+		}
+		return []time.Time{start.AddDate(0, 0, s.Start.Day()-1)}
+	}
+
+	var days []time.Time
+	for d := 1; d <= last; d++ {
+		day := start.AddDate(0, 0, d-1)
+		if len(s.ByMonthDay) > 0 && !s.monthDayMatches(day) {
+			continue
+		}
+		if len(s.ByDay) > 0 && !s.weekdayMatches(day, d, last) {
+			continue
+		}
+		days = append(days, day)
+	}
+	return days
+}
+
+// yearDays returns the days of the year starting at the given time, which are
+// selected by BYMONTHDAY and BYDAY, with the ordinals of BYDAY counting within
+// the year.
+func (s *RRuleSchedule) yearDays(start time.Time) []time.Time {
+	last := start.AddDate(1, 0, -1).YearDay()
+
+	var days []time.Time
+	for d := 1; d <= last; d++ {
+		day := start.AddDate(0, 0, d-1)
+		if len(s.ByMonthDay) > 0 && !s.monthDayMatches(day) {
+			continue
+		}
+		if len(s.ByDay) > 0 && !s.weekdayMatches(day, d, last) {
+			continue
+		}
+		days = append(days, day)
+	}
+	return days
+}
+
+// matches returns true if the given time satisfies the filters of the rule
+// which apply to every frequency.
+func (s *RRuleSchedule) matches(t time.Time) bool {
+	if len(s.ByMonth) > 0 && !s.monthMatches(t.Month()) {
+		return false
+	}
+	switch s.Freq {
+	case Minutely, Hourly, Daily:
+		if len(s.ByMonthDay) > 0 && !s.monthDayMatches(t) {
+			return false
+		}
+		if len(s.ByDay) > 0 && !s.weekdayMatches(t, 0, 0) {
+			return false
+		}
+	case Weekly:
+		if len(s.ByDay) > 0 && !s.weekdayMatches(t, 0, 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// monthMatches returns true if the month is part of BYMONTH.
+func (s *RRuleSchedule) monthMatches(m time.Month) bool {
+	for _, month := range s.ByMonth {
+		if month == m {
+			return true
+		}
+	}
+	return false
+}
+
+// monthDayMatches returns true if the day of the given time is part of
+// BYMONTHDAY.
+func (s *RRuleSchedule) monthDayMatches(t time.Time) bool {
+	last := daysIn(t.Month(), t.Year())
+	for _, d := range s.ByMonthDay {
+		if d == t.Day() || d < 0 && last+1+d == t.Day() {
+			return true
+		}
+	}
+	return false
+}
+
+// weekdayMatches returns true if the weekday of the given time is part of
+// BYDAY.  The ordinals of BYDAY apply to the position of the day within a
+// period of the given length, and are ignored if the length is 0.
+func (s *RRuleSchedule) weekdayMatches(t time.Time, position, length int) bool {
+	for _, day := range s.ByDay {
+		if day.Weekday != t.Weekday() {
+			continue
+		}
+		switch {
+		case day.N == 0 || length == 0:
+			return true
+		case day.N > 0 && (position-1)/7+1 == day.N:
+			return true
+		case day.N < 0 && (length-position)/7+1 == -day.N:
+			return true
+		}
+	}
+	return false
+}
+
+// byInstant is a wrapper for sorting times.
+type byInstant []time.Time
+
+func (s byInstant) Len() int           { return len(s) }
+func (s byInstant) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byInstant) Less(i, j int) bool { return s[i].Before(s[j]) }
@@ -0,0 +1,117 @@
+package cron
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseRRule(t *testing.T) {
+	start := time.Date(2025, time.January, 1, 9, 30, 0, 0, time.UTC)
+	sched, err := ParseRRule("RRULE:FREQ=MONTHLY;INTERVAL=2;BYDAY=MO,-1FR;BYMONTHDAY=1,-1;BYMONTH=1,7;COUNT=10;WKST=SU", start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &RRuleSchedule{
+		Start:      start,
+		Freq:       Monthly,
+		Interval:   2,
+		Count:      10,
+		ByDay:      []RRuleDay{{time.Monday, 0}, {time.Friday, -1}},
+		ByMonthDay: []int{1, -1},
+		ByMonth:    []time.Month{time.January, time.July},
+		WeekStart:  time.Sunday,
+	}
+	if !reflect.DeepEqual(sched, expected) {
+		t.Errorf("(expected) %v != %v (actual)", expected, sched)
+	}
+
+	invalidRules := []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=SECONDLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=0",
+		"FREQ=DAILY;COUNT=5;UNTIL=20250101",
+		"FREQ=DAILY;UNTIL=2025",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=MONTHLY;BYDAY=0MO",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=YEARLY;BYMONTH=13",
+		"FREQ=DAILY;BYHOUR=9",
+		"FREQ=DAILY;WKST",
+	}
+	for _, rule := range invalidRules {
+		if _, err := ParseRRule(rule, start); err == nil {
+			t.Error("expected an error parsing: ", rule)
+		}
+	}
+}
+
+func TestRRuleNext(t *testing.T) {
+	tests := []struct {
+		start, rule string
+		time        string
+		expected    string
+	}{
+		// Daily
+		{"2025-01-01T09:30:00Z", "FREQ=DAILY", "2024-12-01T00:00:00Z", "2025-01-01T09:30:00Z"},
+		{"2025-01-01T09:30:00Z", "FREQ=DAILY", "2025-01-01T09:30:00Z", "2025-01-02T09:30:00Z"},
+		{"2025-01-01T09:30:00Z", "FREQ=DAILY;INTERVAL=3", "2025-03-01T10:00:00Z", "2025-03-02T09:30:00Z"},
+		{"2025-01-01T09:30:00Z", "FREQ=DAILY;BYDAY=MO,FR", "2025-01-01T10:00:00Z", "2025-01-03T09:30:00Z"},
+		{"2025-01-01T09:30:00Z", "FREQ=DAILY;COUNT=3", "2025-01-02T10:00:00Z", "2025-01-03T09:30:00Z"},
+		{"2025-01-01T09:30:00Z", "FREQ=DAILY;COUNT=3", "2025-01-03T09:30:00Z", ""},
+		{"2025-01-01T09:30:00Z", "FREQ=DAILY;UNTIL=20250105", "2025-01-04T10:00:00Z", "2025-01-05T09:30:00Z"},
+		{"2025-01-01T09:30:00Z", "FREQ=DAILY;UNTIL=20250105T000000Z", "2025-01-04T10:00:00Z", ""},
+
+		// Weekly, Wednesday 2025-01-01
+		{"2025-01-01T09:30:00Z", "FREQ=WEEKLY", "2025-01-01T10:00:00Z", "2025-01-08T09:30:00Z"},
+		{"2025-01-01T09:30:00Z", "FREQ=WEEKLY;BYDAY=MO,WE,FR", "2025-01-01T10:00:00Z", "2025-01-03T09:30:00Z"},
+		{"2025-01-01T09:30:00Z", "FREQ=WEEKLY;BYDAY=MO,WE,FR", "2025-01-03T10:00:00Z", "2025-01-06T09:30:00Z"},
+		{"2025-01-01T09:30:00Z", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO", "2025-01-01T10:00:00Z", "2025-01-13T09:30:00Z"},
+		{"2025-01-01T09:30:00Z", "FREQ=WEEKLY;BYDAY=TU,TH;COUNT=4", "2025-01-08T10:00:00Z", "2025-01-09T09:30:00Z"},
+		{"2025-01-01T09:30:00Z", "FREQ=WEEKLY;BYDAY=TU,TH;COUNT=4", "2025-01-09T10:00:00Z", "2025-01-14T09:30:00Z"},
+		{"2025-01-01T09:30:00Z", "FREQ=WEEKLY;BYDAY=TU,TH;COUNT=4", "2025-01-14T09:30:00Z", ""},
+
+		// Monthly
+		{"2025-01-31T09:30:00Z", "FREQ=MONTHLY", "2025-01-31T10:00:00Z", "2025-03-31T09:30:00Z"},
+		{"2025-01-01T09:30:00Z", "FREQ=MONTHLY;BYMONTHDAY=-1", "2025-01-31T10:00:00Z", "2025-02-28T09:30:00Z"},
+		{"2025-01-01T09:30:00Z", "FREQ=MONTHLY;BYDAY=-1FR", "2025-01-01T10:00:00Z", "2025-01-31T09:30:00Z"},
+		{"2025-01-01T09:30:00Z", "FREQ=MONTHLY;BYDAY=2TU", "2025-01-15T10:00:00Z", "2025-02-11T09:30:00Z"},
+		{"2025-01-01T09:30:00Z", "FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13", "2025-01-01T10:00:00Z", "2025-06-13T09:30:00Z"},
+		{"2025-01-01T09:30:00Z", "FREQ=MONTHLY;BYMONTHDAY=15;BYMONTH=3,9", "2025-04-01T10:00:00Z", "2025-09-15T09:30:00Z"},
+		{"2025-01-01T09:30:00Z", "FREQ=MONTHLY;BYMONTHDAY=1;COUNT=12", "2025-12-01T09:30:00Z", ""},
+
+		// Yearly
+		{"2025-03-15T09:30:00Z", "FREQ=YEARLY", "2025-03-15T10:00:00Z", "2026-03-15T09:30:00Z"},
+		{"2024-02-29T09:30:00Z", "FREQ=YEARLY", "2024-03-01T00:00:00Z", "2028-02-29T09:30:00Z"},
+		{"2025-01-01T09:30:00Z", "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH", "2025-01-01T10:00:00Z", "2025-11-27T09:30:00Z"},
+		{"2025-01-01T09:30:00Z", "FREQ=YEARLY;BYDAY=1MO", "2025-01-08T10:00:00Z", "2026-01-05T09:30:00Z"},
+		{"2025-01-01T09:30:00Z", "FREQ=YEARLY;BYDAY=-1SU", "2025-01-08T10:00:00Z", "2025-12-28T09:30:00Z"},
+
+		// Hourly and minutely
+		{"2025-01-01T09:30:00Z", "FREQ=HOURLY;INTERVAL=6", "2025-01-02T01:00:00Z", "2025-01-02T03:30:00Z"},
+		{"2025-01-01T09:30:00Z", "FREQ=MINUTELY;INTERVAL=15;BYDAY=SA", "2025-01-01T10:00:00Z", "2025-01-04T00:00:00Z"},
+
+		// Unsatisfiable
+		{"2025-01-01T09:30:00Z", "FREQ=MONTHLY;BYMONTHDAY=30;BYMONTH=2", "2025-01-01T10:00:00Z", ""},
+	}
+
+	for _, c := range tests {
+		start, _ := time.Parse(time.RFC3339, c.start)
+		sched, err := ParseRRule(c.rule, start)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		from, _ := time.Parse(time.RFC3339, c.time)
+		var expected time.Time
+		if c.expected != "" {
+			expected, _ = time.Parse(time.RFC3339, c.expected)
+		}
+		actual := sched.Next(from)
+		if !actual.Equal(expected) {
+			t.Errorf("%s, %s, \"%s\": (expected) %v != %v (actual)", c.start, c.time, c.rule, expected, actual)
+		}
+	}
+}
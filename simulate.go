@@ -0,0 +1,68 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+)
+
+// Activation is a run of a job in a simulation.
+type Activation struct {
+	ID   EntryID   // ID of the entry
+	Name string    // Name of the entry, if any
+	Time time.Time // Time of the activation
+	Err  error     // Error of an ErrorJob
+}
+
+// Simulate runs the jobs of a Cron which is not running in virtual time, from
+// the given time up to and including the until time, and returns their
+// activations in order.  Rather than waiting for the activations, the jobs
+// are run one after the other in the calling goroutine, e.g. to verify what
+// would run in the next 30 days within a test:
+//
+//	activations, err := c.Simulate(now, now.AddDate(0, 0, 30))
+//
+// The next and previous activations and the statistics of the entries are
+// updated, paused and backed off entries are not run, and jobs are run with a
+// background context.  Panics and errors of the jobs are handled like in a
+// running Cron, as runs taking no time, but failed runs are not retried.  It
+// returns an error if the Cron is running.
+func (c *Cron) Simulate(from, until time.Time) ([]Activation, error) {
+	if c.running {
+		return nil, errors.New("Cron is running")
+	}
+
+	now := from.In(c.location)
+	for _, e := range c.entries {
+		e.Next = e.firstActivation(now)
+	}
+
+	var activations []Activation
+	for {
+		sort.Sort(byTime(c.entries))
+		if len(c.entries) == 0 || c.entries[0].Next.IsZero() || c.entries[0].Next.After(until) {
+			return activations, nil
+		}
+
+		effective := c.entries[0].Next
+		for _, e := range c.entries {
+			if !e.Next.Equal(effective) {
+				break
+			}
+			e.Next = e.next(effective)
+			if !e.Paused && !c.backedOff(e, effective) {
+				run := e.run(effective)
+				c.before(RunInfo{Entry: run, Scheduled: effective, Start: effective})
+				err := c.runEntry(withActivations(context.Background(), []time.Time{effective}), run)
+				c.finished(RunInfo{Entry: run, Scheduled: effective, Start: effective, Err: err})
+				c.mu.Lock()
+				e.Stats.start(effective)
+				e.Stats.finish(effective, effective, err)
+				c.mu.Unlock()
+				activations = append(activations, Activation{ID: e.ID, Name: e.Name, Time: effective, Err: err})
+				e.Prev = effective
+			}
+		}
+	}
+}
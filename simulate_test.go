@@ -0,0 +1,54 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSimulate(t *testing.T) {
+	var runs []string
+
+	cron := New(WithLocation(time.UTC))
+	cron.AddNamedJob("daily", "0 0 6 * * *", FuncJob(func() { runs = append(runs, "daily") }))
+	cron.AddNamedJob("weekly", "0 0 6 * * MON", ErrorFuncJob(func(ctx context.Context) error {
+		runs = append(runs, "weekly")
+		return errors.New("failed")
+	}))
+	paused, _ := cron.AddFunc("@hourly", func() { t.Error("expected the paused entry not to run") })
+	cron.Pause(paused)
+
+	// Tuesday 1st to Tuesday 8th, with both jobs on Monday 7th.
+	from := time.Date(2016, time.March, 1, 0, 0, 0, 0, time.UTC)
+	activations, err := cron.Simulate(from, from.AddDate(0, 0, 7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(activations) != 8 || len(runs) != 8 {
+		t.Fatalf("(expected) 8 activations != %d, %v (actual)", len(activations), runs)
+	}
+	for i, a := range activations {
+		if i > 0 && a.Time.Before(activations[i-1].Time) {
+			t.Errorf("expected the activations in order, got %v", activations)
+		}
+		if (a.Name == "weekly") != (a.Err != nil) {
+			t.Errorf("%s: unexpected error %v", a.Name, a.Err)
+		}
+	}
+	monday := time.Date(2016, time.March, 7, 6, 0, 0, 0, time.UTC)
+	if a, b := activations[6], activations[7]; a.Name == b.Name || !a.Time.Equal(monday) || !b.Time.Equal(monday) {
+		t.Errorf("(expected) both jobs on March 7th != %+v, %+v (actual)", a, b)
+	}
+
+	e := cron.EntryByName("daily")
+	if !e.Prev.Equal(monday) || !e.Next.Equal(time.Date(2016, time.March, 8, 6, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected previous and next activations %v, %v", e.Prev, e.Next)
+	}
+
+	cron.Start()
+	defer cron.Stop()
+	if _, err := cron.Simulate(from, from.AddDate(0, 0, 1)); err == nil {
+		t.Error("expected an error if the Cron is running")
+	}
+}
@@ -0,0 +1,100 @@
+package cron
+
+import (
+	"math"
+	"time"
+)
+
+// SolarEvent is the position of the sun at which a SolarSchedule activates.
+type SolarEvent int
+
+const (
+	SunriseEvent SolarEvent = iota // The upper limb of the sun appears on the horizon
+	SunsetEvent                    // The upper limb of the sun disappears below the horizon
+)
+
+// SolarSchedule activates daily at sunrise or sunset, plus an offset, at a
+// location on earth, e.g. "30 minutes before sunset".  The times are accurate
+// to about a minute.
+type SolarSchedule struct {
+	Latitude  float64 // Degrees north of the equator, negative for the south
+	Longitude float64 // Degrees east of Greenwich, negative for the west
+	Event     SolarEvent
+	Offset    time.Duration // Added to the time of the event, negative for before
+}
+
+// Sunrise returns a Schedule that activates daily at sunrise at the given
+// coordinates, plus the offset.
+func Sunrise(latitude, longitude float64, offset time.Duration) SolarSchedule {
+	return SolarSchedule{Latitude: latitude, Longitude: longitude, Event: SunriseEvent, Offset: offset}
+}
+
+// Sunset returns a Schedule that activates daily at sunset at the given
+// coordinates, plus the offset.
+func Sunset(latitude, longitude float64, offset time.Duration) SolarSchedule {
+	return SolarSchedule{Latitude: latitude, Longitude: longitude, Event: SunsetEvent, Offset: offset}
+}
+
+// Next returns the next activation after the given time, rounded to the
+// second.  Days on which the sun does not rise or set, e.g. during the polar
+// night, are skipped.  If there is no activation within a year, return the
+// zero time.
+func (s SolarSchedule) Next(t time.Time) time.Time {
+	// The offset may move the activation of another day after t.
+	day := t.UTC().Add(-s.Offset).AddDate(0, 0, -1)
+	for i := 0; i <= 368; i++ {
+		if event, ok := s.event(day.AddDate(0, 0, i)); ok {
+			next := event.Add(s.Offset).Round(time.Second)
+			if next.After(t) {
+				return next.In(t.Location())
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// event returns the time of the event on the solar day around the given day in
+// UTC, or false if the sun does not rise or set on it.
+func (s SolarSchedule) event(day time.Time) (time.Time, bool) {
+	const (
+		unixEpoch = 2440587.5 // Julian day of 1970-01-01 00:00 UTC
+		j2000     = 2451545.0 // Julian day of 2000-01-01 12:00 UTC
+	)
+	noon := time.Date(day.Year(), day.Month(), day.Day(), 12, 0, 0, 0, time.UTC)
+	n := float64(noon.Unix())/86400 + unixEpoch - j2000 + 0.0008
+
+	// Mean solar noon, solar mean anomaly, equation of the center and
+	// ecliptic longitude of the sun.
+	meanNoon := n - s.Longitude/360
+	anomaly := math.Mod(357.5291+0.98560028*meanNoon, 360)
+	center := 1.9148*sinDeg(anomaly) + 0.02*sinDeg(2*anomaly) + 0.0003*sinDeg(3*anomaly)
+	longitude := math.Mod(anomaly+center+180+102.9372, 360)
+	transit := j2000 + meanNoon + 0.0053*sinDeg(anomaly) - 0.0069*sinDeg(2*longitude)
+
+	// Declination of the sun and hour angle at which it appears, corrected for
+	// the refraction and the size of the sun.
+	declination := math.Asin(sinDeg(longitude) * sinDeg(23.44))
+	cosHourAngle := (sinDeg(-0.833) - sinDeg(s.Latitude)*math.Sin(declination)) /
+		(cosDeg(s.Latitude) * math.Cos(declination))
+	if cosHourAngle < -1 || cosHourAngle > 1 {
+		return time.Time{}, false
+	}
+	hourAngle := math.Acos(cosHourAngle) * 180 / math.Pi
+
+	julian := transit + hourAngle/360
+	if s.Event == SunriseEvent {
+		julian = transit - hourAngle/360
+	}
+	seconds := (julian - unixEpoch) * 86400
+	return time.Unix(0, int64(seconds*float64(time.Second))).UTC(), true
+}
+
+// sinDeg returns the sine of the angle in degrees.
+func sinDeg(degrees float64) float64 {
+	return math.Sin(degrees * math.Pi / 180)
+}
+
+// cosDeg returns the cosine of the angle in degrees.
+func cosDeg(degrees float64) float64 {
+	return math.Cos(degrees * math.Pi / 180)
+}
@@ -0,0 +1,56 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSolarNext(t *testing.T) {
+	var (
+		zurich    = [2]float64{47.3769, 8.5417}
+		sanFran   = [2]float64{37.7749, -122.4194}
+		sydney    = [2]float64{-33.8688, 151.2093}
+		tromso    = [2]float64{69.6492, 18.9553}
+		zurichLoc = time.FixedZone("CEST", 2*60*60)
+	)
+
+	tests := []struct {
+		coordinates    [2]float64
+		event          SolarEvent
+		offset         time.Duration
+		time, expected string
+	}{
+		{zurich, SunriseEvent, 0, "Mon Jul 9 00:00 2012", "Mon Jul 9 03:40 2012"},
+		{zurich, SunsetEvent, 0, "Mon Jul 9 00:00 2012", "Mon Jul 9 19:24 2012"},
+		{zurich, SunsetEvent, -30 * time.Minute, "Mon Jul 9 00:00 2012", "Mon Jul 9 18:54 2012"},
+		{zurich, SunsetEvent, 0, "Mon Jul 9 19:30 2012", "Tue Jul 10 19:24 2012"},
+		{zurich, SunriseEvent, 0, "Fri Dec 21 00:00 2012", "Fri Dec 21 07:13 2012"},
+
+		// The offset moves the sunset of the day before after the time.
+		{zurich, SunsetEvent, 6 * time.Hour, "Tue Jul 10 00:00 2012", "Tue Jul 10 01:24 2012"},
+
+		// Sunset in California is after midnight in UTC.
+		{sanFran, SunsetEvent, 0, "Mon Jul 9 12:00 2012", "Tue Jul 10 03:34 2012"},
+		{sydney, SunriseEvent, 0, "Mon Jul 9 00:00 2012", "Mon Jul 9 20:59 2012"},
+	}
+
+	for _, c := range tests {
+		s := SolarSchedule{Latitude: c.coordinates[0], Longitude: c.coordinates[1], Event: c.event, Offset: c.offset}
+		actual := s.Next(getTime(c.time))
+		expected := getTime(c.expected)
+		if diff := actual.Sub(expected); diff < -2*time.Minute || diff > 2*time.Minute {
+			t.Errorf("%+v, %s: (expected) %v != %v (actual)", s, c.time, expected, actual)
+		}
+	}
+
+	// The midnight sun does not set until the end of July.
+	actual := Sunset(tromso[0], tromso[1], 0).Next(getTime("Thu Jun 21 00:00 2012"))
+	if actual.Before(getTime("Fri Jul 20 00:00 2012")) || actual.After(getTime("Tue Jul 31 00:00 2012")) {
+		t.Errorf("(expected) sunset at the end of July != %v (actual)", actual)
+	}
+
+	actual = Sunrise(zurich[0], zurich[1], 0).Next(time.Date(2012, time.July, 9, 0, 0, 0, 0, zurichLoc))
+	if actual.Location() != zurichLoc || actual.Nanosecond() != 0 {
+		t.Errorf("(expected) activation in %v != %v (actual)", zurichLoc, actual)
+	}
+}
@@ -1,6 +1,9 @@
 package cron
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -8,6 +11,37 @@ import (
 // traditional crontab specification. It is computed initially and stored as bit sets.
 type SpecSchedule struct {
 	Second, Minute, Hour, Dom, Month, Dow uint64
+
+	// Year lists the years in which the schedule is active, in ascending order.
+	// A nil Year matches every year.
+	Year []int
+
+	// Location is the time zone in which the schedule is evaluated.  A nil
+	// Location uses the location of the time passed to Next.
+	Location *time.Location
+
+	// Gap chooses whether Next activates at wall clock times which are skipped
+	// at the start of daylight saving time, and Overlap how often at wall
+	// clock times which are repeated at its end.
+	Gap     GapPolicy
+	Overlap OverlapPolicy
+
+	// Days which are relative to the month and can not be represented by the
+	// Dom and Dow bit sets.
+	lastDom        bool   // the last day of the month
+	lastWeekdayDom bool   // the last weekday (Monday to Friday) of the month
+	lastDow        uint64 // bits of the weekdays matching on their last occurrence
+	weekdayDom     uint64 // bits of the days matching on their nearest weekday
+	nthDow         uint64 // bits 8*(n-1)+weekday of weekdays matching on their n-th occurrence
+
+	// dayAnd requires both the day of month and the day of week to match, even
+	// if both are restricted.
+	dayAnd bool
+
+	// millis lists the milliseconds within each matching second at which the
+	// schedule activates, in ascending order.  A nil millis activates at the
+	// start of the second only.
+	millis []int
 }
 
 // bounds provides a range of acceptable values (plus a map of name to value).
@@ -45,6 +79,21 @@ var (
 		"fri": 5,
 		"sat": 6,
 	}}
+	years = bounds{1970, 2099, nil}
+
+	// The milliseconds which may follow the seconds field, e.g. "*.0/500".
+	milliseconds = bounds{0, 999, nil}
+
+	// The day of week as numbered by the Quartz scheduler.
+	quartzDow = bounds{1, 7, map[string]uint{
+		"sun": 1,
+		"mon": 2,
+		"tue": 3,
+		"wed": 4,
+		"thu": 5,
+		"fri": 6,
+		"sat": 7,
+	}}
 )
 
 const (
@@ -55,6 +104,42 @@ const (
 // Next returns the next time this schedule is activated, greater than the given
 // time.  If no time can be found to satisfy the schedule, return the zero time.
 func (s *SpecSchedule) Next(t time.Time) time.Time {
+	next := s.next(t)
+	for s.Overlap != OverlapBoth && !next.IsZero() && s.skipsRepeat(next) {
+		next = s.next(next)
+	}
+	if s.Gap == GapRunAfter {
+		next = s.nextAfterGap(t, next)
+	}
+	return next
+}
+
+// next returns the next activation at an existing wall clock time, greater
+// than the given time.
+func (s *SpecSchedule) next(t time.Time) time.Time {
+	if len(s.millis) == 0 {
+		return s.nextSecond(t)
+	}
+
+	// Another millisecond may remain within the second of t.
+	second := t.Add(-time.Duration(t.Nanosecond()))
+	if s.nextSecond(second.Add(-time.Nanosecond)).Equal(second) {
+		for _, ms := range s.millis {
+			if next := second.Add(time.Duration(ms) * time.Millisecond); next.After(t) {
+				return next
+			}
+		}
+	}
+	next := s.nextSecond(t)
+	if next.IsZero() {
+		return next
+	}
+	return next.Add(time.Duration(s.millis[0]) * time.Millisecond)
+}
+
+// nextSecond returns the start of the next second in which this schedule is
+// activated, greater than the given time.
+func (s *SpecSchedule) nextSecond(t time.Time) time.Time {
 	// General approach:
 	// For Month, Day, Hour, Minute, Second:
 	// Check if the time value matches.  If yes, continue to the next field.
@@ -63,6 +148,13 @@ func (s *SpecSchedule) Next(t time.Time) time.Time {
 	// of the field list (since it is necessary to re-verify previous field
 	// values)
 
+	// Evaluate the schedule in its own location, if given, and return the
+	// activation time in the location of the given time.
+	origLocation := t.Location()
+	if s.Location != nil {
+		t = t.In(s.Location)
+	}
+
 	// Start at the earliest possible time (the upcoming second).
 	t = t.Add(1*time.Second - time.Duration(t.Nanosecond())*time.Nanosecond)
 
@@ -77,6 +169,18 @@ WRAP:
 		return time.Time{}
 	}
 
+	// Skip ahead to the first applicable year.
+	if !s.yearMatches(t.Year()) {
+		year, ok := s.nextYear(t.Year())
+		if !ok {
+			return time.Time{}
+		}
+		// The search is limited to five years from the first applicable year.
+		yearLimit = year + 5
+		added = true
+		t = time.Date(year, time.January, 1, 0, 0, 0, 0, t.Location())
+	}
+
 	// Find the first applicable month.
 	// If it's this month, then do nothing.
 	for 1<<uint(t.Month())&s.Month == 0 {
@@ -110,7 +214,9 @@ WRAP:
 	for 1<<uint(t.Hour())&s.Hour == 0 {
 		if !added {
 			added = true
-			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
+			// Truncate by subtraction, which keeps the offset of a wall clock
+			// time occurring twice.
+			t = t.Add(-time.Duration(t.Minute())*time.Minute - time.Duration(t.Second())*time.Second)
 		}
 		t = t.Add(1 * time.Hour)
 
@@ -122,7 +228,7 @@ WRAP:
 	for 1<<uint(t.Minute())&s.Minute == 0 {
 		if !added {
 			added = true
-			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, t.Location())
+			t = t.Add(-time.Duration(t.Second()) * time.Second)
 		}
 		t = t.Add(1 * time.Minute)
 
@@ -132,10 +238,6 @@ WRAP:
 	}
 
 	for 1<<uint(t.Second())&s.Second == 0 {
-		if !added {
-			added = true
-			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, t.Location())
-		}
 		t = t.Add(1 * time.Second)
 
 		if t.Second() == 0 {
@@ -143,19 +245,383 @@ WRAP:
 		}
 	}
 
-	return t
+	return t.In(origLocation)
+}
+
+// Prev returns the previous time this schedule was activated, less than the
+// given time.  If no time can be found to satisfy the schedule, return the zero
+// time.
+func (s *SpecSchedule) Prev(t time.Time) time.Time {
+	if len(s.millis) == 0 {
+		return s.prevSecond(t)
+	}
+
+	// An earlier millisecond may remain within the second of t.
+	second := t.Add(-time.Duration(t.Nanosecond()))
+	if s.nextSecond(second.Add(-time.Nanosecond)).Equal(second) {
+		for i := len(s.millis) - 1; i >= 0; i-- {
+			if prev := second.Add(time.Duration(s.millis[i]) * time.Millisecond); prev.Before(t) {
+				return prev
+			}
+		}
+	}
+	prev := s.prevSecond(second)
+	if prev.IsZero() {
+		return prev
+	}
+	return prev.Add(time.Duration(s.millis[len(s.millis)-1]) * time.Millisecond)
+}
+
+// prevSecond returns the start of the previous second in which this schedule
+// was activated, less than the given time.
+func (s *SpecSchedule) prevSecond(t time.Time) time.Time {
+	// General approach, mirroring Next:
+	// For Month, Day, Hour, Minute, Second:
+	// Check if the time value matches.  If yes, continue to the next field.
+	// If the field doesn't match the schedule, then go back to the last second
+	// of the preceding value of the field, until it matches.  A wrap-around
+	// brings it back to the beginning of the field list.
+
+	origLocation := t.Location()
+	if s.Location != nil {
+		t = t.In(s.Location)
+	}
+
+	// Start at the latest possible time (the preceding second).
+	t = t.Add(-1 * time.Nanosecond)
+	t = t.Add(-time.Duration(t.Nanosecond()) * time.Nanosecond)
+
+	// If no time is found within five years, return zero.
+	yearLimit := t.Year() - 5
+
+WRAP:
+	if t.Year() < yearLimit {
+		return time.Time{}
+	}
+
+	// Skip back to the last applicable year.
+	if !s.yearMatches(t.Year()) {
+		year, ok := s.prevYear(t.Year())
+		if !ok {
+			return time.Time{}
+		}
+		// The search is limited to five years from the last applicable year.
+		yearLimit = year - 5
+		t = time.Date(year, time.December, 31, 23, 59, 59, 0, t.Location())
+	}
+
+	for 1<<uint(t.Month())&s.Month == 0 {
+		t = secondBefore(t, time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location()))
+
+		// Wrapped around.
+		if t.Month() == time.December {
+			goto WRAP
+		}
+	}
+
+	for !dayMatches(s, t) {
+		t = secondBefore(t, time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()))
+
+		if t.Day() == daysIn(t.Month(), t.Year()) {
+			goto WRAP
+		}
+	}
+
+	for 1<<uint(t.Hour())&s.Hour == 0 {
+		// Step back from the instant, since the hour may be repeated at the
+		// end of daylight saving time.
+		t = t.Add(-time.Duration(t.Minute()*60+t.Second()+1) * time.Second)
+
+		if t.Hour() == 23 {
+			goto WRAP
+		}
+	}
+
+	for 1<<uint(t.Minute())&s.Minute == 0 {
+		t = t.Add(-time.Duration(t.Second()+1) * time.Second)
+
+		if t.Minute() == 59 {
+			goto WRAP
+		}
+	}
+
+	for 1<<uint(t.Second())&s.Second == 0 {
+		t = t.Add(-1 * time.Second)
+
+		if t.Second() == 59 {
+			goto WRAP
+		}
+	}
+
+	return t.In(origLocation)
+}
+
+// secondBefore returns the last second before start, the beginning of the
+// month or day of t on the wall clock.  If that is repeated at the end of
+// daylight saving time, start may resolve to an instant which is not before t;
+// then it steps back from t by the difference on the wall clock instead, so
+// that the search always makes progress.
+func secondBefore(t, start time.Time) time.Time {
+	if prev := start.Add(-1 * time.Second); prev.Before(t) {
+		return prev
+	}
+	wall := func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+	}
+	return t.Add(-wall(t).Sub(wall(start)) - time.Second)
+}
+
+// MarshalText returns the schedule as a spec with the fields second, minute,
+// hour, day of month, month, day of week and, if restricted, year, which is
+// accepted by Parse.  The spec is prefixed with "CRON_TZ=" if the schedule has
+// a Location.
+func (s *SpecSchedule) MarshalText() ([]byte, error) {
+	if s.dayAnd {
+		return nil, fmt.Errorf("Schedule requiring both the day of month and the day of week can not be written as a spec")
+	}
+	if s.Gap != GapSkip || s.Overlap != OverlapBoth {
+		return nil, fmt.Errorf("Schedule with a daylight saving time policy can not be written as a spec")
+	}
+
+	domItems := formatBits(s.Dom, dom)
+	if s.lastDom {
+		domItems = append(domItems, "L")
+	}
+	if s.lastWeekdayDom {
+		domItems = append(domItems, "LW")
+	}
+	for _, day := range bitValues(s.weekdayDom, dom) {
+		domItems = append(domItems, strconv.Itoa(day)+"W")
+	}
+	dowItems := formatBits(s.Dow, dow)
+	for _, day := range bitValues(s.lastDow, dow) {
+		dowItems = append(dowItems, strconv.Itoa(day)+"L")
+	}
+	for nth := 0; nth < 5; nth++ {
+		for _, day := range bitValues(s.nthDow>>uint(8*nth), dow) {
+			dowItems = append(dowItems, strconv.Itoa(day)+"#"+strconv.Itoa(nth+1))
+		}
+	}
+
+	secondItems := formatBits(s.Second, seconds)
+	if len(s.millis) > 0 {
+		// The milliseconds apply to every second, so the list of seconds is
+		// wrapped into a single item.
+		secondItems = []string{strings.Join(secondItems, ",") + "." + strings.Join(formatMillis(s.millis), ",")}
+	}
+
+	fields := [][]string{
+		secondItems,
+		formatBits(s.Minute, minutes),
+		formatBits(s.Hour, hours),
+		domItems,
+		formatBits(s.Month, months),
+		dowItems,
+	}
+	if len(s.Year) > 0 {
+		fields = append(fields, formatValues(s.Year))
+	}
+	spec := make([]string, len(fields))
+	for i, items := range fields {
+		if len(items) == 0 {
+			return nil, fmt.Errorf("Schedule with an empty field can not be written as a spec")
+		}
+		spec[i] = strings.Join(items, ",")
+	}
+
+	text := strings.Join(spec, " ")
+	if s.Location != nil {
+		text = "CRON_TZ=" + s.Location.String() + " " + text
+	}
+	return []byte(text), nil
+}
+
+// UnmarshalText sets the schedule to the spec, which is parsed with Parse.
+// It returns a descriptive error if the spec is not valid, or does not result
+// in a SpecSchedule.
+func (s *SpecSchedule) UnmarshalText(text []byte) error {
+	schedule, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	spec, ok := schedule.(*SpecSchedule)
+	if !ok {
+		return fmt.Errorf("Expected a crontab spec, found %T: %s", schedule, text)
+	}
+	*s = *spec
+	return nil
+}
+
+// formatBits returns the items of the field given by the bits, e.g. "*", "*/15"
+// or "1-5".  The star is retained, as it affects the matching of days.
+func formatBits(bits uint64, r bounds) []string {
+	values := bitValues(bits, r)
+	if bits&starBit == 0 {
+		return formatValues(values)
+	}
+	if len(values) == int(r.max-r.min+1) {
+		return []string{"*"}
+	}
+	if d := step(values, r); d > 0 {
+		return []string{"*/" + strconv.Itoa(d)}
+	}
+	// A star stepping over the whole range matches only the minimum.
+	return append([]string{"*/" + strconv.Itoa(int(r.max-r.min+1))}, formatValues(values[1:])...)
+}
+
+// formatMillis returns the milliseconds as items, using a step where they are
+// spaced evenly over the second.
+func formatMillis(millis []int) []string {
+	switch d := step(millis, milliseconds); {
+	case d == 1:
+		return []string{"*"}
+	case d > 0:
+		return []string{"0/" + strconv.Itoa(d)}
+	}
+	return formatValues(millis)
+}
+
+// formatValues returns the ascending values as items, where runs of three or
+// more consecutive values are written as ranges.
+func formatValues(values []int) []string {
+	var items []string
+	for _, run := range runs(values) {
+		if len(run) >= 3 {
+			items = append(items, strconv.Itoa(run[0])+"-"+strconv.Itoa(run[len(run)-1]))
+			continue
+		}
+		for _, v := range run {
+			items = append(items, strconv.Itoa(v))
+		}
+	}
+	return items
+}
+
+// activates returns true if there is any day on which the schedule is
+// activated.  Without years, the days of 28 years are checked, after which the
+// days of the week and the leap years repeat (within 1901-2099).
+func (s *SpecSchedule) activates() bool {
+	years := s.Year
+	if years == nil {
+		for y := 2000; y < 2028; y++ {
+			years = append(years, y)
+		}
+	}
+	for _, year := range years {
+		for t := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC); t.Year() == year; t = t.AddDate(0, 0, 1) {
+			if 1<<uint(t.Month())&s.Month > 0 && dayMatches(s, t) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// yearMatches returns true if the schedule is active in the given year.
+func (s *SpecSchedule) yearMatches(year int) bool {
+	if s.Year == nil {
+		return true
+	}
+	for _, y := range s.Year {
+		if y == year {
+			return true
+		}
+	}
+	return false
+}
+
+// nextYear returns the first year of the schedule after the given year, or
+// false if there is none.
+func (s *SpecSchedule) nextYear(year int) (int, bool) {
+	for _, y := range s.Year {
+		if y > year {
+			return y, true
+		}
+	}
+	return 0, false
+}
+
+// prevYear returns the last year of the schedule before the given year, or
+// false if there is none.
+func (s *SpecSchedule) prevYear(year int) (int, bool) {
+	for i := len(s.Year) - 1; i >= 0; i-- {
+		if s.Year[i] < year {
+			return s.Year[i], true
+		}
+	}
+	return 0, false
 }
 
 // dayMatches returns true if the schedule's day-of-week and day-of-month
 // restrictions are satisfied by the given time.
 func dayMatches(s *SpecSchedule, t time.Time) bool {
 	var (
-		domMatch bool = 1<<uint(t.Day())&s.Dom > 0
-		dowMatch bool = 1<<uint(t.Weekday())&s.Dow > 0
+		lastDay  bool = t.Day() == daysIn(t.Month(), t.Year())
+		lastWeek bool = t.Day()+7 > daysIn(t.Month(), t.Year())
+		domMatch bool = 1<<uint(t.Day())&s.Dom > 0 ||
+			s.lastDom && lastDay ||
+			s.lastWeekdayDom && t.Day() == lastWeekday(t.Year(), t.Month()) ||
+			s.weekdayDom > 0 && nearestWeekdayMatches(s.weekdayDom, t)
+		dowMatch bool = 1<<uint(t.Weekday())&s.Dow > 0 ||
+			1<<uint(t.Weekday())&s.lastDow > 0 && lastWeek ||
+			1<<uint(8*((t.Day()-1)/7)+int(t.Weekday()))&s.nthDow > 0
 	)
 
-	if s.Dom&starBit > 0 || s.Dow&starBit > 0 {
+	if s.dayAnd || s.Dom&starBit > 0 || s.Dow&starBit > 0 {
 		return domMatch && dowMatch
 	}
 	return domMatch || dowMatch
 }
+
+// nearestWeekdayMatches returns true if the given time is on the weekday
+// nearest to one of the days in the bit set.
+func nearestWeekdayMatches(days uint64, t time.Time) bool {
+	// The nearest weekday is at most two days away from the day itself.
+	for day := t.Day() - 2; day <= t.Day()+2; day++ {
+		if day < 1 || day > daysIn(t.Month(), t.Year()) || 1<<uint(day)&days == 0 {
+			continue
+		}
+		if nearestWeekday(t.Year(), t.Month(), day) == t.Day() {
+			return true
+		}
+	}
+	return false
+}
+
+// nearestWeekday returns the day of month of the weekday (Monday to Friday)
+// nearest to the given day.  The weekday is always within the same month, so
+// a Saturday on the 1st moves to Monday the 3rd and a Sunday on the last day
+// of the month moves to the Friday before.
+func nearestWeekday(year int, month time.Month, day int) int {
+	switch time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Weekday() {
+	case time.Saturday:
+		if day == 1 {
+			return day + 2
+		}
+		return day - 1
+	case time.Sunday:
+		if day == daysIn(month, year) {
+			return day - 2
+		}
+		return day + 1
+	}
+	return day
+}
+
+// lastWeekday returns the day of month of the last weekday (Monday to Friday)
+// of the given month.
+func lastWeekday(year int, month time.Month) int {
+	day := daysIn(month, year)
+	switch time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Weekday() {
+	case time.Saturday:
+		return day - 1
+	case time.Sunday:
+		return day - 2
+	}
+	return day
+}
+
+// daysIn returns the number of days in the given month.
+func daysIn(m time.Month, year int) int {
+	return time.Date(year, m+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -1,6 +1,8 @@
 package cron
 
 import (
+	"encoding/json"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -116,6 +118,57 @@ func TestNext(t *testing.T) {
 		{"2012-11-04T00:00:00-0400", "0 30 2 04 Nov ?", "2012-11-04T02:30:00-0500"},
 		{"2012-11-04T01:45:00-0400", "0 30 1 04 Nov ?", "2012-11-04T01:30:00-0500"},
 
+		// Last day of the month
+		{"Mon Jul 9 23:35 2012", "0 0 0 L * ?", "Tue Jul 31 00:00 2012"},
+		{"Tue Jul 31 00:00 2012", "0 0 0 L * ?", "Fri Aug 31 00:00 2012"},
+		{"Mon Jul 9 23:35 2012", "0 0 0 L Feb ?", "Fri Feb 28 00:00 2013"},
+		{"Mon Jan 9 23:35 2012", "0 0 0 L Feb ?", "Wed Feb 29 00:00 2012"},
+		{"Mon Jul 9 23:35 2012", "0 0 0 1,L * ?", "Tue Jul 31 00:00 2012"},
+
+		// Last weekday of the month
+		{"Mon Jul 9 23:35 2012", "0 0 18 LW * ?", "Tue Jul 31 18:00 2012"},
+		{"Tue Jul 31 18:00 2012", "0 0 18 lw * ?", "Fri Aug 31 18:00 2012"},
+		{"Fri Aug 31 18:00 2012", "0 0 18 LW * ?", "Fri Sep 28 18:00 2012"},
+		{"Fri Sep 28 18:00 2012", "0 0 18 LW * ?", "Wed Oct 31 18:00 2012"},
+		{"Mon Jul 9 23:35 2012", "0 0 18 LW Jun ?", "Fri Jun 28 18:00 2013"},
+
+		// Nearest weekday
+		{"Mon Jul 9 23:35 2012", "0 0 0 15W * ?", "Mon Jul 16 00:00 2012"},
+		{"Mon Jul 9 23:35 2012", "0 0 0 14W * ?", "Fri Jul 13 00:00 2012"},
+		{"Mon Jul 9 23:35 2012", "0 0 0 13W * ?", "Fri Jul 13 00:00 2012"},
+		{"Mon Jul 9 23:35 2012", "0 0 0 1W Sep ?", "Mon Sep 3 00:00 2012"},
+		{"Mon Jul 9 23:35 2012", "0 0 0 30W Sep ?", "Fri Sep 28 00:00 2012"},
+		{"Mon Jul 9 23:35 2012", "0 0 0 31W Sep ?", ""},
+		{"Mon Jul 9 23:35 2012", "0 0 0 1w,15W Aug ?", "Wed Aug 1 00:00 2012"},
+		{"Fri Jul 13 00:00 2012", "0 0 0 15W * ?", "Mon Jul 16 00:00 2012"},
+
+		// N-th weekday of the month
+		{"Mon Jul 9 23:35 2012", "0 0 9 * * 2#3", "Tue Jul 17 09:00 2012"},
+		{"Mon Jul 9 23:35 2012", "0 0 9 * * 1#2", "Mon Aug 13 09:00 2012"},
+		{"Mon Jul 9 23:35 2012", "0 0 9 * * MON#1,FRI#3", "Fri Jul 20 09:00 2012"},
+		{"Mon Jul 9 23:35 2012", "0 0 9 * * 0#5", "Sun Jul 29 09:00 2012"},
+		{"Sun Jul 29 09:00 2012", "0 0 9 * * 0#5", "Sun Sep 30 09:00 2012"},
+
+		// Last weekday of the month
+		{"Mon Jul 9 23:35 2012", "0 0 0 * * 5L", "Fri Jul 27 00:00 2012"},
+		{"Fri Jul 27 00:00 2012", "0 0 0 * * FRIL", "Fri Aug 31 00:00 2012"},
+		{"Mon Jul 9 23:35 2012", "0 0 0 * * 1L,0L", "Sun Jul 29 00:00 2012"},
+		{"Mon Jul 9 23:35 2012", "0 0 0 * Sep 1L", "Mon Sep 24 00:00 2012"},
+
+		// Years
+		{"Mon Jul 9 23:35 2012", "0 0 0 1 1 * 2013", "Tue Jan 1 00:00 2013"},
+		{"Mon Jul 9 23:35 2012", "0 0 0 1 1 * 2012", ""},
+		{"Mon Jul 9 23:35 2012", "0 0 0 * * Mon 2012-2013", "Mon Jul 16 00:00 2012"},
+		{"Mon Dec 31 23:35 2012", "0 0 0 * * Mon 2012", ""},
+
+		// Milliseconds
+		{"Mon Jul 9 14:45 2012", "*.0/500 * 9-17 * * MON-FRI", "Mon Jul 9 14:45:00.5 2012"},
+		{"Mon Jul 9 14:45:00.5 2012", "*.0/500 * 9-17 * * MON-FRI", "Mon Jul 9 14:45:01 2012"},
+		{"Mon Jul 9 17:59:59.5 2012", "*.0/500 * 9-17 * * MON-FRI", "Tue Jul 10 09:00 2012"},
+		{"Mon Jul 9 14:45:00.1 2012", "0.250 * * * *", "Mon Jul 9 14:45:00.25 2012"},
+		{"Mon Jul 9 14:45:00.25 2012", "0.250 * * * *", "Mon Jul 9 14:46:00.25 2012"},
+		{"Mon Jul 9 14:45:30.9 2012", "0-29.100,900 * * * *", "Mon Jul 9 14:46:00.1 2012"},
+
 		// Unsatisfiable
 		{"Mon Jul 9 23:35 2012", "0 0 0 30 Feb ?", ""},
 		{"Mon Jul 9 23:35 2012", "0 0 0 31 Apr ?", ""},
@@ -135,12 +188,222 @@ func TestNext(t *testing.T) {
 	}
 }
 
+func TestPrev(t *testing.T) {
+	runs := []struct {
+		time, spec string
+		expected   string
+	}{
+		// Simple cases
+		{"Mon Jul 9 14:45 2012", "0 0/15 * * *", "Mon Jul 9 14:30 2012"},
+		{"Mon Jul 9 14:46 2012", "0 0/15 * * *", "Mon Jul 9 14:45 2012"},
+		{"Mon Jul 9 14:45:00.005 2012", "0 0/15 * * *", "Mon Jul 9 14:45 2012"},
+		{"Mon Jul 9 14:45:01 2012", "* * * * *", "Mon Jul 9 14:45 2012"},
+
+		// Wrap around hours
+		{"Mon Jul 9 15:00 2012", "0 20-35/15 * * *", "Mon Jul 9 14:35 2012"},
+
+		// Wrap around days
+		{"Tue Jul 10 00:05 2012", "0 10 * * * *", "Mon Jul 9 23:10 2012"},
+		{"Tue Jul 10 06:00 2012", "0 30 22 * * *", "Mon Jul 9 22:30 2012"},
+		{"Mon Jul 9 14:45 2012", "0 0 0 * * Sat", "Sat Jul 7 00:00 2012"},
+
+		// Wrap around months
+		{"Mon Jul 9 14:45 2012", "0 0 0 15 * *", "Fri Jun 15 00:00 2012"},
+		{"Mon Jul 9 14:45 2012", "0 0 0 31 * *", "Thu May 31 00:00 2012"},
+		{"Mon Jul 9 14:45 2012", "0 0 0 * Feb *", "Wed Feb 29 00:00 2012"},
+
+		// Wrap around years
+		{"Mon Jul 9 14:45 2012", "0 0 0 1 Sep *", "Thu Sep 1 00:00 2011"},
+		{"Tue Jan 1 00:00 2013", "* * * * * *", "Mon Dec 31 23:59:59 2012"},
+
+		// Leap year
+		{"Mon Jul 9 14:45 2012", "0 0 0 29 Feb ?", "Wed Feb 29 00:00 2012"},
+		{"Sat Jul 9 14:45 2011", "0 0 0 29 Feb ?", "Fri Feb 29 00:00 2008"},
+
+		// Last day and weekdays of the month
+		{"Mon Jul 9 14:45 2012", "0 0 0 L * *", "Sat Jun 30 00:00 2012"},
+		{"Mon Jul 9 14:45 2012", "0 0 0 * * 5L", "Fri Jun 29 00:00 2012"},
+		{"Mon Jul 9 14:45 2012", "0 0 0 * * 1#2", "Mon Jul 9 00:00 2012"},
+
+		// Years
+		{"Mon Jul 9 14:45 2012", "0 0 0 1 1 * 2010", "Fri Jan 1 00:00 2010"},
+		{"Mon Jul 9 14:45 2012", "0 0 0 1 1 * 2013", ""},
+		{"Mon Jul 9 14:45 2012", "0 0 0 * * * 2000", "Sun Dec 31 00:00 2000"},
+
+		// Milliseconds
+		{"Mon Jul 9 14:45 2012", "*.0/500 * 9-17 * * MON-FRI", "Mon Jul 9 14:44:59.5 2012"},
+		{"Mon Jul 9 14:45:00.5 2012", "*.0/500 * 9-17 * * MON-FRI", "Mon Jul 9 14:45 2012"},
+		{"Mon Jul 9 09:00 2012", "*.0/500 * 9-17 * * MON-FRI", "Fri Jul 6 17:59:59.5 2012"},
+		{"Mon Jul 9 14:45:00.25 2012", "0.250 * * * *", "Mon Jul 9 14:44:00.25 2012"},
+		{"Mon Jul 9 14:45:00.3 2012", "0.250 * * * *", "Mon Jul 9 14:45:00.25 2012"},
+
+		// Unsatisfiable
+		{"Mon Jul 9 23:35 2012", "0 0 0 30 Feb ?", ""},
+	}
+
+	for _, c := range runs {
+		sched, err := Parse(c.spec)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		actual := sched.(*SpecSchedule).Prev(getTime(c.time))
+		expected := getTime(c.expected)
+		if !actual.Equal(expected) {
+			t.Errorf("%s, \"%s\": (expected) %v != %v (actual)", c.time, c.spec, expected, actual)
+		}
+	}
+}
+
+func TestPrevLocation(t *testing.T) {
+	sched, err := Parse("TZ=America/New_York 0 0 6 * * ?")
+	if err != nil {
+		t.Fatal(err)
+	}
+	from, _ := time.Parse(time.RFC3339, "2012-07-09T09:00:00Z")
+	expected, _ := time.Parse(time.RFC3339, "2012-07-08T10:00:00Z")
+	actual := sched.(*SpecSchedule).Prev(from)
+	if !actual.Equal(expected) || actual.Location() != from.Location() {
+		t.Errorf("(expected) %v != %v (actual)", expected, actual)
+	}
+}
+
+func TestPrevDST(t *testing.T) {
+	runs := []struct {
+		zone, time, spec string
+		expected         string
+	}{
+		// Daylight saving time ends on October 31st 2021 in Zurich, at 03:00 CEST.
+		{"Europe/Zurich", "2021-11-01T00:00:00+01:00", "0 0 0 * * *", "2021-10-31T00:00:00+02:00"},
+		{"Europe/Zurich", "2021-11-01T00:00:00+01:00", "0 0 0 L * *", "2021-10-31T00:00:00+02:00"},
+		{"Europe/Zurich", "2021-11-01T00:00:00+01:00", "0 0 0 31 * *", "2021-10-31T00:00:00+02:00"},
+		{"Europe/Zurich", "2021-10-31T02:30:00+01:00", "0 0 * * * *", "2021-10-31T02:00:00+01:00"},
+		{"Europe/Zurich", "2021-10-31T02:00:00+01:00", "0 0 * * * *", "2021-10-31T02:00:00+02:00"},
+		{"Europe/Zurich", "2021-10-31T02:00:00+02:00", "0 0 * * * *", "2021-10-31T01:00:00+02:00"},
+		{"Europe/Zurich", "2021-10-31T03:00:00+01:00", "0 0 1 * * *", "2021-10-31T01:00:00+02:00"},
+		{"Europe/Zurich", "2021-10-31T02:10:00+01:00", "0 30 * * * *", "2021-10-31T02:30:00+02:00"},
+
+		// Daylight saving time starts on March 28th 2021 in Zurich, at 02:00 CET.
+		{"Europe/Zurich", "2021-03-28T03:00:00+02:00", "0 0 * * * *", "2021-03-28T01:00:00+01:00"},
+		{"Europe/Zurich", "2021-03-29T00:00:00+02:00", "0 0 0 * * *", "2021-03-28T00:00:00+01:00"},
+
+		// Daylight saving time ends on November 7th 2021 in New York, at 02:00 EDT.
+		{"America/New_York", "2021-11-08T00:00:00-05:00", "0 0 0 * * *", "2021-11-07T00:00:00-04:00"},
+		{"America/New_York", "2021-11-01T00:00:00-04:00", "0 0 0 L * *", "2021-10-31T00:00:00-04:00"},
+		{"America/New_York", "2021-11-07T01:00:00-05:00", "0 0 * * * *", "2021-11-07T01:00:00-04:00"},
+		{"America/New_York", "2021-11-07T02:00:00-05:00", "0 0 1 * * *", "2021-11-07T01:00:00-05:00"},
+		{"America/New_York", "2021-11-07T01:10:00-05:00", "0 30 * * * *", "2021-11-07T01:30:00-04:00"},
+
+		// Daylight saving time starts on March 14th 2021 in New York, at 02:00 EST.
+		{"America/New_York", "2021-03-14T03:00:00-04:00", "0 0 * * * *", "2021-03-14T01:00:00-05:00"},
+
+		// Midnight is repeated on November 7th 2021 in Havana.
+		{"America/Havana", "2021-11-07T00:30:00-04:00", "0 0 12 * * Sat", "2021-11-06T12:00:00-04:00"},
+		{"America/Havana", "2021-11-07T00:30:00-05:00", "0 0 12 * * Sat", "2021-11-06T12:00:00-04:00"},
+		{"America/Havana", "2021-11-07T00:30:00-05:00", "0 0 0 * * *", "2021-11-07T00:00:00-05:00"},
+	}
+
+	for _, c := range runs {
+		loc, err := time.LoadLocation(c.zone)
+		if err != nil {
+			t.Skip(err)
+		}
+		sched, err := Parse(c.spec)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		from, _ := time.Parse(time.RFC3339, c.time)
+		expected, _ := time.Parse(time.RFC3339, c.expected)
+		actual := sched.(*SpecSchedule).Prev(from.In(loc))
+		if !actual.Equal(expected) {
+			t.Errorf("%s, \"%s\": (expected) %v != %v (actual)", c.time, c.spec, expected, actual)
+		}
+	}
+}
+
+func TestNextYear(t *testing.T) {
+	runs := []struct {
+		time, spec string
+		expected   string
+	}{
+		{"Mon Jul 9 23:35 2012", "0 0 12 1 1 ? 2012", ""},
+		{"Mon Jul 9 23:35 2012", "0 0 12 1 1 ? 2013", "Tue Jan 1 12:00 2013"},
+		{"Mon Jul 9 23:35 2012", "0 0 12 ? 1 MON 2013", "Mon Jan 7 12:00 2013"},
+		{"Mon Jul 9 23:35 2012", "0 0 12 ? 1 MON 2012,2013", "Mon Jan 7 12:00 2013"},
+		{"Mon Jul 9 23:35 2012", "0 0 12 1 1 ? 2030", "Tue Jan 1 12:00 2030"},
+		{"Mon Jul 9 23:35 2012", "0 0 0 29 2 ? 2013-2099", "Mon Feb 29 00:00 2016"},
+		{"Mon Jul 9 23:35 2012", "0 0 0 30 2 ? 2030-2099", ""},
+
+		// Last weekday of the month in Quartz numbering
+		{"Mon Jul 9 23:35 2012", "0 0 0 ? * 6L", "Fri Jul 27 00:00 2012"},
+		{"Mon Jul 9 23:35 2012", "0 0 9 ? * 3#3", "Tue Jul 17 09:00 2012"},
+	}
+
+	for _, c := range runs {
+		sched, err := ParseQuartz(c.spec)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		actual := sched.Next(getTime(c.time))
+		expected := getTime(c.expected)
+		if !actual.Equal(expected) {
+			t.Errorf("%s, \"%s\": (expected) %v != %v (actual)", c.time, c.spec, expected, actual)
+		}
+	}
+}
+
+func TestNextLocation(t *testing.T) {
+	runs := []struct {
+		time, spec string
+		expected   string
+	}{
+		{"2012-07-09T00:00:00Z", "TZ=America/New_York 0 0 6 * * ?", "2012-07-09T10:00:00Z"},
+		{"2012-07-09T12:00:00Z", "TZ=America/New_York 0 0 6 * * ?", "2012-07-10T10:00:00Z"},
+		{"2012-12-09T12:00:00Z", "CRON_TZ=America/New_York 0 0 6 * * ?", "2012-12-10T11:00:00Z"},
+		{"2012-07-09T00:00:00Z", "CRON_TZ=Asia/Tokyo 0 0 6 * * Mon", "2012-07-15T21:00:00Z"},
+		{"2012-07-09T00:00:00Z", "CRON_TZ=UTC 0 0 6 * * ?", "2012-07-09T06:00:00Z"},
+	}
+
+	for _, c := range runs {
+		sched, err := Parse(c.spec)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		from, _ := time.Parse(time.RFC3339, c.time)
+		expected, _ := time.Parse(time.RFC3339, c.expected)
+		actual := sched.Next(from)
+		if !actual.Equal(expected) || actual.Location() != from.Location() {
+			t.Errorf("%s, \"%s\": (expected) %v != %v (actual)", c.time, c.spec, expected, actual)
+		}
+	}
+}
+
 func TestErrors(t *testing.T) {
 	invalidSpecs := []string{
 		"xyz",
 		"60 0 * * *",
+		"0.1000 * * * *",
+		"*.0/0 * * * *",
+		"0. * * * *",
+		"0.x * * * *",
 		"0 60 * * *",
 		"0 0 * * XYZ",
+		"0 0 * L-1 *",
+		"0 0 * 32W *",
+		"0 0 * * * 1#0",
+		"0 0 * * * 1#6",
+		"0 0 * * * 1#2#3",
+		"0 0 * * * 7#1",
+		"0 0 * W *",
+		"0 0 * * * 7L",
+		"0 0 * * * L",
+		"*/0 * * * *",
+		"0 0-30/0 * * *",
+		"0 0 0 1 1 * 2030/0",
+		"0 0 0 1 1 * */0",
 	}
 	for _, spec := range invalidSpecs {
 		_, err := Parse(spec)
@@ -171,3 +434,83 @@ func getTime(value string) time.Time {
 
 	return t
 }
+
+func TestSpecScheduleText(t *testing.T) {
+	tests := []struct {
+		spec, expected string
+	}{
+		{"* * * * * *", "* * * * * *"},
+		{"0 30 6 * * MON-FRI", "0 30 6 * * 1-5"},
+		{"0 0/15 * * * *", "0 0,15,30,45 * * * *"},
+		{"0 */15 * * * ?", "0 */15 * * * *"},
+		{"0 5,6,7,8,20 9-17 1,15 Jan-Mar,Jul *", "0 5-8,20 9-17 1,15 1-3,7 *"},
+		{"0 0 0 L,15W * ?", "0 0 0 L,15W * *"},
+		{"0 0 0 LW * ?", "0 0 0 LW * *"},
+		{"0 0 0 * * 5L,1#2", "0 0 0 * * 5L,1#2"},
+		{"0 0 12 1 1 * 2030-2035/5,2031", "0 0 12 1 1 * 2030,2031,2035"},
+		{"CRON_TZ=Europe/Zurich 0 0 6 * * *", "CRON_TZ=Europe/Zurich 0 0 6 * * *"},
+		{"*.0/500 * 9-17 * * MON-FRI", "*.0/500 * 9-17 * * 1-5"},
+		{"0,30.750,250 0 12 * * *", "0,30.250,750 0 12 * * *"},
+		{"0.0 0 12 * * *", "0 0 12 * * *"},
+		{"@weekly", "0 0 0 * * 0"},
+	}
+
+	for _, c := range tests {
+		sched, err := Parse(c.spec)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		text, err := sched.(*SpecSchedule).MarshalText()
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if string(text) != c.expected {
+			t.Errorf("%s: (expected) %q != %q (actual)", c.spec, c.expected, text)
+		}
+
+		var actual SpecSchedule
+		if err := actual.UnmarshalText(text); err != nil {
+			t.Error(err)
+			continue
+		}
+		// LoadLocation returns a new location for every call.
+		if expected := sched.(*SpecSchedule).Location; expected != nil && actual.Location.String() == expected.String() {
+			actual.Location = expected
+		}
+		if !reflect.DeepEqual(&actual, sched) {
+			t.Errorf("%s: (expected) %v != %v (actual)", c.spec, sched, &actual)
+		}
+	}
+
+	// Schedules requiring both day fields can not be written as a spec.
+	sched, _ := ParseOnCalendar("Mon *-*-01 00:00")
+	if _, err := sched.(*SpecSchedule).MarshalText(); err == nil {
+		t.Error("expected an error marshaling a calendar expression")
+	}
+
+	var s SpecSchedule
+	for _, text := range []string{"0 0 25 * * *", "@every 5m"} {
+		if err := s.UnmarshalText([]byte(text)); err == nil {
+			t.Error("expected an error unmarshaling: ", text)
+		}
+	}
+}
+
+func TestSpecScheduleJSON(t *testing.T) {
+	type config struct {
+		Schedule *SpecSchedule
+	}
+	var c config
+	if err := json.Unmarshal([]byte(`{"Schedule":"0 30 6 * * MON-FRI"}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"Schedule":"0 30 6 * * 1-5"}`; string(data) != expected {
+		t.Errorf("(expected) %s != %s (actual)", expected, data)
+	}
+}
@@ -0,0 +1,109 @@
+package cron
+
+import (
+	"sort"
+	"time"
+)
+
+// statsWindow is the number of recent runs, whose durations are kept by Stats.
+const statsWindow = 100
+
+// Stats holds the execution statistics of an entry.  A run fails if its job is
+// an ErrorJob returning an error other than ErrSkipped.
+type Stats struct {
+	Runs     int // Number of completed runs
+	Failures int // Number of completed runs which failed
+
+	// Skips is the number of activations, whose runs have been skipped with
+	// ErrSkipped, e.g. by SkipIfStillRunning.  They do not count as runs.
+	Skips int
+
+	// Timeouts is the number of failed runs, which exceeded the timeout of
+	// the entry.
+	Timeouts int
+
+	// ConsecutiveFailures is the number of the last completed runs which
+	// failed, since the last one which succeeded.
+	ConsecutiveFailures int
+
+	LastStart  time.Time // Start of the last run, which may be running or skipped
+	LastFinish time.Time // Completion of the last completed run
+	LastError  error     // Error of the last failed run
+
+	// durations are the durations of the recent runs, where the next one is
+	// stored at Runs modulo statsWindow.
+	durations []time.Duration
+}
+
+// Mean returns the mean duration of the recent runs, or 0 without runs.
+func (s Stats) Mean() time.Duration {
+	if len(s.durations) == 0 {
+		return 0
+	}
+	var sum time.Duration
+	for _, d := range s.durations {
+		sum += d
+	}
+	return sum / time.Duration(len(s.durations))
+}
+
+// Percentile returns the duration, which the given percentage of the recent
+// runs did not exceed, e.g. 95 for the 95th percentile, or 0 without runs.
+func (s Stats) Percentile(p float64) time.Duration {
+	if len(s.durations) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), s.durations...)
+	sort.Sort(byDuration(sorted))
+	i := int(p/100*float64(len(sorted))+0.5) - 1
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(sorted) {
+		i = len(sorted) - 1
+	}
+	return sorted[i]
+}
+
+// start records the start of a run.
+func (s *Stats) start(t time.Time) {
+	s.LastStart = t
+}
+
+// finish records the completion of a run which started at the given time.
+func (s *Stats) finish(start, t time.Time, err error) {
+	if err == ErrSkipped {
+		s.Skips++
+		return
+	}
+	if len(s.durations) < statsWindow {
+		s.durations = append(s.durations, t.Sub(start))
+	} else {
+		s.durations[s.Runs%statsWindow] = t.Sub(start)
+	}
+	s.Runs++
+	s.LastFinish = t
+	if err != nil {
+		s.Failures++
+		s.ConsecutiveFailures++
+		s.LastError = err
+		if err == ErrTimeout {
+			s.Timeouts++
+		}
+	} else {
+		s.ConsecutiveFailures = 0
+	}
+}
+
+// snapshot returns a copy of the statistics.
+func (s Stats) snapshot() Stats {
+	s.durations = append([]time.Duration(nil), s.durations...)
+	return s
+}
+
+// byDuration sorts durations in increasing order.
+type byDuration []time.Duration
+
+func (s byDuration) Len() int           { return len(s) }
+func (s byDuration) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byDuration) Less(i, j int) bool { return s[i] < s[j] }
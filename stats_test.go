@@ -0,0 +1,47 @@
+package cron
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStats(t *testing.T) {
+	start := time.Date(2016, time.March, 1, 12, 0, 0, 0, time.UTC)
+	var s Stats
+	for i := 1; i <= 10; i++ {
+		var err error
+		if i%5 == 0 {
+			err = errors.New("failed")
+		}
+		s.start(start)
+		s.finish(start, start.Add(time.Duration(i)*time.Second), err)
+	}
+
+	if s.Runs != 10 || s.Failures != 2 || s.ConsecutiveFailures != 1 || s.LastError == nil || !s.LastFinish.Equal(start.Add(10*time.Second)) {
+		t.Errorf("unexpected stats %+v", s)
+	}
+	tests := []struct {
+		actual, expected time.Duration
+	}{
+		{s.Mean(), 5500 * time.Millisecond},
+		{s.Percentile(50), 5 * time.Second},
+		{s.Percentile(95), 10 * time.Second},
+		{s.Percentile(0), 1 * time.Second},
+		{Stats{}.Mean(), 0},
+		{Stats{}.Percentile(95), 0},
+	}
+	for i, c := range tests {
+		if c.actual != c.expected {
+			t.Errorf("%d: (expected) %v != %v (actual)", i, c.expected, c.actual)
+		}
+	}
+
+	// Only the recent runs are kept.
+	for i := 0; i < statsWindow; i++ {
+		s.finish(start, start.Add(time.Minute), nil)
+	}
+	if s.Mean() != time.Minute || s.Runs != 10+statsWindow {
+		t.Errorf("(expected) a mean of 1m0s != %v (actual)", s.Mean())
+	}
+}
@@ -0,0 +1,173 @@
+package cron
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// RunState is the persisted state of the runs of an entry.
+type RunState struct {
+	LastScheduled time.Time `json:"lastScheduled"` // Activation of the last run which has started
+	LastCompleted time.Time `json:"lastCompleted"` // Activation of the last run which has completed
+}
+
+// Store persists the RunStates of the entries by their names, e.g. to detect
+// the activations missed while the process was down.  Loading an unknown name
+// returns the zero RunState.  Its methods are called concurrently.
+type Store interface {
+	Load(name string) (RunState, error)
+	Save(name string, state RunState) error
+}
+
+// WithStore persists the RunStates of the named entries in the given store.
+// When the Cron starts, their activations after the last scheduled one are
+// handled by their misfire policy, so that no activation is run twice across
+// restarts.  Entries without a name are not persisted, since their IDs change
+// across restarts.  Errors of the store are logged, and do not fail the runs.
+func WithStore(store Store) Option {
+	return func(c *Cron) {
+		c.store = store
+	}
+}
+
+// loadLastFire returns the last fire of the entry, taken from WithLastFire or
+// from the last scheduled run in the store, if any.
+func (c *Cron) loadLastFire(e *Entry) time.Time {
+	if c.lastFire != nil {
+		return c.lastFire(e)
+	}
+	if c.store == nil || e.Name == "" {
+		return time.Time{}
+	}
+	state, err := c.store.Load(e.Name)
+	if err != nil {
+		c.logger.Error(err, "failed to load run state", "entry", e.ID, "name", e.Name)
+	}
+	return state.LastScheduled
+}
+
+// saveRun records the activation of a run of the entry in the store, if any,
+// as the last scheduled or completed one, unless it is an earlier one.
+func (c *Cron) saveRun(e *Entry, activation time.Time, completed bool) {
+	if c.store == nil || e.Name == "" {
+		return
+	}
+	c.storeMu.Lock()
+	defer c.storeMu.Unlock()
+	state, err := c.store.Load(e.Name)
+	if err == nil {
+		last := &state.LastScheduled
+		if completed {
+			last = &state.LastCompleted
+		}
+		if activation.After(*last) {
+			*last = activation
+			err = c.store.Save(e.Name, state)
+		}
+	}
+	if err != nil {
+		c.logger.Error(err, "failed to save run state", "entry", e.ID, "name", e.Name)
+	}
+}
+
+// MemoryStore is a Store keeping the RunStates in memory, e.g. for tests.
+type MemoryStore struct {
+	mu     sync.Mutex
+	states map[string]RunState
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{states: make(map[string]RunState)}
+}
+
+// Load returns the RunState of the given name.
+func (s *MemoryStore) Load(name string) (RunState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.states[name], nil
+}
+
+// Save sets the RunState of the given name.
+func (s *MemoryStore) Save(name string, state RunState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.states[name] = state
+	return nil
+}
+
+// FileStore is a Store keeping the RunStates in a JSON file, which is replaced
+// atomically on each save.
+type FileStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileStore returns a FileStore keeping the RunStates in the file at the
+// given path, which is created by the first save.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// Load returns the RunState of the given name.
+func (s *FileStore) Load(name string) (RunState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	states, err := s.read()
+	return states[name], err
+}
+
+// Save sets the RunState of the given name.
+func (s *FileStore) Save(name string, state RunState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	states, err := s.read()
+	if err != nil {
+		return err
+	}
+	states[name] = state
+	data, err := json.MarshalIndent(states, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	// The file is replaced by renaming a temporary one, so that it is never
+	// partially written.
+	f, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(data); err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), s.path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
+
+// read reads the RunStates of the file, which are empty if it does not exist.
+func (s *FileStore) read() (map[string]RunState, error) {
+	states := make(map[string]RunState)
+	data, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return states, nil
+	}
+	if err != nil {
+		return states, err
+	}
+	if err := json.Unmarshal(data, &states); err != nil {
+		return states, err
+	}
+	return states, nil
+}
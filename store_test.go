@@ -0,0 +1,91 @@
+package cron
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStores(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cron")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "state.json")
+	state := RunState{
+		LastScheduled: time.Date(2016, time.March, 1, 12, 0, 0, 0, time.UTC),
+		LastCompleted: time.Date(2016, time.March, 1, 11, 0, 0, 0, time.UTC),
+	}
+	tests := []struct {
+		name   string
+		store  Store
+		reopen func() Store
+	}{
+		{"memory", NewMemoryStore(), nil},
+		{"file", NewFileStore(path), func() Store { return NewFileStore(path) }},
+	}
+	for _, c := range tests {
+		if actual, err := c.store.Load("backup"); err != nil || actual != (RunState{}) {
+			t.Errorf("%s: (expected) the zero state != %+v, %v (actual)", c.name, actual, err)
+		}
+		if err := c.store.Save("backup", state); err != nil {
+			t.Errorf("%s: %v", c.name, err)
+		}
+		if err := c.store.Save("report", RunState{}); err != nil {
+			t.Errorf("%s: %v", c.name, err)
+		}
+		stores := []Store{c.store}
+		if c.reopen != nil {
+			stores = append(stores, c.reopen())
+		}
+		for _, store := range stores {
+			if actual, err := store.Load("backup"); err != nil || !actual.LastScheduled.Equal(state.LastScheduled) || !actual.LastCompleted.Equal(state.LastCompleted) {
+				t.Errorf("%s: (expected) %+v != %+v, %v (actual)", c.name, state, actual, err)
+			}
+		}
+	}
+
+	// Files which are not JSON fail to load.
+	if err := ioutil.WriteFile(path, []byte("corrupt"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewFileStore(path).Load("backup"); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+// The activations after the last scheduled one in the store are handled by the
+// misfire policy, and the runs are saved.
+func TestWithStore(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2016, time.March, 1, hour, 0, 0, 0, time.UTC) }
+	store := NewMemoryStore()
+	store.Save("hourly", RunState{LastScheduled: at(9), LastCompleted: at(9)})
+
+	clock := newFakeClock(time.Date(2016, time.March, 1, 12, 0, 30, 0, time.UTC))
+	ran := make(chan time.Time, 10)
+	cron := New(WithClock(clock), WithLocation(time.UTC), WithStore(store))
+	id, _ := cron.AddNamedJob("hourly", "0 0 * * * *", FuncJob(func() { ran <- clock.Now() }))
+	cron.SetMisfirePolicy(id, MisfireRunOnce)
+	cron.AddFunc("0 0 * * * *", func() { ran <- clock.Now() })
+	cron.Start()
+	defer cron.Stop()
+
+	select {
+	case <-ran:
+	case <-time.After(ONE_SECOND):
+		t.Fatal("expected the missed activation to run")
+	}
+	time.Sleep(10 * time.Millisecond)
+	if actual, _ := store.Load("hourly"); !actual.LastScheduled.Equal(at(12)) || !actual.LastCompleted.Equal(at(12)) {
+		t.Errorf("(expected) the run at 12:00 != %+v (actual)", actual)
+	}
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if len(store.states) != 1 {
+		t.Errorf("(expected) only the named entry to be saved != %v (actual)", store.states)
+	}
+}
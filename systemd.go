@@ -0,0 +1,136 @@
+package cron
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// systemdParser parses the cron specs translated from systemd calendar
+// expressions, which contain all fields.
+var systemdParser = NewParser(Second | Minute | Hour | Dom | Month | Dow | Year)
+
+// systemdShorthands maps the shorthands of systemd calendar expressions to
+// their normalized form.
+var systemdShorthands = map[string]string{
+	"minutely":     "*-*-* *:*:00",
+	"hourly":       "*-*-* *:00:00",
+	"daily":        "*-*-* 00:00:00",
+	"weekly":       "Mon *-*-* 00:00:00",
+	"monthly":      "*-*-01 00:00:00",
+	"yearly":       "*-01-01 00:00:00",
+	"annually":     "*-01-01 00:00:00",
+	"quarterly":    "*-01,04,07,10-01 00:00:00",
+	"semiannually": "*-01,07-01 00:00:00",
+}
+
+// ParseOnCalendar returns a new schedule representing the given systemd
+// calendar expression, as used by OnCalendar= of systemd timers, in the form
+//
+//	[weekdays] [year-]month-day [hour:minute[:second]] [timezone]
+//
+// Each component may be a "*", a list ("Sat,Sun"), a range ("Mon..Fri",
+// "01..15") or a repetition ("00/15").  The weekdays and the date must both
+// match.  A missing date matches every day, a missing time is midnight.
+// It returns a descriptive error if the expression is not valid.
+//
+// It accepts
+//   - Calendar expressions, e.g. "Mon..Fri *-*-* 06:00:00", "*-*-01 04:00"
+//   - Shorthands, e.g. "daily", "weekly", "quarterly"
+func ParseOnCalendar(spec string) (_ Schedule, err error) {
+	// Convert panics into errors
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("%v", recovered)
+		}
+	}()
+
+	tokens := strings.Fields(spec)
+	if len(tokens) == 0 {
+		log.Panicf("Empty calendar expression")
+	}
+	if normalized, ok := systemdShorthands[strings.ToLower(tokens[0])]; ok {
+		tokens = append(strings.Fields(normalized), tokens[1:]...)
+	}
+
+	var (
+		weekdays = "*"
+		date     = []string{"*", "*", "*"}
+		clock    = []string{"0", "0", "0"}
+		location string
+	)
+	for i, token := range tokens {
+		switch {
+		case strings.Contains(token, ":"):
+			parts := strings.Split(token, ":")
+			if len(parts) < 2 || len(parts) > 3 {
+				log.Panicf("Expected hour:minute[:second]: %s", token)
+			}
+			copy(clock, parts)
+			if len(parts) == 2 {
+				clock[2] = "0"
+			}
+		case strings.Contains(token, "-") && strings.IndexAny(token[:1], "0123456789*") == 0:
+			parts := strings.Split(token, "-")
+			switch len(parts) {
+			case 2:
+				date[1], date[2] = parts[0], parts[1]
+			case 3:
+				copy(date, parts)
+			default:
+				log.Panicf("Expected [year-]month-day: %s", token)
+			}
+		case i == 0:
+			weekdays = token
+		case i == len(tokens)-1:
+			location = token
+		default:
+			log.Panicf("Unexpected component %s: %s", token, spec)
+		}
+	}
+
+	fields := []string{clock[2], clock[1], clock[0], date[2], date[1], weekdays, date[0]}
+	for i, field := range fields {
+		if strings.Contains(field, "~") {
+			log.Panicf("Last days of the month (~) are not supported: %s", spec)
+		}
+		field = strings.Replace(field, "..", "-", -1)
+		// Strip the fractions of seconds and the long names of the weekdays.
+		if i == 0 {
+			field = strings.Split(field, ".")[0]
+		}
+		if i == 5 {
+			field = shortWeekdays(field)
+		}
+		fields[i] = field
+	}
+
+	cronSpec := strings.Join(fields, " ")
+	if location != "" {
+		cronSpec = "CRON_TZ=" + location + " " + cronSpec
+	}
+	schedule, err := systemdParser.Parse(cronSpec)
+	if err != nil {
+		log.Panicf("%s: %s", err, spec)
+	}
+	s := schedule.(*SpecSchedule)
+	s.dayAnd = true
+	return s, nil
+}
+
+// shortWeekdays returns the field with the weekday names shortened to their
+// first three letters, e.g. "Monday-Friday" to "Mon-Fri".
+func shortWeekdays(field string) string {
+	var short []string
+	for _, expr := range strings.Split(field, ",") {
+		var days []string
+		for _, day := range strings.Split(expr, "-") {
+			if len(day) > 3 {
+				day = day[:3]
+			}
+			days = append(days, day)
+		}
+		short = append(short, strings.Join(days, "-"))
+	}
+	return strings.Join(short, ",")
+}
@@ -0,0 +1,79 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOnCalendarNext(t *testing.T) {
+	tests := []struct {
+		time, spec string
+		expected   string
+	}{
+		{"Mon Jul 9 14:45 2012", "Mon..Fri *-*-* 06:00:00", "Tue Jul 10 06:00 2012"},
+		{"Fri Jul 13 14:45 2012", "Mon..Fri *-*-* 06:00:00", "Mon Jul 16 06:00 2012"},
+		{"Fri Jul 13 14:45 2012", "Monday..Friday 06:00", "Mon Jul 16 06:00 2012"},
+		{"Mon Jul 9 14:45 2012", "Sat,Sun 10:30", "Sat Jul 14 10:30 2012"},
+		{"Mon Jul 9 14:45 2012", "*-*-01 04:00", "Wed Aug 1 04:00 2012"},
+		{"Mon Jul 9 14:45 2012", "2013-02-03", "Sun Feb 3 00:00 2013"},
+		{"Mon Jul 9 14:45 2012", "*-*-* *:0/20", "Mon Jul 9 15:00 2012"},
+		{"Mon Jul 9 14:45 2012", "*-*-* 14:50:30.5", "Mon Jul 9 14:50:30 2012"},
+		{"Mon Jul 9 14:45 2012", "*-09..10-01 00:00", "Sat Sep 1 00:00 2012"},
+
+		// The weekday and the date must both match.
+		{"Mon Jul 9 14:45 2012", "Mon *-*-01 00:00", "Mon Oct 1 00:00 2012"},
+
+		// Shorthands
+		{"Mon Jul 9 14:45 2012", "hourly", "Mon Jul 9 15:00 2012"},
+		{"Mon Jul 9 14:45 2012", "daily", "Tue Jul 10 00:00 2012"},
+		{"Mon Jul 9 14:45 2012", "weekly", "Mon Jul 16 00:00 2012"},
+		{"Mon Jul 9 14:45 2012", "quarterly", "Mon Oct 1 00:00 2012"},
+		{"Mon Jul 9 14:45 2012", "yearly", "Tue Jan 1 00:00 2013"},
+
+		// Unsatisfiable
+		{"Mon Jul 9 14:45 2012", "2011-*-* 00:00", ""},
+	}
+
+	for _, c := range tests {
+		sched, err := ParseOnCalendar(c.spec)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		actual := sched.Next(getTime(c.time))
+		expected := getTime(c.expected)
+		if !actual.Equal(expected) {
+			t.Errorf("%s, \"%s\": (expected) %v != %v (actual)", c.time, c.spec, expected, actual)
+		}
+	}
+}
+
+func TestOnCalendarLocation(t *testing.T) {
+	sched, err := ParseOnCalendar("*-*-* 06:00 America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	from := time.Date(2012, time.July, 9, 0, 0, 0, 0, time.UTC)
+	expected := time.Date(2012, time.July, 9, 10, 0, 0, 0, time.UTC)
+	if actual := sched.Next(from); !actual.Equal(expected) {
+		t.Errorf("(expected) %v != %v (actual)", expected, actual)
+	}
+}
+
+func TestOnCalendarErrors(t *testing.T) {
+	invalidSpecs := []string{
+		"",
+		"Mon..Xyz 06:00",
+		"*-*-* 25:00",
+		"*-*-*-* 06:00",
+		"*-*-* 06",
+		"*-02~03",
+		"06:00 Mon",
+		"*-*-* 06:00 Nowhere/Bad",
+	}
+	for _, spec := range invalidSpecs {
+		if _, err := ParseOnCalendar(spec); err == nil {
+			t.Error("expected an error parsing: ", spec)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrTimeout is the error of a run which exceeded the timeout of its entry.
+var ErrTimeout = errors.New("Job timed out")
+
+// SetTimeout limits the duration of the runs of the entry with the given ID,
+// including their retries.  The context of a run is canceled when the timeout
+// is exceeded, and the run fails with ErrTimeout.  Only jobs observing their
+// context, such as ContextJobs and ErrorJobs, are canceled; others keep
+// running until they complete.  The timeout is measured by the Clock of the
+// Cron.  A timeout of 0 removes the limit.  It returns an error if there is no
+// such entry.
+func (c *Cron) SetTimeout(id EntryID, timeout time.Duration) error {
+	return c.updateEntry(id, func(e *Entry, running bool) {
+		e.timeout = timeout
+	})
+}
+
+// runWithTimeout runs the job of a run of an entry like retry, within the
+// timeout of the entry, if any, as measured by the clock of the Cron.
+func (c *Cron) runWithTimeout(ctx context.Context, run *Entry) error {
+	if run.timeout <= 0 {
+		return c.retry(ctx, run)
+	}
+	if c.clock == SystemClock {
+		ctx, cancel := context.WithTimeout(ctx, run.timeout)
+		defer cancel()
+		err := c.retry(ctx, run)
+		if ctx.Err() == context.DeadlineExceeded {
+			return ErrTimeout
+		}
+		return err
+	}
+
+	// Other clocks cancel the context once their timer has elapsed.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	timedOut := make(chan struct{})
+	go func() {
+		select {
+		case <-c.clock.After(run.timeout):
+			close(timedOut)
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	err := c.retry(ctx, run)
+	select {
+	case <-timedOut:
+		return ErrTimeout
+	default:
+		return err
+	}
+}
@@ -0,0 +1,68 @@
+package cron
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// Timeouts are measured by the clock of the Cron.
+func TestSetTimeoutWithClock(t *testing.T) {
+	clock := newFakeClock(time.Date(2016, time.March, 1, 11, 59, 59, 0, time.UTC))
+	handled := make(chan RunInfo, 1)
+	cron := New(WithClock(clock), WithLocation(time.UTC), WithErrorHandler(func(run RunInfo) { handled <- run }))
+	id, _ := cron.AddJob("0 0 * * * *", ContextFuncJob(func(ctx context.Context) { <-ctx.Done() }))
+	cron.SetTimeout(id, time.Minute)
+	cron.Start()
+	defer cron.Stop()
+
+	clock.waitForTimers(t, 1)
+	clock.Advance(time.Second)
+	clock.waitForTimers(t, 2)
+	select {
+	case run := <-handled:
+		t.Fatalf("unexpected failed run %+v before the timeout", run)
+	case <-time.After(10 * time.Millisecond):
+	}
+	clock.Advance(time.Minute)
+	select {
+	case run := <-handled:
+		if run.Err != ErrTimeout {
+			t.Errorf("(expected) ErrTimeout != %v (actual)", run.Err)
+		}
+	case <-time.After(ONE_SECOND):
+		t.Fatal("expected the run to time out")
+	}
+}
+
+// Runs exceeding their timeout are canceled and fail with ErrTimeout.
+func TestSetTimeout(t *testing.T) {
+	handled := make(chan RunInfo, 10)
+
+	cron := New(WithErrorHandler(func(run RunInfo) { handled <- run }))
+	id, _ := cron.AddNamedJob("hung", "* * * * * ?", ContextFuncJob(func(ctx context.Context) {
+		<-ctx.Done()
+	}))
+	if err := cron.SetTimeout(id, 20*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if err := cron.SetTimeout(id+1, time.Second); err == nil {
+		t.Error("expected an error for an unknown ID")
+	}
+	cron.Start()
+	defer cron.Stop()
+
+	select {
+	case run := <-handled:
+		if run.Err != ErrTimeout || run.Duration < 20*time.Millisecond || run.Duration > 500*time.Millisecond {
+			t.Errorf("(expected) a timeout after 20ms != %v after %v (actual)", run.Err, run.Duration)
+		}
+	case <-time.After(2 * ONE_SECOND):
+		t.Fatal("expected the run to time out")
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	if s := cron.EntryByName("hung").Stats; s.Timeouts != 1 || s.Failures != 1 {
+		t.Errorf("(expected) 1 timeout != %+v (actual)", s)
+	}
+}
@@ -0,0 +1,45 @@
+package cron
+
+import "context"
+
+// Tracer traces the runs of the jobs, e.g. with the spans of an OpenTelemetry
+// trace.Tracer, named after the name of the entry, or its spec if it has none,
+// with the spec and the activation of the run as attributes.  The package
+// provides no such tracer, to keep it free of dependencies, so callers
+// implement Tracer with their OpenTelemetry SDK.
+type Tracer interface {
+	// Start starts the span of the run, whose Duration and Err are not set,
+	// and returns the context carrying the span and a function ending it with
+	// the error of the run.  The context is passed to ContextJobs and
+	// ErrorJobs, so that their work is part of the trace.
+	Start(ctx context.Context, run RunInfo) (context.Context, func(err error))
+}
+
+// WithTracer traces the runs of the jobs with the given tracer.  The runs of
+// Simulate are not traced.
+func WithTracer(tracer Tracer) Option {
+	return func(c *Cron) {
+		c.tracer = tracer
+	}
+}
+
+// SpanName returns the name of the span of a run of the entry: its name, or
+// its spec if it has none.
+func SpanName(e *Entry) string {
+	if e.Name != "" {
+		return e.Name
+	}
+	return e.Spec
+}
+
+// trace runs the job of a run of an entry like runWithTimeout, within the span
+// of the tracer, if any.
+func (c *Cron) trace(ctx context.Context, run RunInfo) error {
+	if c.tracer == nil {
+		return c.runWithTimeout(ctx, run.Entry)
+	}
+	ctx, end := c.tracer.Start(ctx, run)
+	err := c.runWithTimeout(ctx, run.Entry)
+	end(err)
+	return err
+}
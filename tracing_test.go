@@ -0,0 +1,68 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSpanName(t *testing.T) {
+	tests := []struct {
+		entry    Entry
+		expected string
+	}{
+		{Entry{Name: "backup", Spec: "@daily"}, "backup"},
+		{Entry{Spec: "@daily"}, "@daily"},
+		{Entry{}, ""},
+	}
+	for _, c := range tests {
+		if actual := SpanName(&c.entry); actual != c.expected {
+			t.Errorf("%+v: (expected) %q != %q (actual)", c.entry, c.expected, actual)
+		}
+	}
+}
+
+type spanKey struct{}
+
+// span is a span recorded by the recordingTracer.
+type span struct {
+	name, spec string
+	scheduled  time.Time
+	err        error
+}
+
+// recordingTracer sends the ended spans.
+type recordingTracer chan span
+
+func (r recordingTracer) Start(ctx context.Context, run RunInfo) (context.Context, func(err error)) {
+	s := span{name: SpanName(run.Entry), spec: run.Entry.Spec, scheduled: run.Scheduled}
+	return context.WithValue(ctx, spanKey{}, s.name), func(err error) {
+		s.err = err
+		r <- s
+	}
+}
+
+// The runs are traced, and the contexts of the jobs carry their spans.
+func TestWithTracer(t *testing.T) {
+	spans := make(recordingTracer, 10)
+	cron := New(WithTracer(spans))
+	broken := errors.New("broken")
+	cron.AddNamedJob("traced", "* * * * * ?", ErrorFuncJob(func(ctx context.Context) error {
+		if ctx.Value(spanKey{}) != "traced" {
+			t.Errorf("expected the context to carry the span, got %v", ctx.Value(spanKey{}))
+		}
+		return broken
+	}))
+	cron.Start()
+	defer cron.Stop()
+
+	select {
+	case s := <-spans:
+		if s.name != "traced" || s.spec != "* * * * * ?" || s.scheduled.IsZero() || s.err != broken {
+			t.Errorf("unexpected span %+v", s)
+		}
+	case <-time.After(ONE_SECOND):
+		t.Fatal("expected the run to be traced")
+	}
+}
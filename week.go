@@ -0,0 +1,52 @@
+package cron
+
+import (
+	"fmt"
+	"time"
+)
+
+// isoWeeks are the bounds of the ISO 8601 week numbers.
+var isoWeeks = bounds{1, 53, nil}
+
+// WeekSchedule activates like its schedule, but only in the ISO 8601 weeks of
+// the year in the Weeks bit set.
+type WeekSchedule struct {
+	Schedule Schedule
+	Weeks    uint64
+}
+
+// InWeeks returns a Schedule that activates like the given schedule, but only
+// in the ISO 8601 weeks of the year matching the given field, which accepts the
+// syntax of the other fields within 1-53, e.g. "1-26", or "2/2" for the even
+// weeks.
+// It returns a descriptive error if the field is not valid.
+func InWeeks(schedule Schedule, weeks string) (_ WeekSchedule, err error) {
+	// Convert panics into errors
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			if re, ok := recovered.(*rangeError); ok {
+				recovered = re.message
+			}
+			err = fmt.Errorf("%v", recovered)
+		}
+	}()
+
+	return WeekSchedule{Schedule: schedule, Weeks: getField(weeks, isoWeeks)}, nil
+}
+
+// Next returns the next activation of the schedule in one of the weeks.  If
+// there is none within five years, return the zero time.
+func (s WeekSchedule) Next(t time.Time) time.Time {
+	limit := t.AddDate(5, 0, 0)
+	for next := s.Schedule.Next(t); !next.IsZero() && !next.After(limit); next = s.Schedule.Next(t) {
+		_, week := next.ISOWeek()
+		if 1<<uint(week)&s.Weeks > 0 {
+			return next
+		}
+
+		// Continue before the Monday of the following week.
+		day := next.AddDate(0, 0, -int(next.Weekday()+6)%7+7)
+		t = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, next.Location()).Add(-1 * time.Nanosecond)
+	}
+	return time.Time{}
+}
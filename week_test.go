@@ -0,0 +1,49 @@
+package cron
+
+import "testing"
+
+func TestWeekNext(t *testing.T) {
+	daily, _ := Parse("0 0 6 * * *")
+	mondays, _ := Parse("0 0 6 * * Mon")
+
+	tests := []struct {
+		schedule       Schedule
+		weeks          string
+		time, expected string
+	}{
+		// Monday July 9th 2012 is in week 28.
+		{daily, "2/2", "Mon Jul 9 00:00 2012", "Mon Jul 9 06:00 2012"},
+		{daily, "2/2", "Sun Jul 15 06:00 2012", "Mon Jul 23 06:00 2012"},
+		{daily, "*/2", "Mon Jul 9 00:00 2012", "Mon Jul 16 06:00 2012"},
+		{daily, "1-26", "Mon Jul 9 00:00 2012", "Mon Dec 31 06:00 2012"},
+		{mondays, "28", "Mon Jul 9 06:00 2012", "Mon Jul 8 06:00 2013"},
+		{daily, "53", "Mon Jul 9 00:00 2012", "Mon Dec 28 06:00 2015"},
+		{daily, "53", "Mon Jul 9 00:00 2018", "Mon Dec 28 06:00 2020"},
+		{daily, "53", "Mon Jan 4 00:00 2021", ""},
+
+		// Week 1 of 2013 starts on Monday December 31st 2012.
+		{daily, "1", "Mon Jul 9 00:00 2012", "Mon Dec 31 06:00 2012"},
+	}
+
+	for _, c := range tests {
+		s, err := InWeeks(c.schedule, c.weeks)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		actual := s.Next(getTime(c.time))
+		expected := getTime(c.expected)
+		if !actual.Equal(expected) {
+			t.Errorf("%s, %s: (expected) %v != %v (actual)", c.weeks, c.time, expected, actual)
+		}
+	}
+}
+
+func TestInWeeksErrors(t *testing.T) {
+	daily, _ := Parse("@daily")
+	for _, weeks := range []string{"0", "54", "1-60", "x", "*/0", "2/0", "1-26/0"} {
+		if _, err := InWeeks(daily, weeks); err == nil {
+			t.Errorf("%s: expected an error", weeks)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package cron
+
+import "time"
+
+// WindowSchedule constrains the activations of a schedule to a daily window,
+// e.g. "*/5 * * * * * @between 08:00-18:00".  Activations outside of the window
+// are skipped.
+type WindowSchedule struct {
+	Schedule Schedule
+
+	// Start and End of the window, as the time elapsed since midnight.  The
+	// window includes Start but excludes End.  If End is before Start, the
+	// window spans midnight.
+	Start, End time.Duration
+
+	// Location is the time zone of the window.  A nil Location uses the
+	// location of the activation times.
+	Location *time.Location
+}
+
+// Between returns a Schedule that activates like the given schedule, but only
+// within the daily window from start to end, given as the time since midnight.
+func Between(schedule Schedule, start, end time.Duration) WindowSchedule {
+	return WindowSchedule{
+		Schedule: schedule,
+		Start:    start,
+		End:      end,
+	}
+}
+
+// Next returns the next activation of the underlying schedule within the
+// window.  If no activation is found within five years, return the zero time.
+//
+// Activations outside of the window are skipped by continuing the search right
+// before the window opens.  Schedules relative to the given time, such as
+// ConstantDelaySchedule, therefore restart their interval when the window
+// opens.
+func (schedule WindowSchedule) Next(t time.Time) time.Time {
+	limit := t.AddDate(5, 0, 0)
+	for {
+		next := schedule.Schedule.Next(t)
+		if next.IsZero() || next.After(limit) {
+			return time.Time{}
+		}
+		if schedule.contains(next) {
+			return next
+		}
+
+		// Continue the search right before the start of the next window.
+		t = schedule.nextStart(next).Add(-time.Nanosecond)
+	}
+}
+
+// contains returns true if the given time is within the window.
+func (schedule WindowSchedule) contains(t time.Time) bool {
+	t = schedule.in(t)
+	elapsed := time.Duration(t.Hour())*time.Hour +
+		time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second +
+		time.Duration(t.Nanosecond())
+	if schedule.Start <= schedule.End {
+		return elapsed >= schedule.Start && elapsed < schedule.End
+	}
+	return elapsed >= schedule.Start || elapsed < schedule.End
+}
+
+// nextStart returns the start of the first window after the given time.
+func (schedule WindowSchedule) nextStart(t time.Time) time.Time {
+	t = schedule.in(t)
+	start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, int(schedule.Start), t.Location())
+	if !start.After(t) {
+		start = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, int(schedule.Start), t.Location())
+	}
+	return start
+}
+
+// in returns the given time in the location of the window.
+func (schedule WindowSchedule) in(t time.Time) time.Time {
+	if schedule.Location != nil {
+		return t.In(schedule.Location)
+	}
+	return t
+}
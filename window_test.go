@@ -0,0 +1,81 @@
+package cron
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWindowNext(t *testing.T) {
+	tests := []struct {
+		time, spec string
+		expected   string
+	}{
+		// Within the window.
+		{"Mon Jul 9 14:45 2012", "0 */5 * * * * @between 08:00-18:00", "Mon Jul 9 14:50 2012"},
+		{"Mon Jul 9 07:59 2012", "0 */5 * * * * @between 08:00-18:00", "Mon Jul 9 08:00 2012"},
+		{"Mon Jul 9 17:54 2012", "0 */5 * * * * @between 08:00-18:00", "Mon Jul 9 17:55 2012"},
+
+		// The end of the window is excluded.
+		{"Mon Jul 9 17:55 2012", "0 */5 * * * * @between 08:00-18:00", "Tue Jul 10 08:00 2012"},
+		{"Mon Jul 9 02:00 2012", "0 */5 * * * * @between 08:00-18:00", "Mon Jul 9 08:00 2012"},
+		{"Mon Jul 9 17:55 2012", "0 7 * * * * @between 08:30-18:00", "Tue Jul 10 09:07 2012"},
+
+		// Windows spanning midnight.
+		{"Mon Jul 9 14:45 2012", "0 0 * * * * @between 22:00-02:00", "Mon Jul 9 22:00 2012"},
+		{"Mon Jul 9 23:45 2012", "0 0 * * * * @between 22:00-02:00", "Tue Jul 10 00:00 2012"},
+		{"Tue Jul 10 01:00 2012", "0 0 * * * * @between 22:00-02:00", "Tue Jul 10 22:00 2012"},
+
+		// Descriptors and intervals.
+		{"Mon Jul 9 14:45 2012", "@hourly @between 08:00-09:00", "Tue Jul 10 08:00 2012"},
+		{"Mon Jul 9 17:58 2012", "@every 1m @between 08:00-18:00", "Mon Jul 9 17:59 2012"},
+		{"Mon Jul 9 17:59 2012", "@every 1m @between 08:00-18:00", "Tue Jul 10 08:00:59 2012"},
+
+		// Unsatisfiable
+		{"Mon Jul 9 14:45 2012", "0 0 3 * * * @between 08:00-18:00", ""},
+	}
+
+	for _, c := range tests {
+		sched, err := Parse(c.spec)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		actual := sched.Next(getTime(c.time))
+		expected := getTime(c.expected)
+		if !actual.Equal(expected) {
+			t.Errorf("%s, \"%s\": (expected) %v != %v (actual)", c.time, c.spec, expected, actual)
+		}
+	}
+}
+
+func TestParseWindow(t *testing.T) {
+	sched, err := Parse("0 */5 * * * * @between 08:00-18:30")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := Between(&SpecSchedule{
+		Second: 1 << 0,
+		Minute: getBits(0, 59, 5) | starBit,
+		Hour:   all(hours),
+		Dom:    all(dom),
+		Month:  all(months),
+		Dow:    all(dow),
+	}, 8*time.Hour, 18*time.Hour+30*time.Minute)
+	if !reflect.DeepEqual(sched, expected) {
+		t.Errorf("(expected) %v != %v (actual)", expected, sched)
+	}
+
+	invalidSpecs := []string{
+		"0 */5 * * * * @between",
+		"0 */5 * * * * @between 08:00",
+		"0 */5 * * * * @between 8-18",
+		"0 */5 * * * * @between 08:00-25:00",
+		"@between 08:00-18:00",
+	}
+	for _, spec := range invalidSpecs {
+		if _, err := Parse(spec); err == nil {
+			t.Error("expected an error parsing: ", spec)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// JobWrapper decorates a Job with a cross-cutting concern, such as logging,
+// metrics or locking.  Wrappers should implement ErrorJob to pass the context
+// of the run to the wrapped job, and return its error.
+type JobWrapper func(Job) Job
+
+// Chain is a sequence of JobWrappers, applied to jobs with Then.
+type Chain []JobWrapper
+
+// NewChain returns a Chain of the wrappers.
+func NewChain(wrappers ...JobWrapper) Chain {
+	return Chain(wrappers)
+}
+
+// Then returns the job decorated by the wrappers of the chain, where the first
+// wrapper is the outermost one, e.g. to wrap the jobs of selected entries:
+//
+//	c.AddJob("@hourly", cron.NewChain(logged, cron.SkipIfStillRunning).Then(job))
+func (c Chain) Then(job Job) Job {
+	for i := len(c) - 1; i >= 0; i-- {
+		job = c[i](job)
+	}
+	return job
+}
+
+// ErrSkipped is the error of a run which has been skipped, e.g. by
+// SkipIfStillRunning.  Skipped runs are counted as skips by the Stats of their
+// entry, and are neither failures nor retried.
+var ErrSkipped = errors.New("Run skipped")
+
+// SkipIfStillRunning returns a Job which runs the given job, unless its
+// previous run is still running, in which case the run is skipped with
+// ErrSkipped.  The same returned Job must be used for all runs of the entry.
+func SkipIfStillRunning(job Job) Job {
+	return &skipIfStillRunning{job: job, running: make(chan struct{}, 1)}
+}
+
+type skipIfStillRunning struct {
+	job     Job
+	running chan struct{}
+}
+
+func (s *skipIfStillRunning) Run() { s.RunError(context.Background()) }
+
+func (s *skipIfStillRunning) RunError(ctx context.Context) error {
+	select {
+	case s.running <- struct{}{}:
+		defer func() { <-s.running }()
+		return runJob(ctx, s.job)
+	default:
+		return ErrSkipped
+	}
+}
+
+// DelayIfStillRunning returns a Job which runs the given job after its previous
+// run has completed, so that its runs never overlap and are never skipped.  A
+// run whose context is done while it is waiting fails with the error of the
+// context.  The same returned Job must be used for all runs of the entry.
+func DelayIfStillRunning(job Job) *SerialJob {
+	return DelayIfStillRunningClock(job, SystemClock)
+}
+
+// DelayIfStillRunningClock returns the Job of DelayIfStillRunning, whose delay
+// is measured by the clock, e.g. a fake clock in tests.
+func DelayIfStillRunningClock(job Job, clock Clock) *SerialJob {
+	return &SerialJob{job: job, running: make(chan struct{}, 1), clock: clock}
+}
+
+// SerialJob is a Job returned by DelayIfStillRunning, which keeps track of the
+// time its runs have waited for the previous ones.
+type SerialJob struct {
+	job     Job
+	running chan struct{}
+	clock   Clock
+
+	mu    sync.Mutex
+	delay time.Duration
+}
+
+// Delay returns the accumulated time, which the runs of the job have waited
+// for the previous ones.
+func (s *SerialJob) Delay() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.delay
+}
+
+func (s *SerialJob) Run() { s.RunError(context.Background()) }
+
+func (s *SerialJob) RunError(ctx context.Context) error {
+	start := s.clock.Now()
+	select {
+	case s.running <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-s.running }()
+
+	s.mu.Lock()
+	s.delay += s.clock.Now().Sub(start)
+	s.mu.Unlock()
+	return runJob(ctx, s.job)
+}
+
+// CoalesceIfStillRunning returns a Job which runs the given job, unless its
+// previous run is still running, in which case the run is skipped with
+// ErrSkipped and its activations are coalesced into one more run of the job by
+// the previous run, once it has completed, see Activations.  The coalesced run
+// is stopped if the context of the previous run is done.  The same returned
+// Job must be used for all runs of the entry.
+func CoalesceIfStillRunning(job Job) Job {
+	return &coalesceIfStillRunning{job: job}
+}
+
+type coalesceIfStillRunning struct {
+	job Job
+
+	mu      sync.Mutex
+	running bool
+	pending []time.Time
+}
+
+func (s *coalesceIfStillRunning) Run() { s.RunError(context.Background()) }
+
+func (s *coalesceIfStillRunning) RunError(ctx context.Context) error {
+	s.mu.Lock()
+	if s.running {
+		s.pending = append(s.pending, Activations(ctx)...)
+		s.mu.Unlock()
+		return ErrSkipped
+	}
+	s.running = true
+	s.mu.Unlock()
+
+	err := runJob(ctx, s.job)
+	for {
+		s.mu.Lock()
+		pending := s.pending
+		s.pending = nil
+		if len(pending) == 0 || ctx.Err() != nil {
+			s.running = false
+			s.mu.Unlock()
+			return err
+		}
+		s.mu.Unlock()
+		err = runJob(withActivations(ctx, pending), s.job)
+	}
+}
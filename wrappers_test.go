@@ -0,0 +1,175 @@
+package cron
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Runs are skipped while the previous one is running, and counted as skips.
+func TestSkipIfStillRunning(t *testing.T) {
+	started, release := make(chan struct{}, 10), make(chan struct{})
+
+	cron := New(WithErrorHandler(func(run RunInfo) { t.Errorf("unexpected failed run %+v", run) }))
+	cron.AddNamedJob("slow", "* * * * * ?", SkipIfStillRunning(FuncJob(func() {
+		started <- struct{}{}
+		<-release
+	})))
+	cron.Start()
+	defer cron.Stop()
+
+	<-started
+	time.Sleep(ONE_SECOND + 10*time.Millisecond)
+	close(release)
+	time.Sleep(10 * time.Millisecond)
+
+	s := cron.EntryByName("slow").Stats
+	if s.Runs != 1 || s.Skips < 1 || s.Failures != 0 {
+		t.Errorf("(expected) 1 run and a skip != %+v (actual)", s)
+	}
+	select {
+	case <-started:
+		t.Error("expected the second run to be skipped")
+	default:
+	}
+
+	// The job runs again once the previous run has completed.
+	job := SkipIfStillRunning(FuncJob(func() {})).(ErrorJob)
+	for i := 0; i < 2; i++ {
+		if err := job.RunError(context.Background()); err != nil {
+			t.Errorf("(expected) no error != %v (actual)", err)
+		}
+	}
+}
+
+// Runs while the previous one is running are coalesced into one more run.
+func TestCoalesceIfStillRunning(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2016, time.March, 1, hour, 0, 0, 0, time.UTC) }
+	started, release := make(chan []time.Time, 10), make(chan struct{})
+	job := CoalesceIfStillRunning(ErrorFuncJob(func(ctx context.Context) error {
+		started <- Activations(ctx)
+		<-release
+		return nil
+	})).(ErrorJob)
+
+	done := make(chan error)
+	go func() { done <- job.RunError(withActivations(context.Background(), []time.Time{at(12)})) }()
+	actual := [][]time.Time{<-started}
+	for _, hour := range []int{13, 14} {
+		if err := job.RunError(withActivations(context.Background(), []time.Time{at(hour)})); err != ErrSkipped {
+			t.Errorf("(expected) ErrSkipped != %v (actual)", err)
+		}
+	}
+	close(release)
+	if err := <-done; err != nil {
+		t.Errorf("(expected) no error != %v (actual)", err)
+	}
+
+	close(started)
+	for activations := range started {
+		actual = append(actual, activations)
+	}
+	expected := [][]time.Time{{at(12)}, {at(13), at(14)}}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("(expected) %v != %v (actual)", expected, actual)
+	}
+}
+
+// Runs wait for the previous one to complete, accumulating their delay.
+func TestDelayIfStillRunning(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		running int
+		runs    int
+	)
+	job := DelayIfStillRunning(FuncJob(func() {
+		mu.Lock()
+		if running++; running > 1 {
+			t.Error("expected the runs not to overlap")
+		}
+		runs++
+		mu.Unlock()
+		time.Sleep(20 * time.Millisecond)
+		mu.Lock()
+		running--
+		mu.Unlock()
+	}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			job.Run()
+		}()
+	}
+	wg.Wait()
+	if runs != 3 {
+		t.Errorf("(expected) 3 runs != %d (actual)", runs)
+	}
+	// The second run waits for the first one, and the third for both.
+	if delay := job.Delay(); delay < 40*time.Millisecond {
+		t.Errorf("(expected) a delay of at least 40ms != %v (actual)", delay)
+	}
+
+	// Runs waiting when their context is done fail.
+	ctx, cancel := context.WithCancel(context.Background())
+	blocking := DelayIfStillRunning(ContextFuncJob(func(ctx context.Context) { <-ctx.Done() }))
+	go blocking.RunError(ctx)
+	time.Sleep(10 * time.Millisecond)
+	waiting, waitCancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer waitCancel()
+	if err := blocking.RunError(waiting); err != context.DeadlineExceeded {
+		t.Errorf("(expected) %v != %v (actual)", context.DeadlineExceeded, err)
+	}
+	cancel()
+}
+
+// tracing returns a JobWrapper, which appends its name to the trace around the
+// wrapped job.
+func tracing(trace *[]string, name string) JobWrapper {
+	return func(job Job) Job {
+		return ErrorFuncJob(func(ctx context.Context) error {
+			*trace = append(*trace, name)
+			return runJob(ctx, job)
+		})
+	}
+}
+
+func TestChain(t *testing.T) {
+	var trace []string
+	job := NewChain(tracing(&trace, "outer"), tracing(&trace, "inner")).Then(FuncJob(func() {
+		trace = append(trace, "job")
+	}))
+	job.Run()
+	if len(trace) != 3 || trace[0] != "outer" || trace[1] != "inner" || trace[2] != "job" {
+		t.Errorf("(expected) [outer inner job] != %v (actual)", trace)
+	}
+
+	if job := NewChain().Then(FuncJob(func() {})); job == nil {
+		t.Error("expected an empty chain to return the job")
+	}
+}
+
+// The chain of a Cron wraps the jobs of all entries, including updated ones.
+func TestWithChain(t *testing.T) {
+	var trace []string
+	cron := New(WithChain(tracing(&trace, "wrapped")))
+	id, _ := cron.AddFunc("@hourly", func() { trace = append(trace, "added") })
+	from := time.Date(2016, time.March, 1, 0, 0, 0, 0, time.UTC)
+	cron.Simulate(from, from.Add(time.Hour))
+	cron.UpdateJob(id, FuncJob(func() { trace = append(trace, "updated") }))
+	cron.Simulate(from, from.Add(time.Hour))
+
+	expected := []string{"wrapped", "added", "wrapped", "updated"}
+	if len(trace) != len(expected) {
+		t.Fatalf("(expected) %v != %v (actual)", expected, trace)
+	}
+	for i := range expected {
+		if trace[i] != expected[i] {
+			t.Errorf("(expected) %v != %v (actual)", expected, trace)
+		}
+	}
+}